@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// ConfigFile returns the configuration file the agent was started from, as it
+// is on disk now, so that the hub can tell whether it drifted from its own.
+// The file is read again as it may have been edited since the agent started.
+func (s *Server) ConfigFile(ctx context.Context, in *idl.ConfigFileAgentRequest) (*idl.ConfigFileAgentReply, error) {
+	if s.ConfigFilePath == "" {
+		return &idl.ConfigFileAgentReply{}, grpcStatus.Error(codes.FailedPrecondition, "the agent was not started from a configuration file")
+	}
+
+	contents, err := os.ReadFile(s.ConfigFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &idl.ConfigFileAgentReply{}, grpcStatus.Errorf(codes.NotFound, "configuration file %s does not exist", s.ConfigFilePath)
+	}
+	if err != nil {
+		return &idl.ConfigFileAgentReply{}, grpcStatus.Errorf(codes.Internal, "could not read configuration file %s: %v", s.ConfigFilePath, err)
+	}
+
+	reply := &idl.ConfigFileAgentReply{Sha256: utils.Checksum(contents)}
+
+	conf := &hub.Config{}
+	err = conf.Parse(contents)
+	if err != nil {
+		reply.ParseError = err.Error()
+		return reply, nil
+	}
+
+	reply.Config, err = json.Marshal(conf)
+	if err != nil {
+		return &idl.ConfigFileAgentReply{}, grpcStatus.Errorf(codes.Internal, "could not encode configuration: %v", err)
+	}
+
+	return reply, nil
+}
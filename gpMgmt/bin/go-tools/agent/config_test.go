@@ -0,0 +1,92 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	agent "github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfigFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	writeConfigFile := func(t *testing.T, contents string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "gp.conf")
+		err := os.WriteFile(path, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return path
+	}
+
+	t.Run("returns the checksum and the parsed configuration file", func(t *testing.T) {
+		contents := `{"hubPort": 4242, "agentPort": 8000, "hostnames": ["sdw1"], "hubLogDir": "/tmp",
+			"serviceName": "gp", "gphome": "/usr/local/gp", "Credentials": {"caCert": "/path/to/ca.pem"}}`
+		agentServer := agent.New(agent.Config{ConfigFilePath: writeConfigFile(t, contents)})
+
+		reply, err := agentServer.ConfigFile(context.Background(), &idl.ConfigFileAgentRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if reply.Sha256 != utils.Checksum([]byte(contents)) {
+			t.Fatalf("got %s, want the checksum of the file", reply.Sha256)
+		}
+		if reply.ParseError != "" {
+			t.Fatalf("unexpected parse error: %s", reply.ParseError)
+		}
+
+		var conf struct {
+			ConfigVersion int `json:"configVersion"`
+			AgentPort     int `json:"agentPort"`
+		}
+		err = json.Unmarshal(reply.Config, &conf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if conf.ConfigVersion != hub.ConfigVersion || conf.AgentPort != 8000 {
+			t.Fatalf("got %+v, want the file migrated to version %d", conf, hub.ConfigVersion)
+		}
+	})
+
+	t.Run("reports a file which cannot be parsed", func(t *testing.T) {
+		agentServer := agent.New(agent.Config{ConfigFilePath: writeConfigFile(t, `{"hubPort": 4242}`)})
+
+		reply, err := agentServer.ConfigFile(context.Background(), &idl.ConfigFileAgentRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `could not parse config file: missing required key "agentPort"`
+		if reply.ParseError != expected || len(reply.Config) != 0 {
+			t.Fatalf("got %+v, want parse error %q", reply, expected)
+		}
+	})
+
+	t.Run("errors out when the file does not exist", func(t *testing.T) {
+		agentServer := agent.New(agent.Config{ConfigFilePath: filepath.Join(t.TempDir(), "gp.conf")})
+
+		_, err := agentServer.ConfigFile(context.Background(), &idl.ConfigFileAgentRequest{})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want %v", err, codes.NotFound)
+		}
+
+		agentServer = agent.New(agent.Config{})
+		_, err = agentServer.ConfigFile(context.Background(), &idl.ConfigFileAgentRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", err, codes.FailedPrecondition)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// Exec runs the requested command through the shell, streaming its output back
+// as it is produced and finishing with its exit code.
+func (s *Server) Exec(in *idl.ExecAgentRequest, stream idl.Agent_ExecServer) error {
+	ctx := stream.Context()
+
+	// stdout and stderr are copied by separate goroutines, but a stream
+	// must not be written to concurrently.
+	var mutex sync.Mutex
+	send := func(reply *idl.ExecAgentReply) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return stream.Send(reply)
+	}
+
+	cmd := exec.CommandContext(ctx, constants.ShellPath, "-c", in.Command)
+	cmd.Stdout = outputWriter(func(p []byte) error {
+		return send(&idl.ExecAgentReply{Output: &idl.ExecAgentReply_Stdout{Stdout: p}})
+	})
+	cmd.Stderr = outputWriter(func(p []byte) error {
+		return send(&idl.ExecAgentReply{Output: &idl.ExecAgentReply_Stderr{Stderr: p}})
+	})
+
+	var exitCode int
+	var exitErr *exec.ExitError
+	err := cmd.Run()
+	switch {
+	case ctx.Err() != nil:
+		return grpcStatus.FromContextError(ctx.Err()).Err()
+	case err == nil:
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+	default:
+		return grpcStatus.Errorf(codes.Internal, "could not run command: %v", err)
+	}
+
+	return send(&idl.ExecAgentReply{Output: &idl.ExecAgentReply_ExitCode{ExitCode: int32(exitCode)}})
+}
+
+type outputWriter func(p []byte) error
+
+func (w outputWriter) Write(p []byte) (int, error) {
+	err := w(p)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,72 @@
+package agent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+func TestExec(t *testing.T) {
+	runExec := func(t *testing.T, ctx context.Context, command string) ([]*idl.ExecAgentReply, error) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var replies []*idl.ExecAgentReply
+		stream := mock_idl.NewMockAgent_ExecServer(ctrl)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.ExecAgentReply) error {
+			replies = append(replies, reply)
+			return nil
+		}).AnyTimes()
+
+		agentServer := agent.New(agent.Config{})
+		err := agentServer.Exec(&idl.ExecAgentRequest{Command: command}, stream)
+
+		return replies, err
+	}
+
+	t.Run("streams the output of the command followed by its exit code", func(t *testing.T) {
+		replies, err := runExec(t, context.Background(), "echo out; sleep 0.1; echo err >&2; exit 3")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.ExecAgentReply{
+			{Output: &idl.ExecAgentReply_Stdout{Stdout: []byte("out\n")}},
+			{Output: &idl.ExecAgentReply_Stderr{Stderr: []byte("err\n")}},
+			{Output: &idl.ExecAgentReply_ExitCode{ExitCode: 3}},
+		}
+		if !reflect.DeepEqual(replies, expected) {
+			t.Fatalf("got %+v, want %+v", replies, expected)
+		}
+	})
+
+	t.Run("reports a zero exit code on success", func(t *testing.T) {
+		replies, err := runExec(t, context.Background(), "true")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.ExecAgentReply{
+			{Output: &idl.ExecAgentReply_ExitCode{ExitCode: 0}},
+		}
+		if !reflect.DeepEqual(replies, expected) {
+			t.Fatalf("got %+v, want %+v", replies, expected)
+		}
+	})
+
+	t.Run("stops the command when the caller goes away", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := runExec(t, ctx, "sleep 10")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
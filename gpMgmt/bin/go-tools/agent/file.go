@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// PutFile receives a file and writes it to the path given in its FileInfo.
+// The contents are written to a temporary file next to the destination which
+// is only moved into place once the checksum has been verified, so a failed
+// transfer never leaves a partially written file behind.
+func (s *Server) PutFile(stream idl.Agent_PutFileServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := request.GetInfo()
+	if info == nil {
+		return grpcStatus.Error(codes.InvalidArgument, "expected file info before the file contents")
+	}
+	if !filepath.IsAbs(info.Path) {
+		return grpcStatus.Errorf(codes.InvalidArgument, "destination path %s is not absolute", info.Path)
+	}
+
+	dir := filepath.Dir(info.Path)
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, fmt.Sprintf(".%s.*", filepath.Base(info.Path)))
+	if err != nil {
+		return fmt.Errorf("could not create temporary file in %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once the file has been renamed
+	defer tmp.Close()
+
+	hash := sha256.New()
+	writer := io.MultiWriter(tmp, hash)
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if request.GetInfo() != nil {
+			return grpcStatus.Error(codes.InvalidArgument, "received file info more than once")
+		}
+
+		_, err = writer.Write(request.GetChunk())
+		if err != nil {
+			return fmt.Errorf("could not write to %s: %w", tmp.Name(), err)
+		}
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if checksum != info.Sha256 {
+		return grpcStatus.Errorf(codes.DataLoss, "checksum mismatch for %s: got %s, want %s", info.Path, checksum, info.Sha256)
+	}
+
+	err = tmp.Chmod(os.FileMode(info.Mode).Perm())
+	if err != nil {
+		return fmt.Errorf("could not set mode of %s: %w", info.Path, err)
+	}
+
+	if info.Owner != "" {
+		err = utils.ChownFile(tmp.Name(), info.Owner)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return fmt.Errorf("could not write to %s: %w", tmp.Name(), err)
+	}
+
+	err = os.Rename(tmp.Name(), info.Path)
+	if err != nil {
+		return fmt.Errorf("could not move %s into place: %w", info.Path, err)
+	}
+
+	return stream.SendAndClose(&idl.PutFileReply{})
+}
+
+// GetFile sends the requested file as its FileInfo followed by its contents.
+func (s *Server) GetFile(in *idl.GetFileRequest, stream idl.Agent_GetFileServer) error {
+	info, err := utils.NewFileInfo(in.Path)
+	if os.IsNotExist(err) {
+		return grpcStatus.Errorf(codes.NotFound, "file %s does not exist", in.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read file %s: %w", in.Path, err)
+	}
+
+	err = stream.Send(&idl.GetFileReply{Data: &idl.GetFileReply_Info{Info: info}})
+	if err != nil {
+		return err
+	}
+
+	return utils.ReadFileChunks(in.Path, func(chunk []byte) error {
+		return stream.Send(&idl.GetFileReply{Data: &idl.GetFileReply_Chunk{Chunk: chunk}})
+	})
+}
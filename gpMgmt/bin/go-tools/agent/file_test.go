@@ -0,0 +1,198 @@
+package agent_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func newAgentClient(t *testing.T) idl.AgentClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	t.Cleanup(server.Stop)
+
+	idl.RegisterAgentServer(server, &agent.Server{})
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("server exited with error: %v", err)
+		}
+	}()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return idl.NewAgentClient(conn)
+}
+
+func putFile(client idl.AgentClient, requests ...*idl.PutFileRequest) error {
+	stream, err := client.PutFile(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		err = stream.Send(request)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+func TestPutFile(t *testing.T) {
+	client := newAgentClient(t)
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "source")
+	err := os.WriteFile(source, []byte("some contents"), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	info, err := utils.NewFileInfo(source)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("writes the file once its checksum has been verified", func(t *testing.T) {
+		destination := filepath.Join(dir, "subdir", "destination")
+		info.Path = destination
+
+		err := putFile(client,
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Info{Info: info}},
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Chunk{Chunk: []byte("some ")}},
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Chunk{Chunk: []byte("contents")}},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, err := os.ReadFile(destination)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(contents) != "some contents" {
+			t.Fatalf("got %q, want %q", contents, "some contents")
+		}
+	})
+
+	t.Run("does not write the file when the checksum does not match", func(t *testing.T) {
+		destination := filepath.Join(dir, "corrupted")
+		info.Path = destination
+
+		err := putFile(client,
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Info{Info: info}},
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Chunk{Chunk: []byte("other contents")}},
+		)
+		if status.Code(err) != codes.DataLoss {
+			t.Fatalf("got %v, want a data loss error", err)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		for _, entry := range entries {
+			if entry.Name() != "source" && entry.Name() != "subdir" {
+				t.Fatalf("unexpected file %s left behind", entry.Name())
+			}
+		}
+	})
+
+	t.Run("errors out when the file info is not sent first", func(t *testing.T) {
+		err := putFile(client,
+			&idl.PutFileRequest{Data: &idl.PutFileRequest_Chunk{Chunk: []byte("some contents")}},
+		)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want an invalid argument error", err)
+		}
+	})
+}
+
+func TestGetFile(t *testing.T) {
+	client := newAgentClient(t)
+	dir := t.TempDir()
+
+	t.Run("sends the file info followed by the contents", func(t *testing.T) {
+		path := filepath.Join(dir, "file")
+		err := os.WriteFile(path, []byte("some contents"), 0640)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		stream, err := client.GetFile(context.Background(), &idl.GetFileRequest{Path: path})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var info *idl.FileInfo
+		var contents []byte
+		for {
+			reply, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			if reply.GetInfo() != nil {
+				info = reply.GetInfo()
+			}
+			contents = append(contents, reply.GetChunk()...)
+		}
+
+		expected, err := utils.NewFileInfo(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Sha256 != expected.Sha256 || info.Mode != 0640 || info.Owner != expected.Owner {
+			t.Fatalf("got %+v, want %+v", info, expected)
+		}
+
+		if string(contents) != "some contents" {
+			t.Fatalf("got %q, want %q", contents, "some contents")
+		}
+	})
+
+	t.Run("errors out when the file does not exist", func(t *testing.T) {
+		stream, err := client.GetFile(context.Background(), &idl.GetFileRequest{Path: filepath.Join(dir, "does-not-exist")})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = stream.Recv()
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want a not found error", err)
+		}
+	})
+}
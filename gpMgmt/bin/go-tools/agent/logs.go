@@ -0,0 +1,181 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// CollectLogs sends a tar archive of the agent's own logs, the log of its
+// service and, if requested, the segment logs on this host. Each kind of log is
+// kept in its own directory of the archive.
+func (s *Server) CollectLogs(in *idl.CollectLogsAgentRequest, stream idl.Agent_CollectLogsServer) error {
+	ctx := stream.Context()
+
+	since, until := time.Time{}, time.Time{}
+	if in.Since != 0 {
+		since = time.Unix(in.Since, 0)
+	}
+	if in.Until != 0 {
+		until = time.Unix(in.Until, 0)
+	}
+
+	for _, pattern := range in.SegmentLogDirs {
+		if !filepath.IsAbs(pattern) {
+			return grpcStatus.Errorf(codes.InvalidArgument, "segment log directory %s is not absolute", pattern)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return grpcStatus.Errorf(codes.InvalidArgument, "invalid segment log directory pattern %s: %v", pattern, err)
+		}
+	}
+
+	buffer := bufio.NewWriterSize(chunkWriter(func(chunk []byte) error {
+		return stream.Send(&idl.CollectLogsAgentReply{Chunk: chunk})
+	}), constants.FileChunkSize)
+	archive := utils.NewLogArchive(buffer)
+
+	if s.LogDir != "" {
+		_, err := archive.AddLogFiles("agent", s.LogDir, "gp_agent*", since)
+		if err != nil {
+			return err
+		}
+	}
+
+	serviceName := fmt.Sprintf("%s_agent", s.ServiceName)
+	if cmd := platform.GetServiceLogCommand(serviceName, since, until); cmd != nil {
+		// The journal may not be available to the service user; that should
+		// not prevent collecting the other logs.
+		output, err := cmd.Output()
+		if err != nil {
+			utils.LogWarn(ctx, "Could not read the log of service %s: %v", serviceName, err)
+			output = []byte(fmt.Sprintf("could not read the log of service %s: %v\n", serviceName, err))
+		}
+
+		err = archive.AddBytes(filepath.Join("journal", serviceName+".log"), output)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pattern := range in.SegmentLogDirs {
+		dirs, _ := filepath.Glob(pattern) // the pattern was validated above
+		for _, dir := range dirs {
+			prefix := filepath.Join("segments", strings.TrimPrefix(dir, string(filepath.Separator)))
+			_, err := archive.AddLogFiles(prefix, dir, "*", since)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		return err
+	}
+
+	return buffer.Flush()
+}
+
+// TailLogs sends the last entries of the agent's log and of the logs of the
+// requested segments on this host, following them as they are written to if
+// requested. The logs are tailed concurrently; if any of them cannot be
+// tailed, the others are stopped too.
+func (s *Server) TailLogs(in *idl.TailLogsAgentRequest, stream idl.Agent_TailLogsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	filter, err := utils.NewLogFilter(in.Severity, in.Pattern)
+	if err != nil {
+		return grpcStatus.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var dataDirs []string
+	for _, pattern := range in.DataDirs {
+		if !filepath.IsAbs(pattern) {
+			return grpcStatus.Errorf(codes.InvalidArgument, "data directory %s is not absolute", pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return grpcStatus.Errorf(codes.InvalidArgument, "invalid data directory pattern %s: %v", pattern, err)
+		}
+		dataDirs = append(dataDirs, matches...)
+	}
+
+	// Entries from all the logs are multiplexed onto the one stream, which
+	// must not be written to concurrently.
+	var mutex sync.Mutex
+	send := func(reply *idl.TailLogsAgentReply) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return stream.Send(reply)
+	}
+
+	options := utils.TailOptions{Filter: filter, Lines: int(in.Lines), Follow: in.Follow}
+	tail := func(dataDir string, latest func() (string, error), options utils.TailOptions) error {
+		err := utils.TailLog(ctx, latest, options, func(entry utils.LogEntry) error {
+			return send(&idl.TailLogsAgentReply{DataDir: dataDir, Severity: entry.Severity, Text: entry.Text})
+		})
+		if err != nil {
+			cancel()
+		}
+
+		return err
+	}
+
+	errs := make([]error, len(dataDirs)+1)
+	var wg sync.WaitGroup
+	wg.Add(len(dataDirs) + 1)
+	go func() {
+		defer wg.Done()
+		errs[0] = tail("", func() (string, error) { return utils.CurrentLogFile(s.LogDir, constants.AgentCommandPath) }, options)
+	}()
+	for i, dataDir := range dataDirs {
+		i, dataDir := i, dataDir
+		segmentOptions := options
+		segmentOptions.CSV = true
+		go func() {
+			defer wg.Done()
+			errs[i+1] = tail(dataDir, func() (string, error) { return utils.LatestSegmentLog(dataDir) }, segmentOptions)
+		}()
+	}
+	wg.Wait()
+
+	if err := stream.Context().Err(); err != nil {
+		return grpcStatus.FromContextError(err).Err()
+	}
+	for _, err := range errs {
+		if err != nil {
+			return grpcStatus.Errorf(codes.Internal, "could not tail logs: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// chunkWriter sends everything written to it as a single chunk. Writes must
+// be buffered to get chunks of a reasonable size.
+type chunkWriter func(chunk []byte) error
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	// The buffer behind p is reused for the next chunk
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	err := w(chunk)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,211 @@
+package agent_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func writeLog(t *testing.T, path string, contents string) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = os.WriteFile(path, []byte(contents), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
+
+func TestCollectLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	// collectLogs returns the contents of the files in the archive sent by the
+	// agent by their names.
+	collectLogs := func(t *testing.T, logDir string, request *idl.CollectLogsAgentRequest) (map[string]string, error) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		agent.SetPlatform(&testutils.MockPlatform{LogCmd: exec.Command("echo", "journal entry")})
+		defer agent.ResetPlatform()
+
+		var archive bytes.Buffer
+		stream := mock_idl.NewMockAgent_CollectLogsServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.CollectLogsAgentReply) error {
+			archive.Write(reply.Chunk)
+			return nil
+		}).AnyTimes()
+
+		agentServer := agent.New(agent.Config{LogDir: logDir, ServiceName: "gp"})
+		err := agentServer.CollectLogs(request, stream)
+		if err != nil {
+			return nil, err
+		}
+
+		files := make(map[string]string)
+		tr := tar.NewReader(&archive)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return files, nil
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			contents, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			files[header.Name] = string(contents)
+		}
+	}
+
+	t.Run("archives each kind of log in its own directory", func(t *testing.T) {
+		logDir := t.TempDir()
+		writeLog(t, filepath.Join(logDir, "gp_agent.log"), "agent entry\n")
+		writeLog(t, filepath.Join(logDir, "gp_hub.log"), "hub entry\n")
+
+		dataDir := t.TempDir()
+		segmentLogDir := filepath.Join(dataDir, "seg0", "log")
+		writeLog(t, filepath.Join(segmentLogDir, "gpdb.csv"), "segment entry\n")
+
+		files, err := collectLogs(t, logDir, &idl.CollectLogsAgentRequest{
+			SegmentLogDirs: []string{filepath.Join(dataDir, "seg*", "log")},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := map[string]string{
+			"agent/gp_agent.log":   "agent entry\n",
+			"journal/gp_agent.log": "journal entry\n",
+			filepath.Join("segments", strings.TrimPrefix(segmentLogDir, "/"), "gpdb.csv"): "segment entry\n",
+		}
+		if !reflect.DeepEqual(files, expected) {
+			t.Fatalf("got %v, want %v", files, expected)
+		}
+	})
+
+	t.Run("only archives the logs modified since the given time", func(t *testing.T) {
+		logDir := t.TempDir()
+		writeLog(t, filepath.Join(logDir, "gp_agent.log"), "new entry\n")
+		old := filepath.Join(logDir, "gp_agent.log.2023-01-01T00-00-00")
+		writeLog(t, old, "old entry\n")
+		modified := time.Now().Add(-2 * time.Hour)
+		err := os.Chtimes(old, modified, modified)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files, err := collectLogs(t, logDir, &idl.CollectLogsAgentRequest{Since: time.Now().Add(-time.Hour).Unix()})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := map[string]string{
+			"agent/gp_agent.log":   "new entry\n",
+			"journal/gp_agent.log": "journal entry\n",
+		}
+		if !reflect.DeepEqual(files, expected) {
+			t.Fatalf("got %v, want %v", files, expected)
+		}
+	})
+
+	t.Run("rejects segment log directories which are relative or invalid patterns", func(t *testing.T) {
+		for _, dir := range []string{"seg0/log", "/data/seg[0/log"} {
+			_, err := collectLogs(t, t.TempDir(), &idl.CollectLogsAgentRequest{SegmentLogDirs: []string{dir}})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("got %v for %s, want %v", err, dir, codes.InvalidArgument)
+			}
+		}
+	})
+}
+
+func TestTailLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	tailLogs := func(t *testing.T, logDir string, request *idl.TailLogsAgentRequest) ([]*idl.TailLogsAgentReply, error) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var replies []*idl.TailLogsAgentReply
+		stream := mock_idl.NewMockAgent_TailLogsServer(ctrl)
+		stream.EXPECT().Context().Return(context.Background()).AnyTimes()
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.TailLogsAgentReply) error {
+			replies = append(replies, reply)
+			return nil
+		}).AnyTimes()
+
+		agentServer := agent.New(agent.Config{LogDir: logDir, ServiceName: "gp"})
+		err := agentServer.TailLogs(request, stream)
+
+		return replies, err
+	}
+
+	t.Run("sends the last entries of the agent log and of the segment logs", func(t *testing.T) {
+		logDir := t.TempDir()
+		writeLog(t, filepath.Join(logDir, "gp_agent.log"), "2023-01-01 00:00:00.000000 sdw1  [INFO] one\n"+
+			"2023-01-01 00:00:01.000000 sdw1  [ERROR] two\n")
+
+		dataDir := filepath.Join(t.TempDir(), "seg0")
+		writeLog(t, filepath.Join(dataDir, "log", "gpdb-2023-01-01_000000.csv"),
+			`2023-01-01 00:00:00.000000 UTC,"gpadmin","postgres",p1,th1,,,,,,,seg0,,,,,"ERROR","00000","three",,,,,,,0,,"postgres.c",1,`+"\n")
+
+		replies, err := tailLogs(t, logDir, &idl.TailLogsAgentRequest{
+			DataDirs: []string{filepath.Join(filepath.Dir(dataDir), "seg*")},
+			Severity: "ERROR",
+			Lines:    10,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		// The logs are tailed concurrently, so their entries may be interleaved
+		entries := make(map[string][]string)
+		for _, reply := range replies {
+			if reply.Severity != "ERROR" {
+				t.Fatalf("got %+v, want only errors", reply)
+			}
+			entries[reply.DataDir] = append(entries[reply.DataDir], reply.Text)
+		}
+		if len(entries) != 2 || !reflect.DeepEqual(entries[""], []string{"2023-01-01 00:00:01.000000 sdw1  [ERROR] two"}) ||
+			len(entries[dataDir]) != 1 || !strings.Contains(entries[dataDir][0], ",three,") {
+			t.Fatalf("got %v, want the errors of the agent log and of %s", entries, dataDir)
+		}
+	})
+
+	t.Run("rejects data directories which are relative or invalid patterns", func(t *testing.T) {
+		for _, dir := range []string{"seg0", "/data/seg[0"} {
+			_, err := tailLogs(t, t.TempDir(), &idl.TailLogsAgentRequest{DataDirs: []string{dir}})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("got %v for %s, want %v", err, dir, codes.InvalidArgument)
+			}
+		}
+	})
+}
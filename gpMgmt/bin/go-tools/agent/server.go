@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
 
+	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 var (
@@ -19,8 +27,16 @@ var (
 type Config struct {
 	Port        int
 	ServiceName string
-
-	Credentials utils.Credentials
+	// LogDir is where the audit log is written; no audit log is kept if it is empty
+	LogDir string
+	// MetricsPort is the port metrics are served on over HTTP; zero disables them
+	MetricsPort int
+
+	Credentials   utils.Credentials
+	Authorization *utils.AuthorizationPolicy
+	// ConfigFilePath is the file the configuration was loaded from, which is
+	// reported to the hub for detecting drift
+	ConfigFilePath string
 }
 
 type Server struct {
@@ -29,12 +45,19 @@ type Server struct {
 	mutex      sync.Mutex
 	grpcServer *grpc.Server
 	listener   net.Listener
+	health     *health.Server
 }
 
 func New(conf Config) *Server {
-	return &Server{
+	s := &Server{
 		Config: &conf,
+		health: health.NewServer(),
 	}
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	s.health.SetServingStatus(constants.HealthServing, healthpb.HealthCheckResponse_NOT_SERVING)
+	s.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_UNKNOWN)
+
+	return s
 }
 
 func (s *Server) Stop(ctx context.Context, in *idl.StopAgentRequest) (*idl.StopAgentReply, error) {
@@ -48,20 +71,52 @@ func (s *Server) Start() error {
 		return fmt.Errorf("could not listen on port %d: %w", s.Port, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
+	err = s.Authorization.Validate()
+	if err != nil {
+		listener.Close()
+		return err
 	}
 
-	credentials, err := s.Credentials.LoadServerCredentials()
+	credentials, err := s.Credentials.LoadServerCredentials(utils.RoleAgent)
 	if err != nil {
+		s.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_NOT_SERVING)
 		listener.Close()
 		return err
 	}
+	s.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_SERVING)
+	// Certificates are reloaded when their files change, or on demand through SIGHUP
+	defer utils.ReloadCredentialsOnSignal()()
+
+	var auditLog *utils.AuditLog
+	if s.LogDir != "" {
+		auditLog, err = utils.OpenAuditLog(filepath.Join(s.LogDir, constants.AgentAuditFileName))
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer auditLog.Close()
+	}
+
+	var metrics *utils.Metrics
+	if s.MetricsPort != 0 {
+		metrics = utils.NewMetrics("agent")
+		metricsServer, err := utils.ServeMetrics(s.MetricsPort, metrics)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer metricsServer.Close()
+	}
 
 	grpcServer := grpc.NewServer(
 		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor),
+		grpc.ChainUnaryInterceptor(utils.UnaryRequestIDInterceptor(), auditLog.UnaryInterceptor(), metrics.UnaryInterceptor(), utils.UnaryAuthorizationInterceptor(s.Authorization)),
+		grpc.ChainStreamInterceptor(utils.StreamRequestIDInterceptor(), auditLog.StreamInterceptor(), metrics.StreamInterceptor(), utils.StreamAuthorizationInterceptor(s.Authorization)),
+		// Allow the keepalive pings the hub sends on idle connections
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             constants.MinKeepaliveTime,
+			PermitWithoutStream: true,
+		}),
 	)
 
 	s.mutex.Lock()
@@ -70,8 +125,11 @@ func (s *Server) Start() error {
 	s.mutex.Unlock()
 
 	idl.RegisterAgentServer(grpcServer, s)
+	healthpb.RegisterHealthServer(grpcServer, s.health)
 	reflection.Register(grpcServer)
 
+	s.health.SetServingStatus(constants.HealthServing, healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -85,6 +143,7 @@ func (s *Server) Shutdown() {
 	defer s.mutex.Unlock()
 
 	if s.grpcServer != nil {
+		s.health.Shutdown()
 		s.grpcServer.Stop()
 	}
 }
@@ -95,7 +154,57 @@ func (s *Server) Status(ctx context.Context, in *idl.StatusAgentRequest) (*idl.S
 		return &idl.StatusAgentReply{}, fmt.Errorf("could not get agent status: %w", err)
 	}
 
-	return &idl.StatusAgentReply{Status: status.Status, Uptime: status.Uptime, Pid: uint32(status.Pid)}, nil
+	return &idl.StatusAgentReply{Status: status.Status, Uptime: status.Uptime, Pid: uint32(status.Pid), Certificate: s.certificateInfo(ctx)}, nil
+}
+
+// HostInfo describes the state of the agent host, for judging its health
+// before maintenance.
+func (s *Server) HostInfo(ctx context.Context, in *idl.HostInfoAgentRequest) (*idl.HostInfoAgentReply, error) {
+	for _, pattern := range in.DataDirs {
+		if !filepath.IsAbs(pattern) {
+			return &idl.HostInfoAgentReply{}, grpcStatus.Errorf(codes.InvalidArgument, "data directory %s is not absolute", pattern)
+		}
+	}
+
+	info, err := utils.GetHostInfo(in.DataDirs)
+	if err != nil {
+		return &idl.HostInfoAgentReply{}, fmt.Errorf("could not get host info: %w", err)
+	}
+
+	return &idl.HostInfoAgentReply{Info: info}, nil
+}
+
+// Version answers the handshake of the hub with the version of the agent. The
+// hub decides whether it can work with the agent, but a difference is logged
+// here too, as it is easier to spot on the host which needs upgrading.
+func (s *Server) Version(ctx context.Context, in *idl.VersionAgentRequest) (*idl.VersionAgentReply, error) {
+	if in.Hub != nil {
+		if err := utils.CheckVersionCompatibility(in.Hub); err != nil {
+			utils.LogWarn(ctx, "The hub is incompatible with this agent: %v", err)
+		} else if utils.VersionDiffers(in.Hub) {
+			utils.LogWarn(ctx, "The hub on host %s runs gp %s (commit %s), but this agent runs gp %s (commit %s)",
+				in.Hub.Host, in.Hub.Version, in.Hub.Commit, utils.Version, utils.Commit)
+		}
+	}
+
+	return &idl.VersionAgentReply{Info: utils.GetVersionInfo()}, nil
+}
+
+// certificateInfo describes the certificate the agent currently presents, or
+// returns nil if it cannot be determined.
+func (s *Server) certificateInfo(ctx context.Context) *idl.CertificateInfo {
+	if s.Config == nil || s.Credentials == nil {
+		return nil
+	}
+
+	cert, err := s.Credentials.Certificate(utils.RoleAgent)
+	if err != nil {
+		utils.LogDebug(ctx, "Could not get agent certificate: %v", err)
+		return nil
+	}
+
+	hostname, _ := os.Hostname()
+	return utils.NewCertificateInfo(hostname, cert)
 }
 
 func (s *Server) GetStatus() (*idl.ServiceStatus, error) {
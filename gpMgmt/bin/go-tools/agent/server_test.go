@@ -1,6 +1,7 @@
 package agent_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -14,6 +15,10 @@ import (
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func TestStartServer(t *testing.T) {
@@ -43,6 +48,44 @@ func TestStartServer(t *testing.T) {
 		}
 	})
 
+	t.Run("reports itself as serving through the health service", func(t *testing.T) {
+		credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
+		agentServer := agent.New(agent.Config{
+			Port:        constants.DefaultAgentPort,
+			ServiceName: constants.DefaultServiceName,
+			Credentials: credentials,
+		})
+
+		go func() {
+			_ = agentServer.Start()
+		}()
+		defer agentServer.Shutdown()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, err := grpc.DialContext(ctx, fmt.Sprintf("localhost:%d", constants.DefaultAgentPort),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithBlock(),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer conn.Close()
+
+		client := healthpb.NewHealthClient(conn)
+		for _, service := range []string{"", constants.HealthServing, constants.HealthCredentials} {
+			reply, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			if reply.Status != healthpb.HealthCheckResponse_SERVING {
+				t.Fatalf("got %v for %q, want %v", reply.Status, service, healthpb.HealthCheckResponse_SERVING)
+			}
+		}
+	})
+
 	t.Run("failed to start if the load credential fail", func(t *testing.T) {
 		expected := errors.New("error")
 		credentials := &testutils.MockCredentials{
@@ -70,6 +113,23 @@ func TestStartServer(t *testing.T) {
 		}
 	})
 
+	t.Run("fails to start with an invalid authorization policy", func(t *testing.T) {
+		agentServer := agent.New(agent.Config{
+			Port:        constants.DefaultAgentPort,
+			ServiceName: constants.DefaultServiceName,
+			Credentials: &testutils.MockCredentials{},
+			Authorization: &utils.AuthorizationPolicy{
+				Identities: map[string][]string{"gp-hub": {"agent"}},
+			},
+		})
+
+		err := agentServer.Start()
+		expected := "invalid authorization policy: identity gp-hub refers to undefined role agent"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
 	t.Run("listen fails when starting the server", func(t *testing.T) {
 		credentials := &testutils.MockCredentials{}
 
@@ -19,7 +19,7 @@ func agentCmd() *cobra.Command {
 }
 
 func RunAgent(cmd *cobra.Command, args []string) (err error) {
-	agentConf := agent.Config{Port: Conf.AgentPort, ServiceName: Conf.ServiceName, Credentials: Conf.Credentials}
+	agentConf := agent.Config{Port: Conf.AgentPort, ServiceName: Conf.ServiceName, LogDir: Conf.LogDir, MetricsPort: Conf.AgentMetricsPort, Credentials: Conf.Credentials, Authorization: Conf.Authorization, ConfigFilePath: ConfigFilePath}
 	a := agent.New(agentConf)
 	err = a.Start()
 	if err != nil {
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditSince     string
+	auditAgent     bool
+	auditRequestID string
+)
+
+func auditCmd() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit log of management RPCs",
+	}
+
+	auditCmd.AddCommand(auditShowCmd())
+
+	return auditCmd
+}
+
+func auditShowCmd() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show [--since <time>] [--agent]",
+		Short: "Show the RPCs recorded in the audit log",
+		Long: `Show the RPCs recorded in the hub audit log on this host, or in the agent
+audit log with --agent. The --since flag accepts a duration such as 24h, a
+date such as 2006-01-02, or an RFC 3339 timestamp.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunAuditShow,
+	}
+
+	showCmd.Flags().StringVar(&auditSince, "since", "", `Only show RPCs made after this time`)
+	showCmd.Flags().BoolVar(&auditAgent, "agent", false, `Show the agent audit log instead of the hub audit log`)
+	showCmd.Flags().StringVar(&auditRequestID, "request-id", "", `Only show RPCs made on behalf of the CLI command with this request ID`)
+
+	return showCmd
+}
+
+func RunAuditShow(cmd *cobra.Command, args []string) error {
+	since, err := ParseSince(auditSince, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid value for --since: %w", err)
+	}
+
+	fileName := constants.HubAuditFileName
+	if auditAgent {
+		fileName = constants.AgentAuditFileName
+	}
+
+	file, err := os.Open(filepath.Join(Conf.LogDir, fileName))
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+	defer file.Close()
+
+	records, err := utils.ReadAuditLog(file, since)
+	if err != nil {
+		return err
+	}
+
+	if auditRequestID != "" {
+		matching := make([]utils.AuditRecord, 0)
+		for _, record := range records {
+			if record.RequestID == auditRequestID {
+				matching = append(matching, record)
+			}
+		}
+		records = matching
+	}
+
+	PrintAuditRecords(os.Stdout, records)
+
+	return nil
+}
+
+// ParseSince interprets a --since or --until flag relative to now. An empty
+// value is returned as the zero time, which does not bound anything.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+
+	if since, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return since, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: expected a duration, a date or an RFC 3339 timestamp", value)
+}
+
+func PrintAuditRecords(outfile io.Writer, records []utils.AuditRecord) {
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "TIME\tIDENTITY\tPEER\tMETHOD\tCODE\tDURATION\tREQUEST")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", record.Time.Local().Format(time.RFC3339),
+			record.Identity, record.Peer, record.Method, record.Code, record.Duration, record.Request)
+	}
+	w.Flush()
+}
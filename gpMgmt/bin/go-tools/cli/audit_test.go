@@ -0,0 +1,72 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("accepts durations, dates and timestamps", func(t *testing.T) {
+		cases := map[string]time.Time{
+			"":                     {},
+			"90m":                  now.Add(-90 * time.Minute),
+			"2023-06-14T08:30:00Z": time.Date(2023, 6, 14, 8, 30, 0, 0, time.UTC),
+			"2023-06-01":           time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local),
+		}
+
+		for value, expected := range cases {
+			result, err := cli.ParseSince(value, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if !result.Equal(expected) {
+				t.Fatalf("got %v for %q, want %v", result, value, expected)
+			}
+		}
+	})
+
+	t.Run("errors out on anything else", func(t *testing.T) {
+		_, err := cli.ParseSince("yesterday", now)
+		expected := `invalid time "yesterday": expected a duration, a date or an RFC 3339 timestamp`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestPrintAuditRecords(t *testing.T) {
+	t.Run("prints one row per record", func(t *testing.T) {
+		records := []utils.AuditRecord{{
+			Time:     time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC),
+			Identity: "gp-cli",
+			Peer:     "10.0.0.1:45678",
+			Method:   "/idl.Hub/StopAgents",
+			Request:  "{}",
+			Duration: "1.5ms",
+			Code:     "OK",
+		}}
+
+		var buf bytes.Buffer
+		cli.PrintAuditRecords(&buf, records)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2", len(lines))
+		}
+		if !strings.HasPrefix(lines[0], "TIME") {
+			t.Fatalf("got %q, want a header row", lines[0])
+		}
+		fields := strings.Fields(lines[1])
+		expected := []string{"gp-cli", "10.0.0.1:45678", "/idl.Hub/StopAgents", "OK", "1.5ms", "{}"}
+		if strings.Join(fields[1:], " ") != strings.Join(expected, " ") {
+			t.Fatalf("got %v, want %v", fields[1:], expected)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	IssueCertificates        = IssueCertificatesFunc
+	ConfirmAgentCertificates = ConfirmAgentCertificatesFunc
+
+	certsDir      string
+	certsForce    bool
+	certsValidity time.Duration
+)
+
+func certsCmd() *cobra.Command {
+	certsCmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Manage the certificates of the cluster",
+	}
+
+	certsCmd.AddCommand(
+		certsInitCmd(),
+		certsIssueCmd(),
+		certsRotateCmd(),
+	)
+
+	return certsCmd
+}
+
+func certsInitCmd() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a certificate authority for the cluster",
+		Long: `Create a certificate authority for the cluster. Run this before gp configure,
+which then issues the hub, agent and CLI certificates from it. gp configure
+creates one in the default directory itself if this was not run.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			hubLogDir = constants.DefaultHubLogDir // no configuration file exists yet
+			return InitializeLogger(cmd, args)
+		},
+		RunE: RunCertsInit,
+	}
+
+	initCmd.Flags().StringVar(&certsDir, "dir", filepath.Join(os.Getenv("GPHOME"), constants.CertificatesDir), `Directory in which to create the certificate authority`)
+	initCmd.Flags().DurationVar(&certsValidity, "validity", constants.CAValidity, `How long the certificate authority is valid for`)
+	initCmd.Flags().BoolVar(&certsForce, "force", false, `Replace an existing certificate authority`)
+
+	return initCmd
+}
+
+func RunCertsInit(cmd *cobra.Command, args []string) error {
+	certPath := filepath.Join(certsDir, utils.CACertFileName)
+	keyPath := filepath.Join(certsDir, utils.CAKeyFileName)
+
+	if _, err := os.Stat(keyPath); err == nil && !certsForce {
+		return fmt.Errorf("certificate authority already exists at %s; use --force to replace it", keyPath)
+	}
+
+	return createCertificateAuthority(certPath, keyPath, certsValidity)
+}
+
+// EnsureCertificateAuthority creates a certificate authority at the given
+// paths unless its private key already exists, so that gp configure does not
+// need gp certs init to be run first.
+func EnsureCertificateAuthority(certPath string, keyPath string) error {
+	if _, err := os.Stat(keyPath); err == nil {
+		return nil
+	}
+
+	// Only the directory of the CA is created, so that a wrong gphome is
+	// still reported
+	err := os.Mkdir(filepath.Dir(certPath), 0755)
+	if err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("could not create certificate directory: %w", err)
+	}
+
+	return createCertificateAuthority(certPath, keyPath, constants.CAValidity)
+}
+
+func createCertificateAuthority(certPath string, keyPath string, validity time.Duration) error {
+	ca, err := utils.NewCertificateAuthority("Greenplum cluster CA", validity)
+	if err != nil {
+		return err
+	}
+
+	err = ca.Write(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Created certificate authority %s", certPath)
+
+	return nil
+}
+
+func certsIssueCmd() *cobra.Command {
+	issueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue the hub, agent and CLI certificates",
+		Long: `Issue new hub, agent and CLI certificates from the certificate authority of
+the cluster, and distribute the agent certificates to the agent hosts. Each
+agent certificate is only valid for the name of its own host.`,
+		PreRunE: InitializeCommand,
+		RunE:    RunCertsIssue,
+	}
+
+	issueCmd.Flags().DurationVar(&certsValidity, "validity", constants.CertificateValidity, `How long the certificates are valid for`)
+
+	return issueCmd
+}
+
+func RunCertsIssue(cmd *cobra.Command, args []string) error {
+	credentials, ok := Conf.Credentials.(*utils.GpCredentials)
+	if !ok {
+		return errors.New("certificates can only be issued for file based credentials")
+	}
+
+	_, err := IssueCertificates(credentials, Conf.Hostnames, certsValidity)
+	if err != nil {
+		return err
+	}
+
+	err = Conf.Write(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = distributeConfig(ConfigFilePath, Conf.Hostnames, hubFileWriter(Conf))
+	if err != nil {
+		return err
+	}
+	gplog.Info("Issued certificates for %d hosts; restart the hub and agents to use them", len(Conf.Hostnames))
+
+	return nil
+}
+
+// IssueCertificatesFunc issues the certificates of all roles from the CA in
+// credentials and records their paths there. They are written next to the CA
+// certificate, on this host for the hub and CLI and on each agent host for the
+// agents. The fingerprints of the agent certificates are returned by host.
+func IssueCertificatesFunc(credentials *utils.GpCredentials, hostnames []string, validity time.Duration) (map[string]string, error) {
+	if credentials.CACertPath == "" || credentials.CAKeyPath == "" {
+		return nil, errors.New("no certificate authority is configured; run gp certs init first")
+	}
+
+	ca, err := utils.LoadCertificateAuthority(credentials.CACertPath, credentials.CAKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(credentials.CACertPath)
+	hubCertPath := filepath.Join(dir, utils.HubCertFileName)
+	hubKeyPath := filepath.Join(dir, utils.HubKeyFileName)
+	agentCertPath := filepath.Join(dir, utils.AgentCertFileName)
+	agentKeyPath := filepath.Join(dir, utils.AgentKeyFileName)
+	clientCertPath := filepath.Join(dir, utils.ClientCertFileName)
+	clientKeyPath := filepath.Join(dir, utils.ClientKeyFileName)
+
+	// The CLI always reaches the hub through localhost
+	hubHosts := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil {
+		hubHosts = append(hubHosts, hostname)
+	}
+	err = ca.IssueLocalCertificate(utils.RoleHub, utils.HubIdentity, hubHosts, validity, hubCertPath, hubKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ca.IssueLocalCertificate(utils.RoleCLI, utils.CLIIdentity, nil, validity, clientCertPath, clientKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints, err := ca.InstallAgentCertificates(hostnames, validity, credentials.CACertPath, agentCertPath, agentKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials.HubCertPath, credentials.HubKeyPath = hubCertPath, hubKeyPath
+	credentials.AgentCertPath, credentials.AgentKeyPath = agentCertPath, agentKeyPath
+	credentials.ClientCertPath, credentials.ClientKeyPath = clientCertPath, clientKeyPath
+
+	return fingerprints, nil
+}
+
+func certsRotateCmd() *cobra.Command {
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the certificates of the running services",
+		Long: `Issue new hub, agent and CLI certificates, distribute them to the hosts, and
+confirm that every agent presents its new certificate. The running services
+pick up the new certificates without being restarted.`,
+		PreRunE: InitializeCommand,
+		RunE:    RunCertsRotate,
+	}
+
+	rotateCmd.Flags().DurationVar(&certsValidity, "validity", constants.CertificateValidity, `How long the certificates are valid for`)
+
+	return rotateCmd
+}
+
+func RunCertsRotate(cmd *cobra.Command, args []string) error {
+	credentials, ok := Conf.Credentials.(*utils.GpCredentials)
+	if !ok {
+		return errors.New("certificates can only be rotated for file based credentials")
+	}
+
+	fingerprints, err := IssueCertificates(credentials, Conf.Hostnames, certsValidity)
+	if err != nil {
+		return err
+	}
+
+	err = Conf.Write(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = distributeConfig(ConfigFilePath, Conf.Hostnames, hubFileWriter(Conf))
+	if err != nil {
+		return err
+	}
+
+	err = ConfirmAgentCertificates(fingerprints)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Rotated certificates on %d hosts", len(Conf.Hostnames))
+
+	return nil
+}
+
+// ConfirmAgentCertificatesFunc checks that the agent on each host presents the
+// certificate with the expected fingerprint.
+func ConfirmAgentCertificatesFunc(fingerprints map[string]string) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.AgentCertificates(context.Background(), &idl.AgentCertificatesRequest{})
+	if err != nil {
+		return fmt.Errorf("could not get agent certificates: %w", err)
+	}
+
+	presented := make(map[string]string, len(reply.Certificates))
+	for _, certificate := range reply.Certificates {
+		presented[certificate.Host] = certificate.Fingerprint
+	}
+
+	results := reply.Results
+	for _, result := range results {
+		expected, ok := fingerprints[result.Host]
+		if result.Success && ok && presented[result.Host] != expected {
+			result.Success = false
+			result.Code = int32(codes.FailedPrecondition)
+			result.Message = fmt.Sprintf("agent presents certificate %s instead of %s", presented[result.Host], expected)
+		}
+	}
+
+	return CheckHostResults(os.Stdout, "rotate certificates", results)
+}
@@ -0,0 +1,201 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestRunCertsInit(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	dir := t.TempDir()
+	cmd, _, err := cli.RootCommand().Find([]string{"certs", "init"})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = cmd.ParseFlags([]string{"--dir", dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("creates the certificate authority", func(t *testing.T) {
+		err := cli.RunCertsInit(cmd, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = utils.LoadCertificateAuthority(filepath.Join(dir, utils.CACertFileName), filepath.Join(dir, utils.CAKeyFileName))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("does not replace an existing certificate authority", func(t *testing.T) {
+		err := cli.RunCertsInit(cmd, nil)
+		expected := "certificate authority already exists"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestEnsureCertificateAuthority(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	dir := filepath.Join(t.TempDir(), "certificates")
+	certPath := filepath.Join(dir, utils.CACertFileName)
+	keyPath := filepath.Join(dir, utils.CAKeyFileName)
+
+	t.Run("creates the certificate authority when there is none", func(t *testing.T) {
+		err := cli.EnsureCertificateAuthority(certPath, keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = utils.LoadCertificateAuthority(certPath, keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("keeps an existing certificate authority", func(t *testing.T) {
+		before, err := os.ReadFile(keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.EnsureCertificateAuthority(certPath, keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		after, err := os.ReadFile(keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(before) != string(after) {
+			t.Fatalf("expected the existing certificate authority to be kept")
+		}
+	})
+
+	t.Run("does not create the parent of the certificate directory", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "invalid", "certificates")
+
+		err := cli.EnsureCertificateAuthority(filepath.Join(missing, utils.CACertFileName), filepath.Join(missing, utils.CAKeyFileName))
+		if err == nil || !strings.HasPrefix(err.Error(), "could not create certificate directory") {
+			t.Fatalf("got %v, want an error creating the certificate directory", err)
+		}
+	})
+}
+
+func TestIssueCertificates(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	dir := t.TempDir()
+	ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = ca.Write(filepath.Join(dir, utils.CACertFileName), filepath.Join(dir, utils.CAKeyFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("issues the certificates of all roles and records their paths", func(t *testing.T) {
+		defer utils.ResetExecutor()
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+
+		creds := &utils.GpCredentials{
+			CACertPath: filepath.Join(dir, utils.CACertFileName),
+			CAKeyPath:  filepath.Join(dir, utils.CAKeyFileName),
+		}
+		fingerprints, err := cli.IssueCertificatesFunc(creds, []string{"sdw1", "sdw2"}, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for _, path := range []string{creds.HubCertPath, creds.HubKeyPath, creds.ClientCertPath, creds.ClientKeyPath} {
+			if _, err := os.Stat(path); err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+		if creds.AgentCertPath != filepath.Join(dir, utils.AgentCertFileName) {
+			t.Fatalf("got %s, want %s", creds.AgentCertPath, filepath.Join(dir, utils.AgentCertFileName))
+		}
+		if len(fingerprints) != 2 || fingerprints["sdw1"] == fingerprints["sdw2"] {
+			t.Fatalf("got %+v, want a different fingerprint for each host", fingerprints)
+		}
+		if len(mockExecutor.Hosts) != 6 {
+			t.Fatalf("got %d remote commands, want %d", len(mockExecutor.Hosts), 6)
+		}
+	})
+
+	t.Run("errors out when there is no certificate authority", func(t *testing.T) {
+		_, err := cli.IssueCertificatesFunc(&utils.GpCredentials{}, []string{"sdw1"}, time.Hour)
+		expected := "no certificate authority is configured; run gp certs init first"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestConfirmAgentCertificates(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("reports the agents still presenting another certificate", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AgentCertificates(gomock.Any(), &idl.AgentCertificatesRequest{}).Return(&idl.AgentCertificatesReply{
+				Certificates: []*idl.CertificateInfo{
+					{Host: "sdw1", Fingerprint: "AAAA"},
+					{Host: "sdw2", Fingerprint: "CCCC"},
+				},
+				Results: []*idl.HostResult{
+					{Host: "sdw1", Success: true},
+					{Host: "sdw2", Success: true},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.ConfirmAgentCertificates(map[string]string{"sdw1": "AAAA", "sdw2": "BBBB"})
+		expectedErr := "could not rotate certificates: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("succeeds when every agent presents its new certificate", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AgentCertificates(gomock.Any(), gomock.Any()).Return(&idl.AgentCertificatesReply{
+				Certificates: []*idl.CertificateInfo{{Host: "sdw1", Fingerprint: "AAAA"}},
+				Results:      []*idl.HostResult{{Host: "sdw1", Success: true}},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.ConfirmAgentCertificates(map[string]string{"sdw1": "AAAA"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var (
@@ -22,11 +23,15 @@ var (
 	Unmarshal       = json.Unmarshal
 	DialContextFunc = grpc.DialContext
 	ConnectToHub    = ConnectToHubFunc
+	CheckHubHealth  = CheckHubHealthFunc
 
 	ConfigFilePath string
 	Conf           *hub.Config
 
-	Verbose bool
+	Verbose   bool
+	LogFormat string
+	// RequestID identifies this invocation of the CLI in the logs of the hub and agents
+	RequestID string
 )
 
 func RootCommand() *cobra.Command {
@@ -36,14 +41,24 @@ func RootCommand() *cobra.Command {
 
 	root.PersistentFlags().StringVar(&ConfigFilePath, "config-file", filepath.Join(os.Getenv("GPHOME"), constants.ConfigFileName), `Path to gp configuration file`)
 	root.PersistentFlags().BoolVar(&Verbose, "verbose", false, `Provide verbose output`)
+	root.PersistentFlags().StringVar(&LogFormat, "log-format", "", `Format of the log file, text or json (default as set in the configuration file, or text)`)
 
 	root.AddCommand(
 		agentCmd(),
+		auditCmd(),
+		certsCmd(),
+		configCmd(),
 		configureCmd(),
+		copyCmd(),
+		execCmd(),
+		hostsCmd(),
+		logsCmd(),
 		hubCmd(),
 		startCmd(),
 		statusCmd(),
 		stopCmd(),
+		upgradeToolsCmd(),
+		versionCmd(),
 	)
 
 	return root
@@ -69,47 +84,94 @@ func InitializeCommand(cmd *cobra.Command, args []string) error {
 }
 
 func InitializeLogger(cmd *cobra.Command, args []string) error {
-	// CommandPath lists the names of the called command and all of its parent commands, so this
-	// turns e.g. "gp stop hub" into "gp_stop_hub" to generate a unique log file name for each command.
-	logName := strings.ReplaceAll(cmd.CommandPath(), " ", "_")
-
-	// Create the log directory if it does not exist, otherwise gplog panics
-	if _, err := os.Stat(hubLogDir); err != nil {
-		if os.IsNotExist(err) {
-			err = os.MkdirAll(hubLogDir, 0755)
-			if err != nil {
-				return err
-			}
+	// Every request this command sends carries the same ID, so its log lines
+	// can be matched up with those of the hub and agents. The hub and agent
+	// daemons, which are hidden commands, log the IDs of the requests they serve.
+	RequestID = ""
+	if !cmd.Hidden {
+		RequestID = utils.NewRequestID()
+	}
+
+	options := utils.LogOptions{
+		// CommandPath lists the names of the called command and all of its parent
+		// commands, which gives every command a log file of its own.
+		Path:      filepath.Join(hubLogDir, utils.LogFileName(cmd.CommandPath())),
+		Format:    constants.LogFormatText,
+		Component: "cli",
+		Command:   cmd.CommandPath(),
+		RequestID: RequestID,
+		Verbose:   Verbose,
+	}
+	if cmd.Hidden {
+		options.Component = cmd.Name()
+	}
+
+	// Commands which run before gp is configured have no configuration file
+	if Conf != nil {
+		if Conf.LogFormat != "" {
+			options.Format = Conf.LogFormat
 		}
+		options.Rotation = utils.LogRotation{
+			MaxSize:    int64(Conf.LogMaxSizeMB) * 1024 * 1024,
+			MaxAge:     time.Duration(Conf.LogMaxAgeDays) * 24 * time.Hour,
+			MaxBackups: Conf.LogMaxBackups,
+		}
+	}
+	if LogFormat != "" {
+		options.Format = LogFormat
+	}
+
+	return utils.InitializeLogging(options)
+}
+
+func ConnectToHubFunc(conf *hub.Config) (idl.HubClient, error) {
+	conn, err := dialHub(conf)
+	if err != nil {
+		return nil, err
+	}
 
+	return idl.NewHubClient(conn), nil
+}
+
+// CheckHubHealthFunc queries the health service of the hub, and returns an
+// error if the hub does not report itself as serving in time. Subsystems which
+// are not serving are logged as warnings.
+func CheckHubHealthFunc(conf *hub.Config) error {
+	conn, err := dialHub(conf)
+	if err != nil {
 		return err
 	}
+	defer conn.Close()
 
-	gplog.SetLogFileNameFunc(func(program string, logdir string) string {
-		return filepath.Join(hubLogDir, fmt.Sprintf("%s.log", logName))
-	})
+	ctx, cancel := context.WithTimeout(context.Background(), constants.HealthCheckTimeout)
+	defer cancel()
 
-	gplog.InitializeLogging(logName, "")
+	client := healthpb.NewHealthClient(conn)
+	reply, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("could not check hub health: %w", err)
+	}
+	if reply.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("hub reports %s", reply.Status)
+	}
 
-	timeFormat := time.Now().Format("2006-01-02 15:04:05.000000")
-	hostname, _ := os.Hostname()
-	gplog.SetLogPrefixFunc(func(level string) string {
-		return fmt.Sprintf("%s %s  [%s] ", timeFormat, hostname, level) // TODO: decide what prefix we want, assuming we want one, but we *definitely* don't want the legacy one
-	})
-	if Verbose {
-		gplog.SetVerbosity(gplog.LOGDEBUG)
+	for _, subsystem := range []string{constants.HealthCredentials, constants.HealthAgentConnectivity} {
+		reply, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: subsystem})
+		if err == nil && reply.Status == healthpb.HealthCheckResponse_NOT_SERVING {
+			gplog.Warn("Hub reports %s as %s", subsystem, reply.Status)
+		}
 	}
 
 	return nil
 }
 
-func ConnectToHubFunc(conf *hub.Config) (idl.HubClient, error) {
+func dialHub(conf *hub.Config) (*grpc.ClientConn, error) {
 	var conn *grpc.ClientConn
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	credentials, err := conf.Credentials.LoadClientCredentials()
+	credentials, err := conf.Credentials.LoadClientCredentials(utils.RoleCLI)
 	if err != nil {
 		return nil, err
 	}
@@ -117,13 +179,29 @@ func ConnectToHubFunc(conf *hub.Config) (idl.HubClient, error) {
 	address := fmt.Sprintf("localhost:%d", conf.Port)
 	conn, err = DialContextFunc(ctx, address,
 		grpc.WithTransportCredentials(credentials),
+		grpc.WithChainUnaryInterceptor(utils.UnaryClientRequestIDInterceptor(RequestID)),
+		grpc.WithChainStreamInterceptor(utils.StreamClientRequestIDInterceptor(RequestID)),
 		grpc.WithBlock(),
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithReturnConnectionError(),
 	)
 	if err != nil {
+		// The CLI and hub certificates are local files, so the most common
+		// reason for a failed handshake can be reported precisely
+		for _, role := range []utils.Role{utils.RoleCLI, utils.RoleHub} {
+			cert, certErr := conf.Credentials.Certificate(role)
+			if certErr != nil {
+				continue
+			}
+
+			validityErr := utils.CheckValidity(cert, time.Now())
+			if validityErr != nil {
+				return nil, fmt.Errorf("could not connect to hub on port %d: %s certificate is invalid: %w", conf.Port, role, validityErr)
+			}
+		}
+
 		return nil, fmt.Errorf("could not connect to hub on port %d: %w", conf.Port, err)
 	}
 
-	return idl.NewHubClient(conn), nil
+	return conn, nil
 }
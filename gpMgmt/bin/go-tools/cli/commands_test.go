@@ -34,14 +34,33 @@ func teardownTest() {
 func resetCLIVars() {
 	cli.DialContextFunc = grpc.DialContext
 	cli.ConnectToHub = cli.ConnectToHubFunc
+	cli.CheckHubHealth = cli.CheckHubHealthFunc
 	cli.StartHubService = cli.StartHubServiceFunc
 	cli.WaitAndRetryHubConnect = cli.WaitAndRetryHubConnectFunc
 	cli.ShowHubStatus = cli.ShowHubStatusFunc
 	cli.StartAgentsAll = cli.StartAgentsAllFunc
+	cli.StartAgentsOnHosts = cli.StartAgentsOnHostsFunc
 	cli.ShowAgentsStatus = cli.ShowAgentsStatusFunc
+	cli.ShowAgentsDetail = cli.ShowAgentsDetailFunc
 	cli.PrintServicesStatus = cli.PrintServicesStatusFunc
 	cli.StopAgentService = cli.StopAgentServiceFunc
 	cli.StopHubService = cli.StopHubServiceFunc
+	cli.ExecOnHosts = cli.ExecOnHostsFunc
+	cli.CopyToHosts = cli.CopyToHostsFunc
+	cli.DistributeFile = cli.DistributeFileFunc
+	cli.CollectLogs = cli.CollectLogsFunc
+	cli.TailLogs = cli.TailLogsFunc
+	cli.ShowClusterVersion = cli.ShowClusterVersionFunc
+	cli.UpgradeTools = cli.UpgradeToolsFunc
+	cli.InstallHostCertificates = cli.InstallHostCertificatesFunc
+	cli.ReloadHubConfig = cli.ReloadHubConfigFunc
+	cli.VerifyHubVersion = cli.VerifyHubVersionFunc
+	cli.CheckConfigDrift = cli.CheckConfigDriftFunc
+	cli.SyncConfig = cli.SyncConfigFunc
+	cli.IssueCertificates = cli.IssueCertificatesFunc
+	cli.ConfirmAgentCertificates = cli.ConfirmAgentCertificatesFunc
+	cli.GetHubCertificate = cli.GetHubCertificateFunc
+	cli.ShowCertificates = cli.ShowCertificatesFunc
 }
 
 func funcNilError() func() error {
@@ -0,0 +1,459 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	CheckConfigDrift = CheckConfigDriftFunc
+	SyncConfig       = SyncConfigFunc
+
+	configValidateLocal bool
+	configHosts         []string
+)
+
+func configCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the configuration of the cluster",
+	}
+
+	configCmd.AddCommand(
+		configValidateCmd(),
+		configHistoryCmd(),
+		configRollbackCmd(),
+		configCheckCmd(),
+		configSyncCmd(),
+	)
+
+	return configCmd
+}
+
+func configValidateCmd() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration against the environment of all hosts",
+		Long: `Load gp.conf and check it against the environment of this host and, over SSH,
+of every agent host: the ports are valid and distinct, the certificates and
+keys are readable, belong together and are issued by the CA, GPHOME contains
+bin/gp and greenplum_path.sh, every host name resolves and the log directory is
+writable. The hub and agents need not be running.`,
+		Args:    cobra.NoArgs,
+		PreRunE: initializeWithoutConfig,
+		RunE:    RunConfigValidate,
+	}
+
+	validateCmd.Flags().BoolVar(&configValidateLocal, "local", false, `Only check this host as an agent host, printing the results as JSON`)
+	_ = validateCmd.Flags().MarkHidden("local") // only used by gp config validate itself on the agent hosts
+
+	return validateCmd
+}
+
+func configHistoryCmd() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the previous versions of the configuration",
+		Long: fmt.Sprintf(`List the previous versions of gp.conf kept on this host, newest first. A
+version is kept whenever gp.conf is changed, and the last %d are kept.`, constants.ConfigHistorySize),
+		Args:    cobra.NoArgs,
+		PreRunE: initializeWithoutConfig,
+		RunE:    RunConfigHistory,
+	}
+
+	return historyCmd
+}
+
+func configRollbackCmd() *cobra.Command {
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback <version>",
+		Short: "Restore a previous version of the configuration on all hosts",
+		Long: `Restore the given version of gp.conf, as listed by gp config history, and copy
+it to all hosts. The configuration being replaced is kept as a new version, so
+a rollback can itself be undone. Versions which leave out hosts of the current
+configuration are refused; remove those hosts first. Restart the hub and agents
+to apply the restored configuration.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: initializeWithoutConfig,
+		RunE:    RunConfigRollback,
+	}
+
+	return rollbackCmd
+}
+
+func configCheckCmd() *cobra.Command {
+	checkCmd := &cobra.Command{
+		Use:   "check [--host <host>...]",
+		Short: "Show where gp.conf differs from that of the hub",
+		Long: `Compare gp.conf on every agent host, as it is on disk, with that of the hub,
+and show the keys whose values differ on each host. Use gp config sync to copy
+the file of the hub to the hosts where it differs. The hub and agents must be
+running.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunConfigCheck,
+	}
+
+	checkCmd.Flags().StringSliceVar(&configHosts, "host", nil, `Host to check; may be repeated (default all hosts)`)
+
+	return checkCmd
+}
+
+func configSyncCmd() *cobra.Command {
+	syncCmd := &cobra.Command{
+		Use:   "sync [--host <host>...]",
+		Short: "Copy gp.conf of the hub to the hosts where it differs",
+		Long: `Copy gp.conf of the hub to every agent host whose file differs from it, as
+shown by gp config check. Restart the agents on those hosts to apply it. The
+hub and agents must be running.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunConfigSync,
+	}
+
+	syncCmd.Flags().StringSliceVar(&configHosts, "host", nil, `Host to copy the file to if it differs; may be repeated (default all hosts)`)
+
+	return syncCmd
+}
+
+// initializeWithoutConfig initializes logging without loading the
+// configuration, for the commands which must work when it is broken.
+func initializeWithoutConfig(cmd *cobra.Command, args []string) error {
+	hubLogDir = constants.DefaultHubLogDir
+	return InitializeLogger(cmd, args)
+}
+
+func RunConfigHistory(cmd *cobra.Command, args []string) error {
+	return PrintConfigHistory(os.Stdout, ConfigFilePath)
+}
+
+func RunConfigRollback(cmd *cobra.Command, args []string) error {
+	number, err := strconv.Atoi(args[0])
+	if err != nil || number <= 0 {
+		return fmt.Errorf("invalid version %q: must be a positive number", args[0])
+	}
+
+	return RollbackConfig(ConfigFilePath, number)
+}
+
+// PrintConfigHistory prints a table of the previous versions of the
+// configuration file to outfile.
+func PrintConfigHistory(outfile io.Writer, configFilePath string) error {
+	entries, err := hub.ConfigHistory(configFilePath)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		gplog.Info("No previous versions of %s are kept", configFilePath)
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "VERSION\tSAVED\tHOSTS")
+	for _, entry := range entries {
+		// An entry which cannot be loaded is still listed, as it may be fixed by hand
+		hosts := "-"
+		conf := &hub.Config{}
+		if conf.Load(entry.Path) == nil {
+			hosts = strings.Join(conf.Hostnames, ",")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", entry.Number, entry.Saved.Format(time.RFC3339), hosts)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// RollbackConfig restores the given version of the configuration file from
+// its history, and copies it to the hosts it lists. Versions which leave out
+// hosts of the current configuration are refused, as those hosts would keep
+// the current file.
+func RollbackConfig(configFilePath string, number int) error {
+	entries, err := hub.ConfigHistory(configFilePath)
+	if err != nil {
+		return err
+	}
+
+	// A rollback may be needed because the current file is broken, in
+	// which case there are no current hosts to check
+	current := &hub.Config{}
+	err = current.Load(configFilePath)
+	if err != nil {
+		gplog.Warn("Could not load %s, so the hosts it lists are not checked: %v", configFilePath, err)
+	}
+	previous, err := os.ReadFile(configFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read %s: %w", configFilePath, err)
+	}
+
+	for _, entry := range entries {
+		if entry.Number != number {
+			continue
+		}
+
+		conf := &hub.Config{}
+		err = conf.Load(entry.Path)
+		if err != nil {
+			return fmt.Errorf("could not load version %d of %s: %w", number, configFilePath, err)
+		}
+
+		restored := make(map[string]bool, len(conf.Hostnames))
+		for _, host := range conf.Hostnames {
+			restored[host] = true
+		}
+		var orphaned []string
+		for _, host := range current.Hostnames {
+			if !restored[host] {
+				orphaned = append(orphaned, host)
+			}
+		}
+		if len(orphaned) > 0 {
+			return fmt.Errorf("version %d of %s does not list hosts %s; remove them with gp hosts remove first", number, configFilePath, strings.Join(orphaned, ", "))
+		}
+
+		err = conf.Write(configFilePath)
+		if err != nil {
+			return err
+		}
+
+		// The hub has to know the restored hosts to copy the file to them
+		_, err = ReloadHubConfig(conf)
+		if err != nil && previous != nil {
+			restoreErr := utils.WriteFileAtomically(configFilePath, previous, 0644)
+			if restoreErr != nil {
+				return fmt.Errorf("%w; could not restore the previous %s: %v", err, configFilePath, restoreErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = distributeConfig(configFilePath, conf.Hostnames, hubFileWriter(conf))
+		if err != nil {
+			return err
+		}
+		gplog.Info("Restored version %d of %s on hosts %s; restart the hub and agents to apply it", number, configFilePath, strings.Join(conf.Hostnames, ", "))
+
+		return nil
+	}
+
+	return fmt.Errorf("version %d of %s is not kept; run gp config history to list the kept versions", number, configFilePath)
+}
+
+// distributeConfig copies the configuration file on this host to the given
+// hosts with writeFile.
+func distributeConfig(configFilePath string, hosts []string, writeFile utils.FileWriter) error {
+	contents, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return fmt.Errorf("could not read gp.conf file: %w", err)
+	}
+
+	err = writeFile(hosts, contents, 0644, configFilePath)
+	if err != nil {
+		return fmt.Errorf("could not copy gp.conf file to segment hosts: %w", err)
+	}
+
+	return nil
+}
+
+func RunConfigCheck(cmd *cobra.Command, args []string) error {
+	return CheckConfigDrift(configHosts, os.Stdout)
+}
+
+func RunConfigSync(cmd *cobra.Command, args []string) error {
+	return SyncConfig(configHosts, os.Stdout)
+}
+
+// CheckConfigDriftFunc has the hub compare the configuration file on the given
+// hosts with its own, and prints where it differs to outfile.
+func CheckConfigDriftFunc(hosts []string, outfile io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.CheckConfig(context.Background(), &idl.CheckConfigRequest{Hosts: hosts})
+	if err != nil {
+		return fmt.Errorf("could not check the configuration: %w", err)
+	}
+
+	if len(reply.Drifts) > 0 {
+		PrintConfigDrift(outfile, reply.Drifts)
+	}
+
+	err = CheckHostResults(outfile, "check the configuration", reply.GetResults())
+	if err != nil {
+		return err
+	}
+
+	if len(reply.Drifts) > 0 {
+		return fmt.Errorf("gp.conf differs from that of the hub on %d hosts; run gp config sync to copy it to them", len(reply.Drifts))
+	}
+	gplog.Info("gp.conf is the same as that of the hub on all %d hosts (sha256 %s)", len(reply.GetResults()), reply.Sha256)
+
+	return nil
+}
+
+// SyncConfigFunc has the hub copy its configuration file to the given hosts
+// where it differs, and prints the hosts on which it failed to outfile.
+func SyncConfigFunc(hosts []string, outfile io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.SyncConfig(context.Background(), &idl.SyncConfigRequest{Hosts: hosts})
+	if err != nil {
+		return fmt.Errorf("could not sync the configuration: %w", err)
+	}
+
+	if len(reply.SyncedHosts) > 0 {
+		gplog.Info("Copied gp.conf to hosts %s; restart the agents on them to apply it", strings.Join(reply.SyncedHosts, ", "))
+	} else {
+		gplog.Info("gp.conf is already the same as that of the hub on all hosts")
+	}
+
+	return CheckHostResults(outfile, "sync the configuration", reply.GetResults())
+}
+
+// PrintConfigDrift prints a table of the keys whose values differ between the
+// configuration file of the hub and that of each host.
+func PrintConfigDrift(outfile io.Writer, drifts []*idl.ConfigDrift) {
+	value := func(value string) string {
+		if value == "" {
+			return "(not set)"
+		}
+		return value
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tKEY\tHUB VALUE\tHOST VALUE")
+	for _, drift := range drifts {
+		switch {
+		case drift.Error != "":
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", drift.Host, "-", "-", drift.Error)
+		case len(drift.Fields) == 0:
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", drift.Host, "-", "-", "differs in formatting only")
+		}
+		for _, field := range drift.Fields {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", drift.Host, field.Key, value(field.HubValue), value(field.HostValue))
+		}
+	}
+	w.Flush()
+}
+
+func RunConfigValidate(cmd *cobra.Command, args []string) error {
+	if configValidateLocal {
+		return ValidateLocalConfig(os.Stdout)
+	}
+
+	return ValidateClusterConfig(os.Stdout)
+}
+
+// ValidateClusterConfig checks the configuration on this host, as the hub
+// host, and on every agent host, and prints the outcome of every check to
+// outfile.
+func ValidateClusterConfig(outfile io.Writer) error {
+	conf := &hub.Config{}
+	err := conf.Load(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("could not get the name of this host: %w", err)
+	}
+
+	hostChecks := []*hub.HostConfigChecks{{
+		Host:   hostname,
+		Checks: hub.ValidateConfig(conf, utils.RoleHub, utils.RoleCLI),
+	}}
+	hostChecks = append(hostChecks, hub.ValidateConfigOnHosts(conf, ConfigFilePath)...)
+
+	return PrintConfigChecks(outfile, hostChecks)
+}
+
+// ValidateLocalConfig checks the configuration on this host, as an agent
+// host, and prints the checks to outfile as JSON to be read back by
+// ValidateClusterConfig.
+func ValidateLocalConfig(outfile io.Writer) error {
+	var checks []hub.ConfigCheck
+
+	conf := &hub.Config{}
+	err := conf.Load(ConfigFilePath)
+	if err != nil {
+		checks = []hub.ConfigCheck{{Name: "config file", Error: err.Error()}}
+	} else {
+		checks = hub.ValidateConfig(conf, utils.RoleAgent)
+	}
+
+	contents, err := json.Marshal(checks)
+	if err != nil {
+		return fmt.Errorf("could not encode the checks: %w", err)
+	}
+	fmt.Fprintln(outfile, string(contents))
+
+	for _, check := range checks {
+		if check.Error != "" {
+			return errors.New("the configuration is invalid on this host")
+		}
+	}
+
+	return nil
+}
+
+// PrintConfigChecks prints a table of the checks run on every host, and
+// returns a HostFailureError if any of them failed.
+func PrintConfigChecks(outfile io.Writer, hostChecks []*hub.HostConfigChecks) error {
+	failed := 0
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tCHECK\tSTATUS\tMESSAGE")
+	for _, host := range hostChecks {
+		if host.Err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", host.Host, "-", "failed", host.Err)
+			continue
+		}
+
+		hostFailed := false
+		for _, check := range host.Checks {
+			status := "ok"
+			if check.Error != "" {
+				status = "failed"
+				hostFailed = true
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", host.Host, check.Name, status, check.Error)
+		}
+		if hostFailed {
+			failed++
+		}
+	}
+	w.Flush()
+
+	if failed == 0 {
+		return nil
+	}
+	fmt.Fprintf(outfile, "%d of %d hosts failed\n", failed, len(hostChecks))
+
+	return &HostFailureError{Operation: "validate the configuration", Failed: failed, Total: len(hostChecks)}
+}
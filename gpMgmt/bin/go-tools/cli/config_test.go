@@ -0,0 +1,322 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestValidateLocalConfig(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	configFilePath := cli.ConfigFilePath
+	defer func() { cli.ConfigFilePath = configFilePath }()
+
+	t.Run("prints the load error as a failed check", func(t *testing.T) {
+		cli.ConfigFilePath = filepath.Join(t.TempDir(), "gp.conf")
+		err := os.WriteFile(cli.ConfigFilePath, []byte(`{"hubPort": 4242}`), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var buf bytes.Buffer
+		err = cli.ValidateLocalConfig(&buf)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		var checks []hub.ConfigCheck
+		err = json.Unmarshal(buf.Bytes(), &checks)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		expected := []hub.ConfigCheck{{Name: "config file", Error: `could not parse config file: missing required key "agentPort"`}}
+		if !reflect.DeepEqual(checks, expected) {
+			t.Fatalf("got %+v, want %+v", checks, expected)
+		}
+	})
+}
+
+func TestPrintConfigChecks(t *testing.T) {
+	t.Run("prints every check and returns the number of failed hosts", func(t *testing.T) {
+		hostChecks := []*hub.HostConfigChecks{
+			{Host: "cdw", Checks: []hub.ConfigCheck{{Name: "ports"}, {Name: "gphome"}}},
+			{Host: "sdw1", Checks: []hub.ConfigCheck{{Name: "ports"}, {Name: "gphome", Error: "could not find bin/gp in gphome /usr/local/gp"}}},
+			{Host: "sdw2", Err: errors.New("host sdw2: connection refused")},
+		}
+
+		var buf bytes.Buffer
+		err := cli.PrintConfigChecks(&buf, hostChecks)
+
+		var hostErr *cli.HostFailureError
+		if !errors.As(err, &hostErr) || hostErr.Failed != 2 || hostErr.Total != 3 {
+			t.Fatalf("got %v, want 2 of 3 hosts to fail", err)
+		}
+		if hostErr.ExitCode() != constants.ExitCodePartialFailure {
+			t.Fatalf("got %d, want %d", hostErr.ExitCode(), constants.ExitCodePartialFailure)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 7 {
+			t.Fatalf("got %q, want a header, five checks and a summary", buf.String())
+		}
+		for i, expected := range [][]string{
+			{"sdw1", "gphome", "failed", "could", "not", "find", "bin/gp", "in", "gphome", "/usr/local/gp"},
+			{"sdw2", "-", "failed", "host", "sdw2:", "connection", "refused"},
+		} {
+			if !reflect.DeepEqual(strings.Fields(lines[i+4]), expected) {
+				t.Fatalf("got %q, want %q", lines[i+4], strings.Join(expected, " "))
+			}
+		}
+		if lines[6] != "2 of 3 hosts failed" {
+			t.Fatalf("got %q, want %q", lines[6], "2 of 3 hosts failed")
+		}
+	})
+
+	t.Run("succeeds when every check passed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintConfigChecks(&buf, []*hub.HostConfigChecks{{Host: "cdw", Checks: []hub.ConfigCheck{{Name: "ports"}}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
+
+func TestConfigHistory(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	var distributed [][]string
+	cli.DistributeFile = func(conf *hub.Config, hosts []string, contents []byte, mode os.FileMode, dest string) error {
+		distributed = append(distributed, hosts)
+		return nil
+	}
+	cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+		return true, nil
+	}
+	defer resetCLIVars()
+
+	// Three versions of the file leave the first two in the history
+	configFilePath := filepath.Join(t.TempDir(), "gp.conf")
+	for _, hosts := range [][]string{{"sdw1", "sdw2"}, {"sdw1", "sdw2", "sdw3"}, {"sdw1"}} {
+		conf := testutils.InitializeTestEnv()
+		conf.Hostnames = hosts
+		conf.Credentials = &utils.GpCredentials{}
+		err := conf.Write(configFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+	loadHosts := func(t *testing.T) []string {
+		t.Helper()
+
+		conf := &hub.Config{}
+		err := conf.Load(configFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		return conf.Hostnames
+	}
+
+	t.Run("lists the kept versions newest first", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.PrintConfigHistory(&buf, configFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 3 || !reflect.DeepEqual(strings.Fields(lines[0]), []string{"VERSION", "SAVED", "HOSTS"}) {
+			t.Fatalf("got %q, want a header and two versions", buf.String())
+		}
+		for i, expected := range [][]string{{"2", "sdw1,sdw2,sdw3"}, {"1", "sdw1,sdw2"}} {
+			fields := strings.Fields(lines[i+1])
+			if len(fields) != 3 || fields[0] != expected[0] || fields[2] != expected[1] {
+				t.Fatalf("got %q, want version %s with hosts %s", lines[i+1], expected[0], expected[1])
+			}
+		}
+	})
+
+	t.Run("restores a version on its hosts and keeps the replaced one", func(t *testing.T) {
+		err := cli.RollbackConfig(configFilePath, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(loadHosts(t), []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want %v", loadHosts(t), []string{"sdw1", "sdw2"})
+		}
+		if !reflect.DeepEqual(distributed, [][]string{{"sdw1", "sdw2"}}) {
+			t.Fatalf("got %v, want the restored file to be copied to sdw1 and sdw2", distributed)
+		}
+	})
+
+	t.Run("refuses to restore a version which leaves out hosts of the cluster", func(t *testing.T) {
+		distributed = nil
+
+		// Version 3 is the one replaced by the rollback above
+		err := cli.RollbackConfig(configFilePath, 3)
+		expected := "version 3 of " + configFilePath + " does not list hosts sdw2; remove them with gp hosts remove first"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		if !reflect.DeepEqual(loadHosts(t), []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want the current file to be kept", loadHosts(t))
+		}
+		if len(distributed) != 0 {
+			t.Fatalf("got %v, want nothing to be copied", distributed)
+		}
+	})
+
+	t.Run("keeps the current file when the hub cannot reload the restored one", func(t *testing.T) {
+		distributed = nil
+		cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+			return true, errors.New("could not reload the hub configuration: agent on host sdw3 is unreachable")
+		}
+		defer func() {
+			cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+				return true, nil
+			}
+		}()
+
+		err := cli.RollbackConfig(configFilePath, 2)
+		expected := "could not reload the hub configuration: agent on host sdw3 is unreachable"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		if !reflect.DeepEqual(loadHosts(t), []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want the current file to be kept", loadHosts(t))
+		}
+		if len(distributed) != 0 {
+			t.Fatalf("got %v, want nothing to be copied", distributed)
+		}
+	})
+
+	t.Run("errors out for a version which is not kept", func(t *testing.T) {
+		err := cli.RollbackConfig(configFilePath, 42)
+		expected := "version 42 of " + configFilePath + " is not kept; run gp config history to list the kept versions"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestCheckConfigDrift(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("prints the keys which differ and errors out", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CheckConfig(gomock.Any(), &idl.CheckConfigRequest{Hosts: []string{"sdw1", "sdw2"}}).Return(&idl.CheckConfigReply{
+				Drifts: []*idl.ConfigDrift{{
+					Host:   "sdw2",
+					Fields: []*idl.ConfigFieldDiff{{Key: "agentPort", HubValue: "8000", HostValue: "9000"}},
+				}},
+				Results: []*idl.HostResult{{Host: "sdw1", Success: true}, {Host: "sdw2", Success: true}},
+			}, nil)
+			return hubClient, nil
+		}
+
+		var buf bytes.Buffer
+		err := cli.CheckConfigDriftFunc([]string{"sdw1", "sdw2"}, &buf)
+		expectedErr := "gp.conf differs from that of the hub on 1 hosts; run gp config sync to copy it to them"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 || !reflect.DeepEqual(strings.Fields(lines[1]), []string{"sdw2", "agentPort", "8000", "9000"}) {
+			t.Fatalf("got %q, want the drifted key of sdw2", buf.String())
+		}
+	})
+
+	t.Run("succeeds when every host has the file of the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CheckConfig(gomock.Any(), gomock.Any()).Return(&idl.CheckConfigReply{
+				Results: []*idl.HostResult{{Host: "sdw1", Success: true}},
+			}, nil)
+			return hubClient, nil
+		}
+
+		var buf bytes.Buffer
+		err := cli.CheckConfigDriftFunc(nil, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("got %q, want no output", buf.String())
+		}
+	})
+}
+
+func TestSyncConfig(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("reports the hosts to which the file could not be copied", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().SyncConfig(gomock.Any(), &idl.SyncConfigRequest{}).Return(&idl.SyncConfigReply{
+				SyncedHosts: []string{"sdw1"},
+				Results: []*idl.HostResult{
+					{Host: "sdw1", Success: true},
+					{Host: "sdw2", Code: 14, Message: "could not copy gp.conf to host sdw2: connection refused"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		var buf bytes.Buffer
+		err := cli.SyncConfigFunc(nil, &buf)
+		expectedErr := "could not sync the configuration: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestPrintConfigDrift(t *testing.T) {
+	t.Run("prints why each host differs", func(t *testing.T) {
+		drifts := []*idl.ConfigDrift{
+			{Host: "sdw1", Error: "configuration file /usr/local/gp/gp.conf does not exist"},
+			{Host: "sdw2"},
+			{Host: "sdw3", Fields: []*idl.ConfigFieldDiff{{Key: "agentMetricsPort", HostValue: "9100"}}},
+		}
+
+		var buf bytes.Buffer
+		cli.PrintConfigDrift(&buf, drifts)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		for i, expected := range [][]string{
+			{"sdw1", "-", "-", "configuration", "file", "/usr/local/gp/gp.conf", "does", "not", "exist"},
+			{"sdw2", "-", "-", "differs", "in", "formatting", "only"},
+			{"sdw3", "agentMetricsPort", "(not", "set)", "9100"},
+		} {
+			if len(lines) != 4 || !reflect.DeepEqual(strings.Fields(lines[i+1]), expected) {
+				t.Fatalf("got %q, want %q", buf.String(), strings.Join(expected, " "))
+			}
+		}
+	})
+}
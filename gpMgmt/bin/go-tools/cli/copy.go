@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	CopyToHosts    = CopyToHostsFunc
+	DistributeFile = DistributeFileFunc
+
+	copyHosts []string
+)
+
+func copyCmd() *cobra.Command {
+	copyCmd := &cobra.Command{
+		Use:   "copy [--host <host>]... [<source host>:]<source> =:<destination>",
+		Short: "Copy a file to the agent hosts",
+		Long: `Copy a local file, or a file on the given source host, to the given path on
+the agent hosts, or on all hosts if none are given. As with gpsync, the
+destination is prefixed with "=:".`,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: InitializeCommand,
+		RunE:    RunCopy,
+	}
+
+	copyCmd.Flags().StringSliceVar(&copyHosts, "host", nil, `Host to copy the file to; may be repeated`)
+
+	return copyCmd
+}
+
+func RunCopy(cmd *cobra.Command, args []string) error {
+	// As with scp, a colon before any slash separates the source host
+	var sourceHost string
+	source := args[0]
+	if i := strings.Index(source, ":"); i > 0 && !strings.Contains(source[:i], "/") {
+		sourceHost, source = source[:i], source[i+1:]
+	}
+
+	if sourceHost != "" && !filepath.IsAbs(source) {
+		return fmt.Errorf("source path %s on host %s must be absolute", source, sourceHost)
+	}
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return fmt.Errorf("could not resolve source path %s: %w", args[0], err)
+	}
+
+	destination := strings.TrimPrefix(args[1], "=:")
+	if destination == args[1] {
+		return fmt.Errorf("destination %s must be of the form =:<path>", args[1])
+	}
+	if !filepath.IsAbs(destination) {
+		return fmt.Errorf("destination path %s must be absolute", destination)
+	}
+
+	err = CopyToHosts(copyHosts, sourceHost, source, destination)
+	if err != nil {
+		return err
+	}
+	if sourceHost != "" {
+		source = sourceHost + ":" + source
+	}
+	gplog.Info("Copied %s to %s", source, destination)
+
+	return nil
+}
+
+// CopyToHostsFunc copies source, which is on sourceHost unless it is empty, to
+// destination on the given hosts.
+func CopyToHostsFunc(hosts []string, sourceHost string, source string, destination string) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.CopyFile(context.Background(), &idl.CopyFileRequest{
+		Hosts:       hosts,
+		Source:      source,
+		Destination: destination,
+		SourceHost:  sourceHost,
+	})
+	if err != nil {
+		return fmt.Errorf("could not copy file: %w", err)
+	}
+
+	return CheckHostResults(os.Stdout, "copy file", reply.GetResults())
+}
+
+// hubFileWriter writes files to the agent hosts of the cluster described by
+// conf with DistributeFile.
+func hubFileWriter(conf *hub.Config) utils.FileWriter {
+	return func(hosts []string, contents []byte, mode os.FileMode, dest string) error {
+		return DistributeFile(conf, hosts, contents, mode, dest)
+	}
+}
+
+// DistributeFileFunc writes contents to dest on the given hosts. The file is
+// copied through the agents when the hub is running. Hosts whose agent the hub
+// cannot reach, such as those being configured, and all hosts if the hub is
+// not running, get the file over SSH instead.
+func DistributeFileFunc(conf *hub.Config, hosts []string, contents []byte, mode os.FileMode, dest string) error {
+	client, err := ConnectToHub(conf)
+	if err != nil {
+		gplog.Debug("Could not connect to the hub, copying %s over SSH: %v", dest, err)
+		return utils.WriteFileOverSSH(hosts, contents, mode, dest)
+	}
+
+	// The hub copies a file on this host, as the CLI runs on the hub host
+	file, err := os.CreateTemp("", filepath.Base(dest)+".*")
+	if err != nil {
+		return fmt.Errorf("could not create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(contents)
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write temporary file %s: %w", file.Name(), err)
+	}
+
+	reply, err := client.CopyFile(context.Background(), &idl.CopyFileRequest{
+		Hosts:       hosts,
+		Source:      file.Name(),
+		Destination: dest,
+	})
+	if err != nil {
+		return fmt.Errorf("could not copy %s: %w", dest, err)
+	}
+
+	var unreachable []string
+	var failed []string
+	for _, result := range reply.GetResults() {
+		switch {
+		case result.Success:
+		case codes.Code(result.Code) == codes.Unavailable:
+			unreachable = append(unreachable, result.Host)
+		default:
+			failed = append(failed, fmt.Sprintf("host %s: %s", result.Host, result.Message))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not copy %s: %s", dest, strings.Join(failed, "; "))
+	}
+
+	if len(unreachable) > 0 {
+		gplog.Debug("Copying %s over SSH to hosts %s, whose agents are not running", dest, strings.Join(unreachable, ", "))
+		return utils.WriteFileOverSSH(unreachable, contents, mode, dest)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRunCopy(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("copies the file to the destination on the hosts", func(t *testing.T) {
+		defer resetCLIVars()
+		var sourceHost, source, destination string
+		cli.CopyToHosts = func(hosts []string, srcHost string, src string, dest string) error {
+			sourceHost, source, destination = srcHost, src, dest
+			return nil
+		}
+
+		err := cli.RunCopy(nil, []string{"/tmp/gp.conf", "=:/usr/local/gp.conf"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if sourceHost != "" || source != "/tmp/gp.conf" || destination != "/usr/local/gp.conf" {
+			t.Fatalf("got %q, %s and %s, want no source host, %s and %s", sourceHost, source, destination, "/tmp/gp.conf", "/usr/local/gp.conf")
+		}
+	})
+
+	t.Run("copies a file from the given source host", func(t *testing.T) {
+		defer resetCLIVars()
+		var sourceHost, source string
+		cli.CopyToHosts = func(hosts []string, srcHost string, src string, dest string) error {
+			sourceHost, source = srcHost, src
+			return nil
+		}
+
+		err := cli.RunCopy(nil, []string{"sdw1:/tmp/gp.conf", "=:/usr/local/gp.conf"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if sourceHost != "sdw1" || source != "/tmp/gp.conf" {
+			t.Fatalf("got %s and %s, want %s and %s", sourceHost, source, "sdw1", "/tmp/gp.conf")
+		}
+	})
+
+	t.Run("errors out when the source path on a host is not absolute", func(t *testing.T) {
+		defer resetCLIVars()
+
+		err := cli.RunCopy(nil, []string{"sdw1:gp.conf", "=:/usr/local/gp.conf"})
+		expectedErr := "source path gp.conf on host sdw1 must be absolute"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the destination is not prefixed", func(t *testing.T) {
+		defer resetCLIVars()
+
+		err := cli.RunCopy(nil, []string{"/tmp/gp.conf", "/usr/local/gp.conf"})
+		expectedErr := "destination /usr/local/gp.conf must be of the form =:<path>"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the destination is not absolute", func(t *testing.T) {
+		defer resetCLIVars()
+
+		err := cli.RunCopy(nil, []string{"/tmp/gp.conf", "=:gp.conf"})
+		expectedErr := "destination path gp.conf must be absolute"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestCopyToHosts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("reports the hosts the file could not be copied to", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CopyFile(gomock.Any(), &idl.CopyFileRequest{Source: "/src", Destination: "/dest", SourceHost: "sdw1"}).Return(&idl.CopyFileReply{
+				Results: []*idl.HostResult{
+					{Host: "sdw1", Success: true},
+					{Host: "sdw2", Code: 15, Message: "checksum mismatch"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.CopyToHosts(nil, "sdw1", "/src", "/dest")
+		expectedErr := "could not copy file: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("returns an error when the hub fails to copy the file", func(t *testing.T) {
+		defer resetCLIVars()
+		expectedStr := "TEST Error copying file"
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(nil, errors.New(expectedStr))
+			return hubClient, nil
+		}
+
+		err := cli.CopyToHosts(nil, "", "/src", "/dest")
+		if !strings.Contains(err.Error(), expectedStr) {
+			t.Fatalf("got %v, want %v", err, expectedStr)
+		}
+	})
+}
+
+func TestDistributeFile(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	executor := &testutils.MockExecutor{}
+	utils.SetExecutor(executor)
+	defer utils.ResetExecutor()
+
+	t.Run("copies the file through the running agents and over SSH to the others", func(t *testing.T) {
+		defer resetCLIVars()
+		executor.Hosts = nil
+
+		var copied []byte
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CopyFile(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, in *idl.CopyFileRequest, _ ...grpc.CallOption) (*idl.CopyFileReply, error) {
+				if !reflect.DeepEqual(in.Hosts, []string{"sdw1", "sdw2"}) || in.Destination != "/usr/local/gp/gp.conf" {
+					t.Fatalf("got %+v, want gp.conf to be copied to sdw1 and sdw2", in)
+				}
+
+				var err error
+				copied, err = os.ReadFile(in.Source)
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+
+				return &idl.CopyFileReply{Results: []*idl.HostResult{
+					{Host: "sdw1", Success: true},
+					{Host: "sdw2", Code: int32(codes.Unavailable), Message: "agent on host sdw2 is unreachable"},
+				}}, nil
+			})
+			return hubClient, nil
+		}
+
+		err := cli.DistributeFileFunc(cli.Conf, []string{"sdw1", "sdw2"}, []byte("contents"), 0644, "/usr/local/gp/gp.conf")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if string(copied) != "contents" {
+			t.Fatalf("got %q, want %q", copied, "contents")
+		}
+		if !reflect.DeepEqual(executor.Hosts, [][]string{{"sdw2"}}) {
+			t.Fatalf("got %v, want the file to be copied over SSH to sdw2 only", executor.Hosts)
+		}
+	})
+
+	t.Run("copies the file over SSH when the hub is not running", func(t *testing.T) {
+		defer resetCLIVars()
+		executor.Hosts = nil
+
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		err := cli.DistributeFileFunc(cli.Conf, []string{"sdw1", "sdw2"}, []byte("contents"), 0644, "/usr/local/gp/gp.conf")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(executor.Hosts, [][]string{{"sdw1", "sdw2"}}) {
+			t.Fatalf("got %v, want the file to be copied over SSH to all hosts", executor.Hosts)
+		}
+	})
+
+	t.Run("does not fall back to SSH when a running agent fails to write the file", func(t *testing.T) {
+		defer resetCLIVars()
+		executor.Hosts = nil
+
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CopyFile(gomock.Any(), gomock.Any()).Return(&idl.CopyFileReply{Results: []*idl.HostResult{
+				{Host: "sdw1", Code: int32(codes.DataLoss), Message: "checksum mismatch"},
+			}}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.DistributeFileFunc(cli.Conf, []string{"sdw1"}, []byte("contents"), 0644, "/usr/local/gp/gp.conf")
+		expectedErr := "could not copy /usr/local/gp/gp.conf: host sdw1: checksum mismatch"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+		if len(executor.Hosts) != 0 {
+			t.Fatalf("got %v, want no copies over SSH", executor.Hosts)
+		}
+	})
+}
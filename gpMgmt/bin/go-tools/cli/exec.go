@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ExecOnHosts = ExecOnHostsFunc
+
+	execAll   bool
+	execHosts []string
+)
+
+func execCmd() *cobra.Command {
+	execCmd := &cobra.Command{
+		Use:   "exec (--host <host>... | --all) -- <command>",
+		Short: "Run a command on the agent hosts",
+		Long: `Run a command through the shell on the agent hosts and print its output,
+prefixed with the host it came from.`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunExec,
+	}
+
+	execCmd.Flags().StringSliceVar(&execHosts, "host", nil, `Host on which to run the command; may be repeated`)
+	execCmd.Flags().BoolVar(&execAll, "all", false, `Run the command on all hosts`)
+
+	return execCmd
+}
+
+func RunExec(cmd *cobra.Command, args []string) error {
+	if execAll == (len(execHosts) > 0) {
+		return errors.New("exactly one of --host or --all must be specified")
+	}
+
+	return ExecOnHosts(execHosts, strings.Join(args, " "), os.Stdout, os.Stderr)
+}
+
+// ExecOnHostsFunc runs command on the given hosts, or on all hosts if none are
+// given, writing the output of each host to stdout and stderr line by line.
+func ExecOnHostsFunc(hosts []string, command string, stdout io.Writer, stderr io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	stream, err := client.Exec(context.Background(), &idl.ExecRequest{Hosts: hosts, Command: command})
+	if err != nil {
+		return fmt.Errorf("could not run command: %w", err)
+	}
+
+	outputs := make(map[string]*hostOutput)
+	output := func(host string) *hostOutput {
+		if outputs[host] == nil {
+			outputs[host] = &hostOutput{
+				stdout: &prefixWriter{w: stdout, prefix: fmt.Sprintf("[%s] ", host)},
+				stderr: &prefixWriter{w: stderr, prefix: fmt.Sprintf("[%s] ", host)},
+			}
+		}
+
+		return outputs[host]
+	}
+
+	var results []*idl.HostResult
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not run command: %w", err)
+		}
+
+		switch out := reply.Output.(type) {
+		case *idl.ExecReply_Stdout:
+			output(reply.Host).stdout.Write(out.Stdout)
+		case *idl.ExecReply_Stderr:
+			output(reply.Host).stderr.Write(out.Stderr)
+		case *idl.ExecReply_Result:
+			output(reply.Host).flush()
+			results = append(results, out.Result)
+		}
+	}
+
+	return CheckHostResults(stdout, "run command", results)
+}
+
+type hostOutput struct {
+	stdout *prefixWriter
+	stderr *prefixWriter
+}
+
+func (o *hostOutput) flush() {
+	o.stdout.Flush()
+	o.stderr.Flush()
+}
+
+// prefixWriter writes every complete line written to it to w with the given
+// prefix, holding back any trailing partial line until it is completed or
+// flushed, so that the output of different hosts is never interleaved within
+// a line.
+type prefixWriter struct {
+	w       io.Writer
+	prefix  string
+	partial []byte
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.partial = append(p.partial, data...)
+	for {
+		i := bytes.IndexByte(p.partial, '\n')
+		if i < 0 {
+			break
+		}
+
+		_, err := fmt.Fprintf(p.w, "%s%s", p.prefix, p.partial[:i+1])
+		if err != nil {
+			return 0, err
+		}
+		p.partial = p.partial[i+1:]
+	}
+
+	return len(data), nil
+}
+
+func (p *prefixWriter) Flush() {
+	if len(p.partial) > 0 {
+		fmt.Fprintf(p.w, "%s%s\n", p.prefix, p.partial)
+		p.partial = nil
+	}
+}
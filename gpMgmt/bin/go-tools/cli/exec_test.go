@@ -0,0 +1,96 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+func TestExecOnHosts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	mockHubExec := func(replies ...*idl.ExecReply) {
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			stream := mock_idl.NewMockHub_ExecClient(ctrl)
+			calls := make([]*gomock.Call, 0, len(replies)+1)
+			for _, reply := range replies {
+				calls = append(calls, stream.EXPECT().Recv().Return(reply, nil))
+			}
+			calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF))
+			gomock.InOrder(calls...)
+
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().Exec(gomock.Any(), &idl.ExecRequest{Hosts: []string{"sdw1", "sdw2"}, Command: "some command"}).Return(stream, nil)
+			return hubClient, nil
+		}
+	}
+
+	t.Run("prints the output of each host line by line with a host prefix", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubExec(
+			&idl.ExecReply{Host: "sdw1", Output: &idl.ExecReply_Stdout{Stdout: []byte("hello ")}},
+			&idl.ExecReply{Host: "sdw2", Output: &idl.ExecReply_Stdout{Stdout: []byte("line1\nline2\n")}},
+			&idl.ExecReply{Host: "sdw1", Output: &idl.ExecReply_Stdout{Stdout: []byte("world\nno newline")}},
+			&idl.ExecReply{Host: "sdw2", Output: &idl.ExecReply_Stderr{Stderr: []byte("oops\n")}},
+			&idl.ExecReply{Host: "sdw1", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw1", Success: true}}},
+			&idl.ExecReply{Host: "sdw2", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw2", Success: true}}},
+		)
+
+		var stdout, stderr bytes.Buffer
+		err := cli.ExecOnHosts([]string{"sdw1", "sdw2"}, "some command", &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "[sdw2] line1\n[sdw2] line2\n[sdw1] hello world\n[sdw1] no newline\n"
+		if stdout.String() != expected {
+			t.Fatalf("got %q, want %q", stdout.String(), expected)
+		}
+
+		expected = "[sdw2] oops\n"
+		if stderr.String() != expected {
+			t.Fatalf("got %q, want %q", stderr.String(), expected)
+		}
+	})
+
+	t.Run("returns an error when the command failed on some hosts", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubExec(
+			&idl.ExecReply{Host: "sdw1", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw1", Success: true}}},
+			&idl.ExecReply{Host: "sdw2", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw2", Code: 2, Message: "command exited with status 1 on host sdw2"}}},
+		)
+
+		var stdout, stderr bytes.Buffer
+		err := cli.ExecOnHosts([]string{"sdw1", "sdw2"}, "some command", &stdout, &stderr)
+		expectedErr := "could not run command: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		if !strings.Contains(stdout.String(), "command exited with status 1 on host sdw2") {
+			t.Fatalf("got %q, want it to contain the failure", stdout.String())
+		}
+	})
+
+	t.Run("returns an error when not able to connect to the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		expectedStr := "TEST Error connecting Hub"
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			return nil, errors.New(expectedStr)
+		}
+
+		err := cli.ExecOnHosts(nil, "some command", io.Discard, io.Discard)
+		if !strings.Contains(err.Error(), expectedStr) {
+			t.Fatalf("got %v, want %v", err, expectedStr)
+		}
+	})
+}
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	InstallHostCertificates = InstallHostCertificatesFunc
+	ReloadHubConfig         = ReloadHubConfigFunc
+
+	hostsHostfilePath string
+	hostsServiceDir   string
+	hostsServiceUser  string
+	hostsForce        bool
+)
+
+func hostsCmd() *cobra.Command {
+	hostsCmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Add hosts to or remove hosts from the cluster",
+	}
+
+	hostsCmd.AddCommand(
+		hostsAddCmd(),
+		hostsRemoveCmd(),
+	)
+
+	return hostsCmd
+}
+
+// addServiceFlags adds the flags locating the agent service files, which are
+// not part of gp.conf and default to the same values as for gp configure.
+func addServiceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&hostsServiceDir, "service-dir", "", `Path to service file directory (default the one of the service user)`)
+	cmd.Flags().StringVar(&hostsServiceUser, "service-user", os.Getenv("USER"), `User for whom the service is configured`)
+}
+
+func hostsAddCmd() *cobra.Command {
+	addCmd := &cobra.Command{
+		Use:   "add [--hostfile <file>] [<host>...]",
+		Short: "Add hosts to the cluster",
+		Long: `Add the given hosts to the cluster. The agent service is installed on the new
+hosts only, with certificates issued for them if the configured agent
+certificate does not cover them, and gp.conf is updated on all hosts. A running
+hub connects to the new hosts and starts their agents without being restarted.`,
+		PreRunE: InitializeCommand,
+		RunE:    RunHostsAdd,
+	}
+
+	addCmd.Flags().StringVar(&hostsHostfilePath, "hostfile", "", `Path to file containing a list of hostnames to add`)
+	addServiceFlags(addCmd)
+
+	return addCmd
+}
+
+func hostsRemoveCmd() *cobra.Command {
+	removeCmd := &cobra.Command{
+		Use:   "remove [--force] <host>...",
+		Short: "Remove hosts from the cluster",
+		Long: `Remove the given hosts from the cluster. The agents on the removed hosts are
+stopped and their service files removed, and gp.conf is updated on the
+remaining hosts. A running hub disconnects from the removed hosts without being
+restarted.`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: InitializeCommand,
+		RunE:    RunHostsRemove,
+	}
+
+	removeCmd.Flags().BoolVar(&hostsForce, "force", false, `Remove the hosts even if their agents could not be stopped, such as when the hosts are unreachable`)
+	addServiceFlags(removeCmd)
+
+	return removeCmd
+}
+
+func RunHostsAdd(cmd *cobra.Command, args []string) error {
+	hosts := args
+	if hostsHostfilePath != "" {
+		hostfileHosts, err := GetHostnames(hostsHostfilePath)
+		if err != nil {
+			return err
+		}
+		hosts = append(hosts, hostfileHosts...)
+	}
+	if len(hosts) == 0 {
+		return errors.New("at least one hostname must be provided either as an argument or using --hostfile")
+	}
+
+	configured := make(map[string]bool, len(Conf.Hostnames)+len(hosts))
+	for _, host := range Conf.Hostnames {
+		configured[host] = true
+	}
+	for _, host := range hosts {
+		if configured[host] {
+			return fmt.Errorf("host %s is already part of the cluster", host)
+		}
+		configured[host] = true
+	}
+
+	err := InstallHostCertificates(Conf, hosts)
+	if err != nil {
+		return err
+	}
+
+	serviceDir := agentServiceDir()
+	err = Platform.CreateServiceDir(hosts, serviceDir)
+	if err != nil {
+		return err
+	}
+
+	err = Platform.CreateAndInstallAgentServiceFile(hosts, Conf.GpHome, serviceDir, Conf.ServiceName, utils.WriteFileOverSSH)
+	if err != nil {
+		return err
+	}
+
+	err = Platform.EnableUserLingering(hosts, hostsServiceUser)
+	if err != nil {
+		return err
+	}
+
+	existing := Conf.Hostnames
+	Conf.Hostnames = append(existing[:len(existing):len(existing)], hosts...)
+	err = Conf.Write(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = distributeConfig(ConfigFilePath, existing, hubFileWriter(Conf))
+	if err != nil {
+		return err
+	}
+	err = distributeConfig(ConfigFilePath, hosts, utils.WriteFileOverSSH)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Added hosts %s to the cluster", strings.Join(hosts, ", "))
+
+	reloaded, err := ReloadHubConfig(Conf)
+	if err != nil || !reloaded {
+		return err
+	}
+
+	// The agents on the existing hosts are already running
+	_, err = StartAgentsOnHosts(Conf, hosts)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Agents started successfully")
+
+	return nil
+}
+
+func RunHostsRemove(cmd *cobra.Command, args []string) error {
+	configured := make(map[string]bool, len(Conf.Hostnames))
+	for _, host := range Conf.Hostnames {
+		configured[host] = true
+	}
+
+	removed := make(map[string]bool, len(args))
+	for _, host := range args {
+		if !configured[host] {
+			return fmt.Errorf("host %s is not part of the cluster", host)
+		}
+		removed[host] = true
+	}
+
+	remaining := make([]string, 0, len(Conf.Hostnames))
+	for _, host := range Conf.Hostnames {
+		if !removed[host] {
+			remaining = append(remaining, host)
+		}
+	}
+	if len(remaining) == 0 {
+		return errors.New("cannot remove all hosts from the cluster")
+	}
+
+	err := Platform.RemoveAgentServiceFile(args, agentServiceDir(), Conf.ServiceName)
+	if err != nil {
+		if !hostsForce {
+			return fmt.Errorf("%w; use --force to remove the hosts anyway", err)
+		}
+		gplog.Warn("%v; the agents on these hosts may still be running", err)
+	}
+
+	Conf.Hostnames = remaining
+	err = Conf.Write(ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	err = distributeConfig(ConfigFilePath, remaining, hubFileWriter(Conf))
+	if err != nil {
+		return err
+	}
+	gplog.Info("Removed hosts %s from the cluster", strings.Join(args, ", "))
+
+	_, err = ReloadHubConfig(Conf)
+	return err
+}
+
+func agentServiceDir() string {
+	if hostsServiceDir != "" {
+		return hostsServiceDir
+	}
+
+	return fmt.Sprintf(DefaultServiceDir, hostsServiceUser)
+}
+
+// InstallHostCertificatesFunc makes sure that the agents on the given new
+// hosts can present a certificate valid for them: either the configured agent
+// certificate covers them already, or certificates are issued for them by the
+// certificate authority of the cluster.
+func InstallHostCertificatesFunc(conf *hub.Config, hostnames []string) error {
+	credentials, ok := conf.Credentials.(*utils.GpCredentials)
+	if !ok {
+		return errors.New("hosts can only be added for file based credentials")
+	}
+
+	verifyErr := credentials.VerifyHostnames(hostnames)
+	if verifyErr == nil {
+		return nil
+	}
+	if credentials.CACertPath == "" || credentials.CAKeyPath == "" {
+		return verifyErr
+	}
+
+	ca, err := utils.LoadCertificateAuthority(credentials.CACertPath, credentials.CAKeyPath)
+	if err != nil {
+		return fmt.Errorf("%v, and could not issue certificates for the new hosts: %w", verifyErr, err)
+	}
+
+	certPath, keyPath := credentials.CertificatePaths(utils.RoleAgent)
+	_, err = ca.InstallAgentCertificates(hostnames, constants.CertificateValidity, credentials.CACertPath, certPath, keyPath)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Issued agent certificates for hosts %s", strings.Join(hostnames, ", "))
+
+	return nil
+}
+
+// ReloadHubConfigFunc has a running hub pick up the hosts in gp.conf. It
+// reports whether the hub was running; if it was not, it picks up the hosts
+// when it is started.
+func ReloadHubConfigFunc(conf *hub.Config) (bool, error) {
+	client, err := ConnectToHub(conf)
+	if err != nil {
+		gplog.Warn("Could not connect to the hub, which picks up the changed hosts when it is started: %v", err)
+		return false, nil
+	}
+
+	reply, err := client.ReloadConfig(context.Background(), &idl.ReloadConfigRequest{})
+	if err != nil {
+		return true, fmt.Errorf("could not reload the hub configuration: %w", err)
+	}
+	gplog.Debug("Hub added hosts %v and removed hosts %v", reply.AddedHosts, reply.RemovedHosts)
+
+	return true, nil
+}
@@ -0,0 +1,237 @@
+package cli_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestHosts(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	executor := &testutils.MockExecutor{}
+	utils.SetExecutor(executor)
+	defer utils.ResetExecutor()
+
+	configFilePath := cli.ConfigFilePath
+	defer func() { cli.ConfigFilePath = configFilePath }()
+
+	platform := cli.Platform
+	defer func() { cli.Platform = platform }()
+
+	parseFlags := func(t *testing.T, args ...string) {
+		t.Helper()
+
+		// Building the commands resets the configuration file path
+		configFilePath := cli.ConfigFilePath
+		defer func() { cli.ConfigFilePath = configFilePath }()
+
+		cmd, _, err := cli.RootCommand().Find(args[:2])
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.ParseFlags(args[2:])
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+
+	// setup configures a cluster of sdw1 and sdw2, and returns the hosts
+	// written to gp.conf.
+	setup := func(t *testing.T) func() []string {
+		t.Helper()
+
+		cli.Conf.Hostnames = []string{"sdw1", "sdw2"}
+		cli.ConfigFilePath = filepath.Join(t.TempDir(), "gp.conf")
+		cli.Platform = &testutils.MockPlatform{}
+		cli.DistributeFile = func(conf *hub.Config, hosts []string, contents []byte, mode os.FileMode, dest string) error {
+			return nil
+		}
+		executor.Hosts = nil
+
+		return func() []string {
+			contents, err := os.ReadFile(cli.ConfigFilePath)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			var conf struct {
+				Hostnames []string `json:"hostnames"`
+			}
+			err = json.Unmarshal(contents, &conf)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			return conf.Hostnames
+		}
+	}
+
+	t.Run("adds hosts and has the hub start their agents", func(t *testing.T) {
+		defer resetCLIVars()
+		written := setup(t)
+		parseFlags(t, "hosts", "add")
+
+		var certified []string
+		cli.InstallHostCertificates = func(conf *hub.Config, hostnames []string) error {
+			certified = hostnames
+			return nil
+		}
+		var calls []string
+		cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+			calls = append(calls, "reload")
+			return true, nil
+		}
+		var started []string
+		cli.StartAgentsOnHosts = func(hubConfig *hub.Config, hosts []string) (idl.HubClient, error) {
+			calls = append(calls, "start agents")
+			started = hosts
+			return nil, nil
+		}
+		var distributed []string
+		cli.DistributeFile = func(conf *hub.Config, hosts []string, contents []byte, mode os.FileMode, dest string) error {
+			distributed = append(distributed, hosts...)
+			return nil
+		}
+
+		err := cli.RunHostsAdd(nil, []string{"sdw3", "sdw4"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(certified, []string{"sdw3", "sdw4"}) {
+			t.Fatalf("got %v, want certificates for the new hosts only", certified)
+		}
+		expected := []string{"sdw1", "sdw2", "sdw3", "sdw4"}
+		if !reflect.DeepEqual(written(), expected) {
+			t.Fatalf("got %v, want %v", written(), expected)
+		}
+		// The agents of the new hosts are not running yet
+		if !reflect.DeepEqual(distributed, []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want gp.conf to be copied through the agents of the existing hosts", distributed)
+		}
+		if !reflect.DeepEqual(executor.Hosts, [][]string{{"sdw3", "sdw4"}}) {
+			t.Fatalf("got %v, want gp.conf to be copied over SSH to the new hosts", executor.Hosts)
+		}
+		if !reflect.DeepEqual(calls, []string{"reload", "start agents"}) {
+			t.Fatalf("got %v, want the hub to reload its configuration and start the agents", calls)
+		}
+		if !reflect.DeepEqual(started, []string{"sdw3", "sdw4"}) {
+			t.Fatalf("got %v, want the agents of the new hosts to be started and those of the existing hosts to be left alone", started)
+		}
+	})
+
+	t.Run("does not start the agents when the hub is not running", func(t *testing.T) {
+		defer resetCLIVars()
+		setup(t)
+		parseFlags(t, "hosts", "add")
+
+		cli.InstallHostCertificates = func(conf *hub.Config, hostnames []string) error {
+			return nil
+		}
+		cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+			return false, nil
+		}
+		cli.StartAgentsOnHosts = func(hubConfig *hub.Config, hosts []string) (idl.HubClient, error) {
+			t.Fatalf("unexpected start of the agents")
+			return nil, nil
+		}
+
+		err := cli.RunHostsAdd(nil, []string{"sdw3"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("rejects hosts which are already part of the cluster", func(t *testing.T) {
+		defer resetCLIVars()
+		setup(t)
+		parseFlags(t, "hosts", "add")
+
+		err := cli.RunHostsAdd(nil, []string{"sdw3", "sdw2"})
+		expected := "host sdw2 is already part of the cluster"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("removes hosts and has the hub disconnect from them", func(t *testing.T) {
+		defer resetCLIVars()
+		written := setup(t)
+		parseFlags(t, "hosts", "remove")
+
+		reloaded := false
+		cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+			reloaded = true
+			return true, nil
+		}
+
+		err := cli.RunHostsRemove(nil, []string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(written(), []string{"sdw2"}) {
+			t.Fatalf("got %v, want %v", written(), []string{"sdw2"})
+		}
+		if !reloaded {
+			t.Fatalf("expected the hub to reload its configuration")
+		}
+	})
+
+	t.Run("keeps the hosts whose agents could not be stopped unless forced", func(t *testing.T) {
+		defer resetCLIVars()
+		written := setup(t)
+		cli.Platform = &testutils.MockPlatform{Err: errors.New("could not remove agent service files from hosts: host sdw1: connection refused")}
+		cli.ReloadHubConfig = func(conf *hub.Config) (bool, error) {
+			return true, nil
+		}
+
+		parseFlags(t, "hosts", "remove")
+		err := cli.RunHostsRemove(nil, []string{"sdw1"})
+		if err == nil || !strings.HasSuffix(err.Error(), "use --force to remove the hosts anyway") {
+			t.Fatalf("got %v, want a suggestion to use --force", err)
+		}
+		if !reflect.DeepEqual(cli.Conf.Hostnames, []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want the hosts to be kept", cli.Conf.Hostnames)
+		}
+
+		parseFlags(t, "hosts", "remove", "--force")
+		err = cli.RunHostsRemove(nil, []string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !reflect.DeepEqual(written(), []string{"sdw2"}) {
+			t.Fatalf("got %v, want %v", written(), []string{"sdw2"})
+		}
+	})
+
+	t.Run("rejects removing unknown hosts or all hosts", func(t *testing.T) {
+		defer resetCLIVars()
+		setup(t)
+		parseFlags(t, "hosts", "remove")
+
+		err := cli.RunHostsRemove(nil, []string{"sdw3"})
+		expected := "host sdw3 is not part of the cluster"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		err = cli.RunHostsRemove(nil, []string{"sdw1", "sdw2"})
+		expected = "cannot remove all hosts from the cluster"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -18,19 +18,30 @@ var (
 	Platform          = utils.GetPlatform()
 	DefaultServiceDir = Platform.GetDefaultServiceDir()
 
-	agentPort      int
-	caCertPath     string
-	caKeyPath      string
-	gphome         string
-	hubLogDir      string
-	hubPort        int
-	hostnames      []string
-	hostfilePath   string
-	serverCertPath string
-	serverKeyPath  string
-	serviceDir     string // Provide the service file's directory and name separately so users can name different files for different clusters
-	serviceName    string
-	serviceUser    string
+	agentCertPath    string
+	agentKeyPath     string
+	agentMetricsPort int
+	agentPort        int
+	caCertPath       string
+	caKeyPath        string
+	clientCertPath   string
+	clientKeyPath    string
+	gphome           string
+	hubCertPath      string
+	hubKeyPath       string
+	hubLogDir        string
+	logMaxAgeDays    int
+	logMaxBackups    int
+	logMaxSizeMB     int
+	hubMetricsPort   int
+	hubPort          int
+	hostnames        []string
+	hostfilePath     string
+	serverCertPath   string
+	serverKeyPath    string
+	serviceDir       string // Provide the service file's directory and name separately so users can name different files for different clusters
+	serviceName      string
+	serviceUser      string
 )
 
 func hubCmd() *cobra.Command {
@@ -48,6 +59,7 @@ func hubCmd() *cobra.Command {
 
 func RunHub(cmd *cobra.Command, args []string) (err error) {
 	h := hub.New(Conf, nil)
+	h.ConfigFilePath = ConfigFilePath
 	err = h.Start()
 	if err != nil {
 		return err
@@ -60,39 +72,50 @@ func configureCmd() *cobra.Command {
 	configureCmd := &cobra.Command{
 		Use:    "configure",
 		Short:  "Configure gp as a systemd daemon",
+		Long: `Configure gp as a systemd daemon. Unless certificates are provided, the hub,
+agent and CLI certificates are issued from the certificate authority in
+<gphome>/certificates, which is created if "gp certs init" was not run before.`,
 		PreRunE: InitializeLogger,
 		RunE:   RunConfigure,
 	}
 
 	viper.AutomaticEnv()
 	// TODO: Adding input validation
+	configureCmd.Flags().IntVar(&agentMetricsPort, "agent-metrics-port", 0, `Port on which the agents should serve metrics over HTTP (default disabled)`)
 	configureCmd.Flags().IntVar(&agentPort, "agent-port", constants.DefaultAgentPort, `Port on which the agents should listen`)
 	configureCmd.Flags().StringVar(&gphome, "gphome", "/usr/local/greenplum-db", `Path to GPDB installation`)
+	configureCmd.Flags().IntVar(&hubMetricsPort, "hub-metrics-port", 0, `Port on which the hub should serve metrics over HTTP (default disabled)`)
 	configureCmd.Flags().IntVar(&hubPort, "hub-port", constants.DefaultHubPort, `Port on which the hub should listen`)
 	configureCmd.Flags().StringVar(&hubLogDir, "log-dir", constants.DefaultHubLogDir, `Path to gp hub log directory`)
+	configureCmd.Flags().IntVar(&logMaxSizeMB, "log-max-size", constants.DefaultLogMaxSizeMB, `Size in MB at which log files are rotated; 0 disables rotation by size`)
+	configureCmd.Flags().IntVar(&logMaxAgeDays, "log-max-age", constants.DefaultLogMaxAgeDays, `Age in days at which log files are rotated; 0 disables rotation by age`)
+	configureCmd.Flags().IntVar(&logMaxBackups, "log-max-backups", constants.DefaultLogMaxBackups, `Number of rotated log files to keep; 0 keeps all of them`)
 	configureCmd.Flags().StringVar(&serviceName, "service-name", constants.DefaultServiceName, `Name for the generated systemd service file`)
 	configureCmd.Flags().StringVar(&serviceDir, "service-dir", fmt.Sprintf(DefaultServiceDir, os.Getenv("USER")), `Path to service file directory`)
 	configureCmd.Flags().StringVar(&serviceUser, "service-user", os.Getenv("USER"), `User for whom to configure the service`)
-	// TLS credentials are deliberately left blank if not provided, and need to be filled in by the user
-	configureCmd.Flags().StringVar(&caCertPath, "ca-certificate", "", `Path to SSL/TLS CA certificate`)
-	configureCmd.Flags().StringVar(&caKeyPath, "ca-key", "", `Path to SSL/TLS CA private key`)
+	// The CA defaults to the one created by "gp certs init", or created here if there is
+	// none, which is then used to issue all other certificates unless they are provided
+	configureCmd.Flags().StringVar(&caCertPath, "ca-certificate", "", `Path to SSL/TLS CA certificate (default "<gphome>/certificates/ca-cert.pem", created if it does not exist)`)
+	configureCmd.Flags().StringVar(&caKeyPath, "ca-key", "", `Path to SSL/TLS CA private key (default "<gphome>/certificates/ca-key.pem")`)
 	configureCmd.Flags().StringVar(&serverCertPath, "server-certificate", "", `Path to hub SSL/TLS server certificate`)
 	configureCmd.Flags().StringVar(&serverKeyPath, "server-key", "", `Path to hub SSL/TLS server private key`)
+	configureCmd.MarkFlagsRequiredTogether("ca-certificate", "ca-key")
+	configureCmd.MarkFlagsRequiredTogether("server-certificate", "server-key")
+	// Separate identities for each role are optional, and default to the server certificate
+	configureCmd.Flags().StringVar(&hubCertPath, "hub-certificate", "", `Path to SSL/TLS certificate identifying the hub`)
+	configureCmd.Flags().StringVar(&hubKeyPath, "hub-key", "", `Path to SSL/TLS private key identifying the hub`)
+	configureCmd.Flags().StringVar(&agentCertPath, "agent-certificate", "", `Path to SSL/TLS certificate identifying the agents`)
+	configureCmd.Flags().StringVar(&agentKeyPath, "agent-key", "", `Path to SSL/TLS private key identifying the agents`)
+	configureCmd.Flags().StringVar(&clientCertPath, "client-certificate", "", `Path to SSL/TLS certificate identifying the gp CLI`)
+	configureCmd.Flags().StringVar(&clientKeyPath, "client-key", "", `Path to SSL/TLS private key identifying the gp CLI`)
+	configureCmd.MarkFlagsRequiredTogether("hub-certificate", "hub-key")
+	configureCmd.MarkFlagsRequiredTogether("agent-certificate", "agent-key")
+	configureCmd.MarkFlagsRequiredTogether("client-certificate", "client-key")
 	// Allow passing a hostfile for "real" use cases or a few host names for tests, but not both
 	configureCmd.Flags().StringArrayVar(&hostnames, "host", []string{}, `Segment hostname`)
 	configureCmd.Flags().StringVar(&hostfilePath, "hostfile", "", `Path to file containing a list of segment hostnames`)
 	configureCmd.MarkFlagsMutuallyExclusive("host", "hostfile")
 
-	requiredFlags := []string{
-		"ca-certificate",
-		"ca-key",
-		"server-certificate",
-		"server-key",
-	}
-	for _, flag := range requiredFlags {
-		configureCmd.MarkFlagRequired(flag) // nolint
-	}
-
 	viper.BindPFlag("gphome", configureCmd.Flags().Lookup("gphome")) // nolint
 	gphome = viper.GetString("gphome")
 
@@ -117,9 +140,21 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("at least one hostname must be provided using either --host or --hostfile")
 	}
 	
+	if LogFormat != "" {
+		err = utils.ValidateLogFormat(LogFormat)
+		if err != nil {
+			return err
+		}
+	}
+
 	if agentPort == hubPort {
 		return errors.New("hub port and agent port must be different")
 	}
+	for _, metricsPort := range []int{hubMetricsPort, agentMetricsPort} {
+		if metricsPort != 0 && (metricsPort == hubPort || metricsPort == agentPort) {
+			return fmt.Errorf("metrics port %d must be different from the hub and agent ports", metricsPort)
+		}
+	}
 
 	// Convert file/directory paths to absolute path before writing to gp.Conf file
 	err = resolveAbsolutePaths(cmd)
@@ -141,6 +176,12 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 			return fmt.Errorf("empty host name found -- please provide a valid input host name")
 		}
 	}
+	defaultCA := caCertPath == ""
+	if defaultCA {
+		caCertPath = filepath.Join(gphome, constants.CertificatesDir, utils.CACertFileName)
+		caKeyPath = filepath.Join(gphome, constants.CertificatesDir, utils.CAKeyFileName)
+	}
+
 	Conf = &hub.Config{
 		Port:        hubPort,
 		AgentPort:   agentPort,
@@ -148,19 +189,65 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		LogDir:      hubLogDir,
 		ServiceName: serviceName,
 		GpHome:      gphome,
-		Credentials: &utils.GpCredentials{
-			CACertPath:     caCertPath,
-			CAKeyPath:      caKeyPath,
-			ServerCertPath: serverCertPath,
-			ServerKeyPath:  serverKeyPath,
-		},
+		// Empty unless --log-format was given, for the default text format
+		LogFormat:     LogFormat,
+		LogMaxSizeMB:  logMaxSizeMB,
+		LogMaxAgeDays: logMaxAgeDays,
+		LogMaxBackups: logMaxBackups,
+		// Zero if the metrics were not enabled
+		MetricsPort:      hubMetricsPort,
+		AgentMetricsPort: agentMetricsPort,
+	}
+	credentials := &utils.GpCredentials{
+		CACertPath:     caCertPath,
+		CAKeyPath:      caKeyPath,
+		ServerCertPath: serverCertPath,
+		ServerKeyPath:  serverKeyPath,
+		HubCertPath:    hubCertPath,
+		HubKeyPath:     hubKeyPath,
+		AgentCertPath:  agentCertPath,
+		AgentKeyPath:   agentKeyPath,
+		ClientCertPath: clientCertPath,
+		ClientKeyPath:  clientKeyPath,
+	}
+	Conf.Credentials = credentials
+
+	if serverCertPath == "" && hubCertPath == "" && agentCertPath == "" && clientCertPath == "" {
+		if defaultCA {
+			err = EnsureCertificateAuthority(caCertPath, caKeyPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = IssueCertificates(credentials, hostnames, constants.CertificateValidity)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = credentials.VerifyHostnames(hostnames)
+		if err != nil {
+			return err
+		}
+
+		// The default policy only knows the certificates gp issues itself
+		Conf.Authorization, err = utils.CertificateAuthorizationPolicy(*credentials)
+		if err != nil {
+			return err
+		}
 	}
+
 	err = Conf.Write(ConfigFilePath)
 	if err != nil {
 		return err
 	}
 
-	err = Platform.CreateServiceDir(hostnames, serviceDir, gphome)
+	err = distributeConfig(ConfigFilePath, hostnames, hubFileWriter(Conf))
+	if err != nil {
+		return err
+	}
+
+	err = Platform.CreateServiceDir(hostnames, serviceDir)
 	if err != nil {
 		return err
 	}
@@ -170,12 +257,12 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	err = Platform.CreateAndInstallAgentServiceFile(hostnames, gphome, serviceDir, serviceName)
+	err = Platform.CreateAndInstallAgentServiceFile(hostnames, gphome, serviceDir, serviceName, hubFileWriter(Conf))
 	if err != nil {
 		return err
 	}
 
-	err = Platform.EnableUserLingering(hostnames, gphome, serviceUser)
+	err = Platform.EnableUserLingering(hostnames, serviceUser)
 	if err != nil {
 		return err
 	}
@@ -184,8 +271,13 @@ func RunConfigure(cmd *cobra.Command, args []string) (err error) {
 }
 
 func resolveAbsolutePaths(cmd *cobra.Command) error {
-	paths := []*string{&caCertPath, &caKeyPath, &serverCertPath, &serverKeyPath, &hubLogDir, &gphome}
+	paths := []*string{&caCertPath, &caKeyPath, &serverCertPath, &serverKeyPath, &hubLogDir, &gphome,
+		&hubCertPath, &hubKeyPath, &agentCertPath, &agentKeyPath, &clientCertPath, &clientKeyPath}
 	for _, path := range paths {
+		if *path == "" { // optional paths which were not given
+			continue
+		}
+
 		p, err := filepath.Abs(*path)
 		if err != nil {
 			return fmt.Errorf("error resolving absolute path for %s: %w", *path, err)
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+var (
+	CollectLogs = CollectLogsFunc
+	TailLogs    = TailLogsFunc
+
+	logsHosts          []string
+	logsSince          string
+	logsUntil          string
+	logsSegmentLogDirs []string
+	logsOutput         string
+	logsDataDirs       []string
+	logsLines          int
+	logsFollow         bool
+	logsSeverity       string
+	logsGrep           string
+)
+
+func logsCmd() *cobra.Command {
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Work with the logs of the gp services",
+	}
+
+	logsCmd.AddCommand(
+		logsCollectCmd(),
+		logsTailCmd(),
+	)
+
+	return logsCmd
+}
+
+func logsCollectCmd() *cobra.Command {
+	collectCmd := &cobra.Command{
+		Use:   "collect [--host <host>]... [--since <time>] [--until <time>] [--segment-log-dir <dir>]... [--output <file>]",
+		Short: "Collect the logs of the hub and agents into one archive",
+		Long: `Collect the logs of the hub and of the agents on the given hosts, or on all
+hosts if none are given, into a single tar.gz archive on this host. The archive
+contains the gp logs and the service log of each host, the segment logs in the
+directories given with --segment-log-dir, and a manifest listing the collected
+files. Only logs written to since --since are collected, which, like --until,
+accepts a duration such as 24h, a date such as 2006-01-02, or an RFC 3339
+timestamp.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunLogsCollect,
+	}
+
+	collectCmd.Flags().StringSliceVar(&logsHosts, "host", nil, `Host to collect the logs of; may be repeated`)
+	collectCmd.Flags().StringVar(&logsSince, "since", "", `Only collect logs written to after this time`)
+	collectCmd.Flags().StringVar(&logsUntil, "until", "", `Only collect service log entries before this time`)
+	collectCmd.Flags().StringArrayVar(&logsSegmentLogDirs, "segment-log-dir", nil, `Segment log directory to collect on every host, such as "/data/*/gpseg*/log"; may be repeated`)
+	collectCmd.Flags().StringVar(&logsOutput, "output", "", `Path of the archive to write (default "gp-logs-<time>.tar.gz" in the current directory)`)
+
+	return collectCmd
+}
+
+func RunLogsCollect(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := ParseSince(logsSince, now)
+	if err != nil {
+		return fmt.Errorf("invalid value for --since: %w", err)
+	}
+	until, err := ParseSince(logsUntil, now)
+	if err != nil {
+		return fmt.Errorf("invalid value for --until: %w", err)
+	}
+
+	output := logsOutput
+	if output == "" {
+		output = fmt.Sprintf("gp-logs-%s.tar.gz", now.Format("20060102-150405"))
+	}
+	destination, err := filepath.Abs(output)
+	if err != nil {
+		return fmt.Errorf("could not resolve output path %s: %w", output, err)
+	}
+
+	request := &idl.CollectLogsRequest{
+		Hosts:          logsHosts,
+		SegmentLogDirs: logsSegmentLogDirs,
+		Destination:    destination,
+	}
+	if !since.IsZero() {
+		request.Since = since.Unix()
+	}
+	if !until.IsZero() {
+		request.Until = until.Unix()
+	}
+
+	return CollectLogs(request)
+}
+
+func CollectLogsFunc(request *idl.CollectLogsRequest) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.CollectLogs(context.Background(), request)
+	if err != nil {
+		return fmt.Errorf("could not collect logs: %w", err)
+	}
+	gplog.Info("Wrote logs to %s", request.Destination)
+
+	return CheckHostResults(os.Stdout, "collect logs", reply.GetResults())
+}
+
+func logsTailCmd() *cobra.Command {
+	tailCmd := &cobra.Command{
+		Use:   "tail [--host <host>]... [--data-dir <dir>]... [--lines <n>] [--follow] [--severity <severity>] [--grep <regex>]",
+		Short: "Print the last entries of the logs of the agents and segments",
+		Long: `Print the last entries of the agent log on the given hosts, or on all hosts if
+none are given, and of the current CSV log of every segment whose data
+directory matches a --data-dir pattern on those hosts. Every line is prefixed
+with the host and, for segment logs, the name of the segment's data directory,
+such as gpseg0. With --follow, entries are printed as they are written until
+interrupted.
+
+Entries may be limited to those of at least a given severity, such as WARNING
+or ERROR, and to those matching a regular expression.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunLogsTail,
+	}
+
+	tailCmd.Flags().StringSliceVar(&logsHosts, "host", nil, `Host to tail the logs of; may be repeated`)
+	tailCmd.Flags().StringArrayVar(&logsDataDirs, "data-dir", nil, `Segment data directory to tail the log of on every host, such as "/data/primary/gpseg*"; may be repeated`)
+	tailCmd.Flags().IntVar(&logsLines, "lines", 10, `Number of existing entries to print from each log`)
+	tailCmd.Flags().BoolVar(&logsFollow, "follow", false, `Print new entries as they are written`)
+	tailCmd.Flags().StringVar(&logsSeverity, "severity", "", `Only print entries of at least this severity`)
+	tailCmd.Flags().StringVar(&logsGrep, "grep", "", `Only print entries matching this regular expression`)
+
+	return tailCmd
+}
+
+func RunLogsTail(cmd *cobra.Command, args []string) error {
+	if logsLines < 0 {
+		return fmt.Errorf("invalid value for --lines: %d is negative", logsLines)
+	}
+
+	// Catch an invalid filter before contacting the hub
+	_, err := utils.NewLogFilter(logsSeverity, logsGrep)
+	if err != nil {
+		return err
+	}
+
+	return TailLogs(&idl.TailLogsRequest{
+		Hosts:    logsHosts,
+		DataDirs: logsDataDirs,
+		Lines:    int32(logsLines),
+		Follow:   logsFollow,
+		Severity: logsSeverity,
+		Pattern:  logsGrep,
+	}, os.Stdout)
+}
+
+// TailLogsFunc prints the log entries sent by the hub to stdout as they arrive,
+// prefixed with the host and segment they came from. Hosts whose logs could
+// not be tailed are warned about straight away, as following the logs only
+// ends once interrupted.
+func TailLogsFunc(request *idl.TailLogsRequest, stdout io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stream, err := client.TailLogs(ctx, request)
+	if err != nil {
+		return fmt.Errorf("could not tail logs: %w", err)
+	}
+
+	writers := make(map[string]*prefixWriter)
+	writer := func(host string, dataDir string) *prefixWriter {
+		prefix := fmt.Sprintf("[%s] ", host)
+		if dataDir != "" {
+			prefix = fmt.Sprintf("[%s:%s] ", host, filepath.Base(dataDir))
+		}
+		if writers[prefix] == nil {
+			writers[prefix] = &prefixWriter{w: stdout, prefix: prefix}
+		}
+
+		return writers[prefix]
+	}
+
+	var results []*idl.HostResult
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if grpcStatus.Code(err) == codes.Canceled && ctx.Err() != nil {
+			// Following the logs was interrupted
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not tail logs: %w", err)
+		}
+
+		switch output := reply.Output.(type) {
+		case *idl.TailLogsReply_Entry:
+			fmt.Fprintln(writer(reply.Host, output.Entry.DataDir), output.Entry.Text)
+		case *idl.TailLogsReply_Result:
+			if !output.Result.Success {
+				gplog.Warn("Could not tail the logs on host %s: %s", reply.Host, output.Result.Message)
+			}
+			results = append(results, output.Result)
+		}
+	}
+
+	return CheckHostResults(stdout, "tail logs", results)
+}
@@ -0,0 +1,142 @@
+package cli_test
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+)
+
+func TestRunLogsCollect(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("requests the logs of the time window to be written to an archive in the current directory", func(t *testing.T) {
+		defer resetCLIVars()
+		var request *idl.CollectLogsRequest
+		cli.CollectLogs = func(r *idl.CollectLogsRequest) error {
+			request = r
+			return nil
+		}
+
+		cmd, _, err := cli.RootCommand().Find([]string{"logs", "collect"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.ParseFlags([]string{"--since", "2h", "--segment-log-dir", "/data/*/log"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		start := time.Now()
+		err = cli.RunLogsCollect(cmd, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if request == nil {
+			t.Fatalf("expected the logs to be collected")
+		}
+		if !filepath.IsAbs(request.Destination) || !strings.HasPrefix(filepath.Base(request.Destination), "gp-logs-") {
+			t.Fatalf("got %s, want an absolute path to a gp-logs archive", request.Destination)
+		}
+		since := time.Unix(request.Since, 0)
+		if since.After(start.Add(-2*time.Hour)) || since.Before(start.Add(-2*time.Hour-time.Minute)) {
+			t.Fatalf("got %v, want two hours before %v", since, start)
+		}
+		if len(request.SegmentLogDirs) != 1 || request.SegmentLogDirs[0] != "/data/*/log" {
+			t.Fatalf("got %v, want %v", request.SegmentLogDirs, []string{"/data/*/log"})
+		}
+		if request.Until != 0 {
+			t.Fatalf("got %d, want no end of the time window", request.Until)
+		}
+	})
+}
+
+func TestTailLogs(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.TailLogsRequest{Lines: 10}
+	mockHubTailLogs := func(replies ...*idl.TailLogsReply) {
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			stream := mock_idl.NewMockHub_TailLogsClient(ctrl)
+			calls := make([]*gomock.Call, 0, len(replies)+1)
+			for _, reply := range replies {
+				calls = append(calls, stream.EXPECT().Recv().Return(reply, nil))
+			}
+			calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF))
+			gomock.InOrder(calls...)
+
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().TailLogs(gomock.Any(), request).Return(stream, nil)
+			return hubClient, nil
+		}
+	}
+
+	t.Run("prints every entry with its host and segment", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubTailLogs(
+			&idl.TailLogsReply{Host: "sdw1", Output: &idl.TailLogsReply_Entry{Entry: &idl.TailLogsAgentReply{Text: "agent entry"}}},
+			&idl.TailLogsReply{Host: "sdw2", Output: &idl.TailLogsReply_Entry{Entry: &idl.TailLogsAgentReply{DataDir: "/data/primary/gpseg1", Text: "segment\nentry"}}},
+			&idl.TailLogsReply{Host: "sdw1", Output: &idl.TailLogsReply_Result{Result: &idl.HostResult{Host: "sdw1", Success: true}}},
+			&idl.TailLogsReply{Host: "sdw2", Output: &idl.TailLogsReply_Result{Result: &idl.HostResult{Host: "sdw2", Success: true}}},
+		)
+
+		var stdout bytes.Buffer
+		err := cli.TailLogs(request, &stdout)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "[sdw1] agent entry\n[sdw2:gpseg1] segment\n[sdw2:gpseg1] entry\n"
+		if stdout.String() != expected {
+			t.Fatalf("got %q, want %q", stdout.String(), expected)
+		}
+	})
+
+	t.Run("returns an error when the logs could not be tailed on some hosts", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubTailLogs(
+			&idl.TailLogsReply{Host: "sdw1", Output: &idl.TailLogsReply_Result{Result: &idl.HostResult{Host: "sdw1", Code: 13, Message: "could not tail logs on host sdw1"}}},
+			&idl.TailLogsReply{Host: "sdw2", Output: &idl.TailLogsReply_Result{Result: &idl.HostResult{Host: "sdw2", Success: true}}},
+		)
+
+		var stdout bytes.Buffer
+		err := cli.TailLogs(request, &stdout)
+		expected := "could not tail logs: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("rejects an invalid filter before contacting the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.TailLogs = func(request *idl.TailLogsRequest, stdout io.Writer) error {
+			t.Fatalf("unexpected call to tail the logs")
+			return nil
+		}
+
+		cmd, _, err := cli.RootCommand().Find([]string{"logs", "tail"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.ParseFlags([]string{"--grep", "("})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = cli.RunLogsTail(cmd, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), `invalid pattern "(":`) {
+			t.Fatalf("got %v, want an invalid pattern error", err)
+		}
+	})
+}
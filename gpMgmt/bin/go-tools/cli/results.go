@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"google.golang.org/grpc/codes"
+)
+
+// HostFailureError is returned when an operation that was fanned out to the
+// agent hosts failed on some or all of them.
+type HostFailureError struct {
+	Operation string
+	Failed    int
+	Total     int
+}
+
+func (e *HostFailureError) Error() string {
+	if e.Failed == e.Total {
+		return fmt.Sprintf("could not %s: failed on all %d hosts", e.Operation, e.Total)
+	}
+
+	return fmt.Sprintf("could not %s: failed on %d of %d hosts", e.Operation, e.Failed, e.Total)
+}
+
+// ExitCode lets callers distinguish a partial failure, where some hosts still
+// succeeded, from a total failure.
+func (e *HostFailureError) ExitCode() int {
+	if e.Failed == e.Total {
+		return constants.ExitCodeTotalFailure
+	}
+
+	return constants.ExitCodePartialFailure
+}
+
+// CheckHostResults prints a summary table of the hosts on which an operation
+// failed, and returns a HostFailureError if there were any.
+func CheckHostResults(outfile io.Writer, operation string, results []*idl.HostResult) error {
+	failed := make([]*idl.HostResult, 0)
+	for _, result := range results {
+		if !result.Success {
+			failed = append(failed, result)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tSTATUS\tCODE\tMESSAGE")
+	for _, result := range failed {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Host, "failed", codes.Code(result.Code), result.Message)
+	}
+	w.Flush()
+	fmt.Fprintf(outfile, "%d of %d hosts failed\n", len(failed), len(results))
+
+	return &HostFailureError{Operation: operation, Failed: len(failed), Total: len(results)}
+}
@@ -0,0 +1,84 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCheckHostResults(t *testing.T) {
+	t.Run("succeeds without output when all the hosts succeeded", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.CheckHostResults(&buf, "start agents", []*idl.HostResult{
+			{Host: "sdw1", Success: true},
+			{Host: "sdw2", Success: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if buf.Len() != 0 {
+			t.Fatalf("got %q, want no output", buf.String())
+		}
+	})
+
+	t.Run("reports a partial failure when only some hosts failed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.CheckHostResults(&buf, "start agents", []*idl.HostResult{
+			{Host: "sdw1", Success: true},
+			{Host: "sdw2", Code: int32(codes.Unavailable), Message: "connection refused"},
+		})
+
+		var hostErr *cli.HostFailureError
+		if !errors.As(err, &hostErr) {
+			t.Fatalf("got %T, want %T", err, hostErr)
+		}
+
+		expectedErr := "could not start agents: failed on 1 of 2 hosts"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+
+		if hostErr.ExitCode() != constants.ExitCodePartialFailure {
+			t.Fatalf("got %d, want %d", hostErr.ExitCode(), constants.ExitCodePartialFailure)
+		}
+
+		output := buf.String()
+		for _, expected := range []string{"sdw2", "Unavailable", "connection refused", "1 of 2 hosts failed"} {
+			if !strings.Contains(output, expected) {
+				t.Fatalf("got %q, want it to contain %q", output, expected)
+			}
+		}
+		if strings.Contains(output, "sdw1") {
+			t.Fatalf("got %q, want successful hosts to be omitted", output)
+		}
+	})
+
+	t.Run("reports a total failure when all the hosts failed", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.CheckHostResults(&buf, "stop agents", []*idl.HostResult{
+			{Host: "sdw1", Code: int32(codes.Unknown), Message: "error"},
+			{Host: "sdw2", Code: int32(codes.Unknown), Message: "error"},
+		})
+
+		var hostErr *cli.HostFailureError
+		if !errors.As(err, &hostErr) {
+			t.Fatalf("got %T, want %T", err, hostErr)
+		}
+
+		expectedErr := "could not stop agents: failed on all 2 hosts"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+
+		if hostErr.ExitCode() != constants.ExitCodeTotalFailure {
+			t.Fatalf("got %d, want %d", hostErr.ExitCode(), constants.ExitCodeTotalFailure)
+		}
+	})
+}
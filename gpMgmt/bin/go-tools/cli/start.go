@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
@@ -31,6 +32,7 @@ var (
 	RunStartHub            = RunStartHubFunc
 	RunStartAgent          = RunStartAgentFunc
 	StartAgentsAll         = StartAgentsAllFunc
+	StartAgentsOnHosts     = StartAgentsOnHostsFunc
 	RunStartService        = RunStartServiceFunc
 	WaitAndRetryHubConnect = WaitAndRetryHubConnectFunc
 )
@@ -113,17 +115,23 @@ func RunStartAgentFunc(cmd *cobra.Command, args []string) error {
 }
 
 func StartAgentsAllFunc(hubConfig *hub.Config) (idl.HubClient, error) {
+	return StartAgentsOnHosts(hubConfig, nil)
+}
+
+// StartAgentsOnHostsFunc has the hub start the agents on the given hosts, or
+// on all hosts if none are given.
+func StartAgentsOnHostsFunc(hubConfig *hub.Config, hosts []string) (idl.HubClient, error) {
 	client, err := ConnectToHub(hubConfig)
 	if err != nil {
 		return client, err
 	}
 
-	_, err = client.StartAgents(context.Background(), &idl.StartAgentsRequest{})
+	reply, err := client.StartAgents(context.Background(), &idl.StartAgentsRequest{Hosts: hosts})
 	if err != nil {
 		return client, fmt.Errorf("could not start agents: %w", err)
 	}
 
-	return client, nil
+	return client, CheckHostResults(os.Stdout, "start agents", reply.GetResults())
 }
 
 func RunStartServiceFunc(cmd *cobra.Command, args []string) error {
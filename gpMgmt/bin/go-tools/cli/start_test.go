@@ -56,6 +56,18 @@ func TestStartAgentsAll(t *testing.T) {
 			t.Fatalf("unexpected error: %#v", err)
 		}
 	})
+	t.Run("starts the agents on the given hosts", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().StartAgents(gomock.Any(), &idl.StartAgentsRequest{Hosts: []string{"sdw3"}})
+			return hubClient, nil
+		}
+		_, err := cli.StartAgentsOnHosts(cli.Conf, []string{"sdw3"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
 	t.Run("start all agents fails on error connecting hub", func(t *testing.T) {
 		defer resetCLIVars()
 		expectedStr := "error connecting hub"
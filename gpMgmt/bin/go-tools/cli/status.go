@@ -2,9 +2,16 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/spf13/cobra"
@@ -13,7 +20,15 @@ import (
 var (
 	ShowHubStatus       = ShowHubStatusFunc
 	ShowAgentsStatus    = ShowAgentsStatusFunc
+	ShowAgentsDetail    = ShowAgentsDetailFunc
 	PrintServicesStatus = PrintServicesStatusFunc
+	GetHubCertificate   = GetHubCertificateFunc
+	ShowCertificates    = ShowCertificatesFunc
+
+	certificateExpiryWarning = constants.CertificateExpiryWarning
+	statusCertificates       bool
+	statusDetail             bool
+	statusDataDirs           []string
 )
 
 func statusCmd() *cobra.Command {
@@ -22,6 +37,9 @@ func statusCmd() *cobra.Command {
 		Short: "Display status",
 	}
 
+	statusCmd.PersistentFlags().DurationVar(&certificateExpiryWarning, "certificate-expiry-warning", constants.CertificateExpiryWarning, `Warn about certificates expiring within this duration`)
+	statusCmd.PersistentFlags().BoolVar(&statusCertificates, "certificates", false, `Also show the subject, issuer, names and expiry of the certificates presented by the services`)
+
 	statusCmd.AddCommand(statusHubCmd())
 	statusCmd.AddCommand(statusAgentsCmd())
 	statusCmd.AddCommand(statusServicesCmd())
@@ -41,22 +59,33 @@ func statusHubCmd() *cobra.Command {
 }
 
 func RunStatusHub(cmd *cobra.Command, args []string) error {
-	_, err := ShowHubStatus(Conf, false)
+	running, err := ShowHubStatus(Conf, false)
 	if err != nil {
 		return err
 	}
 
+	if statusCertificates && running {
+		fmt.Println()
+		return ShowCertificates(Conf, true, false)
+	}
+
 	return nil
 }
 
 func statusAgentsCmd() *cobra.Command {
 	statusAgentsCmd := &cobra.Command{
-		Use:     "agents",
-		Short:   "Display agents status",
+		Use:   "agents [--detail [--data-dir <dir>]...]",
+		Short: "Display agents status",
+		Long: `Display the status of the agents. With --detail, the state of every agent host
+is shown too: its load, CPUs, memory, swap, kernel and gp version, and the free
+space of the data directories matching the --data-dir patterns.`,
 		PreRunE: InitializeCommand,
 		RunE:    RunStatusAgent,
 	}
 
+	statusAgentsCmd.Flags().BoolVar(&statusDetail, "detail", false, `Show the state of every agent host`)
+	statusAgentsCmd.Flags().StringArrayVar(&statusDataDirs, "data-dir", nil, `Data directory to show the free space of on every host, such as "/data/primary/gpseg*"; may be repeated`)
+
 	return statusAgentsCmd
 }
 
@@ -72,12 +101,29 @@ func statusServicesCmd() *cobra.Command {
 }
 
 func RunStatusAgent(cmd *cobra.Command, args []string) error {
+	if len(statusDataDirs) > 0 && !statusDetail {
+		return errors.New("--data-dir can only be given with --detail")
+	}
+
 	err := ShowAgentsStatus(Conf, false)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if statusCertificates {
+		fmt.Println()
+		err = ShowCertificates(Conf, false, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !statusDetail {
+		return nil
+	}
+
+	fmt.Println()
+	return ShowAgentsDetail(Conf, statusDataDirs)
 }
 
 func ShowHubStatusFunc(conf *hub.Config, skipHeader bool) (bool, error) {
@@ -87,7 +133,29 @@ func ShowHubStatusFunc(conf *hub.Config, skipHeader bool) (bool, error) {
 	}
 	status := Platform.ParseServiceStatusMessage(message)
 	status.Host, _ = os.Hostname()
+
+	// The service manager only knows whether the process is running, so ask
+	// the hub itself whether it is actually able to serve requests.
+	var healthErr error
+	if status.Status == "running" {
+		healthErr = CheckHubHealth(conf)
+		if healthErr != nil {
+			status.Status = "unhealthy"
+		}
+	}
+	if healthErr == nil && status.Status == "running" {
+		status.Certificate, err = GetHubCertificate(conf)
+		if err != nil {
+			gplog.Debug("Could not get hub certificate: %v", err)
+		}
+		setCertificateWarnings([]*idl.ServiceStatus{&status})
+	}
+
 	Platform.DisplayServiceStatus(os.Stdout, "Hub", []*idl.ServiceStatus{&status}, skipHeader)
+	if healthErr != nil {
+		gplog.Warn("Hub is running but unhealthy: %v", healthErr)
+		return false, nil
+	}
 	if status.Status == "Unknown" {
 		return false, nil
 	}
@@ -105,9 +173,93 @@ func ShowAgentsStatusFunc(conf *hub.Config, skipHeader bool) error {
 	if err != nil {
 		return err
 	}
+	setCertificateWarnings(reply.Statuses)
 	Platform.DisplayServiceStatus(os.Stdout, "Agent", reply.Statuses, skipHeader)
 
-	return nil
+	return CheckHostResults(os.Stdout, "get agent status", reply.GetResults())
+}
+
+// ShowAgentsDetailFunc displays the state of every agent host, including the
+// free space of the data directories matching dataDirs.
+func ShowAgentsDetailFunc(conf *hub.Config, dataDirs []string) error {
+	client, err := ConnectToHub(conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.HostInfo(context.Background(), &idl.HostInfoRequest{DataDirs: dataDirs})
+	if err != nil {
+		return fmt.Errorf("could not get host info: %w", err)
+	}
+	PrintHostInfo(os.Stdout, reply.Hosts)
+
+	return CheckHostResults(os.Stdout, "get host info", reply.GetResults())
+}
+
+// PrintHostInfo prints a table of the state of the hosts, followed by one of
+// the free space of their data directories if any were reported.
+func PrintHostInfo(outfile io.Writer, infos []*idl.HostInfo) {
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tLOAD\tCPUS\tMEMORY AVAILABLE\tSWAP FREE\tKERNEL\tGP VERSION")
+	for _, info := range infos {
+		load := "-"
+		if info.Load1 != 0 || info.Load5 != 0 || info.Load15 != 0 {
+			load = fmt.Sprintf("%.2f %.2f %.2f", info.Load1, info.Load5, info.Load15)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n", info.Host, load, info.Cpus,
+			formatCapacity(info.MemoryAvailable, info.MemoryTotal), formatCapacity(info.SwapFree, info.SwapTotal),
+			info.KernelVersion, info.GpVersion)
+	}
+	w.Flush()
+
+	disks := false
+	for _, info := range infos {
+		disks = disks || len(info.Disks) > 0
+	}
+	if !disks {
+		return
+	}
+
+	fmt.Fprintln(outfile)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tDATA DIRECTORY\tFREE\tSIZE\tUSED")
+	for _, info := range infos {
+		for _, disk := range info.Disks {
+			used := "-"
+			if disk.Total > 0 {
+				used = fmt.Sprintf("%d%%", (disk.Total-disk.Free)*100/disk.Total)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.Host, disk.Path, FormatBytes(disk.Free), FormatBytes(disk.Total), used)
+		}
+	}
+	w.Flush()
+}
+
+// formatCapacity describes how much of a total is left, or returns "-" if the
+// total is not known.
+func formatCapacity(free uint64, total uint64) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s of %s", FormatBytes(free), FormatBytes(total))
+}
+
+// FormatBytes formats a size in bytes with a binary unit, such as "1.5 GiB".
+func FormatBytes(size uint64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < 5 {
+		value /= 1024
+		unit++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", value, "BKMGTP"[unit])
 }
 
 func RunServiceStatus(cmd *cobra.Command, args []string) error {
@@ -126,7 +278,7 @@ func PrintServicesStatusFunc() error {
 		return err
 	}
 	if !hubRunning {
-		fmt.Println("Hub service not running, not able to fetch agent status.")
+		fmt.Println("Hub service not running or unhealthy, not able to fetch agent status.")
 		return nil
 	}
 	err = ShowAgentsStatus(Conf, true)
@@ -134,5 +286,117 @@ func PrintServicesStatusFunc() error {
 		return err
 	}
 
+	if statusCertificates {
+		fmt.Println()
+		return ShowCertificates(Conf, true, true)
+	}
+
+	return nil
+}
+
+// ShowCertificatesFunc displays the certificates presented by the hub and by
+// the agents, as selected by showHub and showAgents.
+func ShowCertificatesFunc(conf *hub.Config, showHub bool, showAgents bool) error {
+	var hubStatuses []*idl.ServiceStatus
+	if showHub {
+		certificate, err := GetHubCertificate(conf)
+		if err != nil {
+			return fmt.Errorf("could not get hub certificate: %w", err)
+		}
+		status := &idl.ServiceStatus{Certificate: certificate}
+		status.Host, _ = os.Hostname()
+		hubStatuses = append(hubStatuses, status)
+	}
+
+	var agentStatuses []*idl.ServiceStatus
+	if showAgents {
+		client, err := ConnectToHub(conf)
+		if err != nil {
+			return err
+		}
+
+		reply, err := client.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			return err
+		}
+		agentStatuses = reply.Statuses
+	}
+
+	PrintCertificates(os.Stdout, hubStatuses, agentStatuses)
+
 	return nil
 }
+
+// PrintCertificates prints a table of the certificates presented by the hub
+// and agents with the given statuses. Services which did not report their
+// certificate are shown with "-".
+func PrintCertificates(outfile io.Writer, hubStatuses []*idl.ServiceStatus, agentStatuses []*idl.ServiceStatus) {
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "ROLE\tHOST\tSUBJECT\tISSUER\tNAMES\tEXPIRES")
+	for _, services := range []struct {
+		role     string
+		statuses []*idl.ServiceStatus
+	}{{"Hub", hubStatuses}, {"Agent", agentStatuses}} {
+		for _, status := range services.statuses {
+			certificate := status.Certificate
+			if certificate == nil {
+				fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\n", services.role, status.Host)
+				continue
+			}
+
+			names := "-"
+			if len(certificate.Sans) > 0 {
+				names = strings.Join(certificate.Sans, ",")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", services.role, status.Host, certificate.Subject, certificate.Issuer,
+				names, time.Unix(certificate.NotAfter, 0).UTC().Format(time.RFC3339))
+		}
+	}
+	w.Flush()
+}
+
+func GetHubCertificateFunc(conf *hub.Config) (*idl.CertificateInfo, error) {
+	client, err := ConnectToHub(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.Status(context.Background(), &idl.StatusHubRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply.Certificate, nil
+}
+
+// CertificateWarning returns a warning if the certificate has expired, or
+// expires within the given window. Missing certificates are not warned about,
+// as not every process reports its certificate.
+func CertificateWarning(certificate *idl.CertificateInfo, window time.Duration, now time.Time) string {
+	if certificate == nil {
+		return ""
+	}
+
+	notAfter := time.Unix(certificate.NotAfter, 0)
+	switch {
+	case now.After(notAfter):
+		return fmt.Sprintf("certificate expired on %s", notAfter.Format("2006-01-02"))
+	case now.Add(window).After(notAfter):
+		return fmt.Sprintf("certificate expires on %s", notAfter.Format("2006-01-02"))
+	}
+
+	return ""
+}
+
+func setCertificateWarnings(statuses []*idl.ServiceStatus) {
+	now := time.Now()
+	for _, status := range statuses {
+		status.Warning = CertificateWarning(status.Certificate, certificateExpiryWarning, now)
+		if status.Certificate != nil {
+			gplog.Debug("Host %s presents certificate %q issued by %q for %s, valid until %s",
+				status.Host, status.Certificate.Subject, status.Certificate.Issuer,
+				strings.Join(status.Certificate.Sans, ", "), time.Unix(status.Certificate.NotAfter, 0).Format(time.RFC1123))
+		}
+	}
+}
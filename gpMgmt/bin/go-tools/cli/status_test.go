@@ -1,9 +1,12 @@
 package cli_test
 
 import (
+	"bytes"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/greenplum-db/gpdb/gp/cli"
@@ -12,6 +15,7 @@ import (
 	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
 	"github.com/greenplum-db/gpdb/gp/testutils"
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
 )
 
 func TestPrintServicesStatus(t *testing.T) {
@@ -146,6 +150,74 @@ func TestShowHubStatus(t *testing.T) {
 			t.Fatalf("unexpected error: %#v", err)
 		}
 	})
+	t.Run("reports a running hub as unhealthy when it fails its health check", func(t *testing.T) {
+		defer resetCLIVars()
+		mockPlatform := &testutils.MockPlatform{Err: nil}
+		mockPlatform.RetStatus = &idl.ServiceStatus{Status: "running", Uptime: "10ms", Pid: uint32(1234)}
+		cli.Platform = mockPlatform
+		defer func() { cli.Platform = utils.GetPlatform() }()
+
+		cli.CheckHubHealth = func(conf *hub.Config) error {
+			return errors.New("hub reports NOT_SERVING")
+		}
+
+		running, err := cli.ShowHubStatus(cli.Conf, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if running {
+			t.Fatalf("expected an unhealthy hub not to be reported as running")
+		}
+	})
+	t.Run("reports a running hub which passes its health check as running", func(t *testing.T) {
+		defer resetCLIVars()
+		mockPlatform := &testutils.MockPlatform{Err: nil}
+		mockPlatform.RetStatus = &idl.ServiceStatus{Status: "running", Uptime: "10ms", Pid: uint32(1234)}
+		cli.Platform = mockPlatform
+		defer func() { cli.Platform = utils.GetPlatform() }()
+
+		cli.CheckHubHealth = func(conf *hub.Config) error {
+			return nil
+		}
+		cli.GetHubCertificate = func(conf *hub.Config) (*idl.CertificateInfo, error) {
+			return &idl.CertificateInfo{NotAfter: time.Now().Add(365 * 24 * time.Hour).Unix()}, nil
+		}
+
+		running, err := cli.ShowHubStatus(cli.Conf, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !running {
+			t.Fatalf("expected a healthy hub to be reported as running")
+		}
+	})
+	t.Run("warns about a hub certificate which is about to expire", func(t *testing.T) {
+		defer resetCLIVars()
+		mockPlatform := &testutils.MockPlatform{Err: nil}
+		mockPlatform.RetStatus = &idl.ServiceStatus{Status: "running", Uptime: "10ms", Pid: uint32(1234)}
+		cli.Platform = mockPlatform
+		defer func() { cli.Platform = utils.GetPlatform() }()
+
+		cli.CheckHubHealth = func(conf *hub.Config) error {
+			return nil
+		}
+		notAfter := time.Now().Add(24 * time.Hour)
+		cli.GetHubCertificate = func(conf *hub.Config) (*idl.CertificateInfo, error) {
+			return &idl.CertificateInfo{NotAfter: notAfter.Unix()}, nil
+		}
+
+		_, err := cli.ShowHubStatus(cli.Conf, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "certificate expires on " + notAfter.Format("2006-01-02")
+		if len(mockPlatform.DisplayedStatuses) != 1 || mockPlatform.DisplayedStatuses[0].Warning != expected {
+			t.Fatalf("got %+v, want a warning %q", mockPlatform.DisplayedStatuses, expected)
+		}
+	})
 	t.Run("returns error when error getting service status", func(t *testing.T) {
 		expectedStr := "TEST Error getting service status"
 		mockPlatform := &testutils.MockPlatform{Err: errors.New(expectedStr), ServiceStatusMessage: ""}
@@ -187,6 +259,190 @@ func TestRunStatusAgent(t *testing.T) {
 			t.Fatalf("got %v, want %v", err, expectedStr)
 		}
 	})
+
+	t.Run("shows the state of the hosts with --detail", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ShowAgentsStatus = func(conf *hub.Config, skipHeader bool) error {
+			return nil
+		}
+		var dataDirs []string
+		cli.ShowAgentsDetail = func(conf *hub.Config, d []string) error {
+			dataDirs = d
+			return nil
+		}
+
+		cmd, _, err := cli.RootCommand().Find([]string{"status", "agents"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.ParseFlags([]string{"--detail", "--data-dir", "/data/*/gpseg*"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer cli.RootCommand() // resets the flags
+
+		err = cli.RunStatusAgent(cmd, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"/data/*/gpseg*"}
+		if !reflect.DeepEqual(dataDirs, expected) {
+			t.Fatalf("got %v, want %v", dataDirs, expected)
+		}
+	})
+}
+
+func TestRunStatusCertificates(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	cases := []struct {
+		command    string
+		run        func(cmd *cobra.Command, args []string) error
+		showHub    bool
+		showAgents bool
+	}{
+		{"hub", cli.RunStatusHub, true, false},
+		{"agents", cli.RunStatusAgent, false, true},
+		{"services", cli.RunServiceStatus, true, true},
+	}
+	for _, tc := range cases {
+		t.Run("shows the certificates with gp status "+tc.command+" --certificates", func(t *testing.T) {
+			defer resetCLIVars()
+			cli.ShowHubStatus = func(conf *hub.Config, skipHeader bool) (bool, error) {
+				return true, nil
+			}
+			cli.ShowAgentsStatus = func(conf *hub.Config, skipHeader bool) error {
+				return nil
+			}
+			var shown []bool
+			cli.ShowCertificates = func(conf *hub.Config, showHub bool, showAgents bool) error {
+				shown = []bool{showHub, showAgents}
+				return nil
+			}
+
+			cmd, _, err := cli.RootCommand().Find([]string{"status", tc.command})
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			err = cmd.ParseFlags([]string{"--certificates"})
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			defer cli.RootCommand() // resets the flags
+
+			err = tc.run(cmd, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			expected := []bool{tc.showHub, tc.showAgents}
+			if !reflect.DeepEqual(shown, expected) {
+				t.Fatalf("got %v, want %v", shown, expected)
+			}
+		})
+	}
+}
+
+func TestPrintCertificates(t *testing.T) {
+	t.Run("prints the identity and expiry of each certificate", func(t *testing.T) {
+		notAfter := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC).Unix()
+
+		var buf bytes.Buffer
+		cli.PrintCertificates(&buf,
+			[]*idl.ServiceStatus{{Host: "cdw", Certificate: &idl.CertificateInfo{Subject: "CN=gp-hub", Issuer: "CN=Greenplum cluster CA", Sans: []string{"localhost", "cdw"}, NotAfter: notAfter}}},
+			[]*idl.ServiceStatus{
+				{Host: "sdw1", Certificate: &idl.CertificateInfo{Subject: "CN=sdw1", Issuer: "CN=Greenplum cluster CA", Sans: []string{"sdw1"}, NotAfter: notAfter}},
+				{Host: "sdw2"},
+			})
+
+		expected := "ROLE\tHOST\tSUBJECT\t\tISSUER\t\t\t\tNAMES\t\tEXPIRES\n" +
+			"Hub\tcdw\tCN=gp-hub\tCN=Greenplum cluster CA\t\tlocalhost,cdw\t2027-03-01T12:00:00Z\n" +
+			"Agent\tsdw1\tCN=sdw1\t\tCN=Greenplum cluster CA\t\tsdw1\t\t2027-03-01T12:00:00Z\n" +
+			"Agent\tsdw2\t-\t\t-\t\t\t\t-\t\t-\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+}
+
+func TestShowAgentsDetail(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("requests the state of all hosts and returns their failures", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().HostInfo(gomock.Any(), &idl.HostInfoRequest{DataDirs: []string{"/data"}}).Return(&idl.HostInfoReply{
+				Hosts: []*idl.HostInfo{{Host: "sdw1", Cpus: 4}},
+				Results: []*idl.HostResult{
+					{Host: "sdw1", Success: true},
+					{Host: "sdw2", Code: 14, Message: "agent on host sdw2 is unreachable"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.ShowAgentsDetail(&hub.Config{}, []string{"/data"})
+		expected := "could not get host info: failed on 1 of 2 hosts"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestPrintHostInfo(t *testing.T) {
+	t.Run("prints the state of the hosts and their data directories", func(t *testing.T) {
+		var buf bytes.Buffer
+		cli.PrintHostInfo(&buf, []*idl.HostInfo{
+			{
+				Host:            "sdw1",
+				Load1:           0.5,
+				Load5:           0.25,
+				Load15:          0.125,
+				Cpus:            8,
+				MemoryTotal:     32 << 30,
+				MemoryAvailable: 12 << 30,
+				SwapTotal:       2 << 30,
+				SwapFree:        2 << 30,
+				KernelVersion:   "5.15.0",
+				GpVersion:       "7.1.0",
+				Disks:           []*idl.DiskUsage{{Path: "/data/primary/gpseg0", Total: 100 << 30, Free: 25 << 30}},
+			},
+			{Host: "sdw2", Cpus: 4, KernelVersion: "22.6.0", GpVersion: "7.1.0"},
+		})
+
+		expected := "HOST\tLOAD\t\tCPUS\tMEMORY AVAILABLE\tSWAP FREE\t\tKERNEL\tGP VERSION\n" +
+			"sdw1\t0.50 0.25 0.12\t8\t12.0 GiB of 32.0 GiB\t2.0 GiB of 2.0 GiB\t5.15.0\t7.1.0\n" +
+			"sdw2\t-\t\t4\t-\t\t\t-\t\t\t22.6.0\t7.1.0\n" +
+			"\n" +
+			"HOST\tDATA DIRECTORY\t\tFREE\t\tSIZE\t\tUSED\n" +
+			"sdw1\t/data/primary/gpseg0\t25.0 GiB\t100.0 GiB\t75%\n"
+		if buf.String() != expected {
+			t.Fatalf("got %q, want %q", buf.String(), expected)
+		}
+	})
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := []struct {
+		size     uint64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 << 30, "5.0 GiB"},
+	}
+
+	for _, c := range cases {
+		result := cli.FormatBytes(c.size)
+		if result != c.expected {
+			t.Fatalf("got %q, want %q", result, c.expected)
+		}
+	}
 }
 
 func TestRunStatusHub(t *testing.T) {
@@ -217,3 +473,28 @@ func TestRunStatusHub(t *testing.T) {
 		}
 	})
 }
+
+func TestCertificateWarning(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	window := 30 * 24 * time.Hour
+
+	cases := []struct {
+		name        string
+		certificate *idl.CertificateInfo
+		expected    string
+	}{
+		{"no certificate", nil, ""},
+		{"valid beyond the window", &idl.CertificateInfo{NotAfter: now.Add(60 * 24 * time.Hour).Unix()}, ""},
+		{"expiring within the window", &idl.CertificateInfo{NotAfter: now.Add(10 * 24 * time.Hour).Unix()}, "certificate expires on " + now.Add(10*24*time.Hour).Local().Format("2006-01-02")},
+		{"expired", &idl.CertificateInfo{NotAfter: now.Add(-time.Hour).Unix()}, "certificate expired on " + now.Add(-time.Hour).Local().Format("2006-01-02")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := cli.CertificateWarning(tc.certificate, window, now)
+			if result != tc.expected {
+				t.Fatalf("got %q, want %q", result, tc.expected)
+			}
+		})
+	}
+}
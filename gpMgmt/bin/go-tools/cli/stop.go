@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gp/idl"
@@ -100,11 +101,12 @@ func StopAgentServiceFunc() error {
 		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
 	}
 
-	_, err = client.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+	reply, err := client.StopAgents(context.Background(), &idl.StopAgentsRequest{})
 	if err != nil {
 		return fmt.Errorf("could not stop agents: %w", err)
 	}
-	return nil
+
+	return CheckHostResults(os.Stdout, "stop agents", reply.GetResults())
 }
 
 func StopServicesCmd() *cobra.Command {
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	UpgradeTools     = UpgradeToolsFunc
+	VerifyHubVersion = VerifyHubVersionFunc
+
+	upgradeBinary    string
+	upgradeBatchSize int
+)
+
+func upgradeToolsCmd() *cobra.Command {
+	upgradeToolsCmd := &cobra.Command{
+		Use:   "upgrade-tools --binary <path> [--batch-size <n>]",
+		Short: "Install a new gp binary on all hosts",
+		Long: `Install the given gp binary in $GPHOME/bin on every host and restart the hub
+and agents on it. The binary is copied to all hosts and its checksum verified
+before any agent is restarted. The agents are then restarted a batch at a time,
+and each batch must come back running the new version before the next one is
+restarted; otherwise every agent restarted so far is rolled back to the
+previous binary, which is kept as $GPHOME/bin/gp.previous. The hub is restarted
+last.`,
+		Args:    cobra.NoArgs,
+		PreRunE: InitializeCommand,
+		RunE:    RunUpgradeTools,
+	}
+
+	upgradeToolsCmd.Flags().StringVar(&upgradeBinary, "binary", "", `Path of the new gp binary on this host`)
+	upgradeToolsCmd.Flags().IntVar(&upgradeBatchSize, "batch-size", constants.DefaultUpgradeBatchSize, `Number of agents to restart at a time`)
+
+	return upgradeToolsCmd
+}
+
+func RunUpgradeTools(cmd *cobra.Command, args []string) error {
+	if upgradeBinary == "" {
+		return fmt.Errorf("the path of the new gp binary must be given with --binary")
+	}
+	if upgradeBatchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d: must be positive", upgradeBatchSize)
+	}
+
+	binary, err := filepath.Abs(upgradeBinary)
+	if err != nil {
+		return fmt.Errorf("could not resolve binary path %s: %w", upgradeBinary, err)
+	}
+
+	target, err := utils.BinaryVersion(binary)
+	if err != nil {
+		return err
+	}
+
+	err = UpgradeTools(binary, upgradeBatchSize, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Restarting the hub")
+	err = StopHubService()
+	if err != nil {
+		return err
+	}
+	err = StartHubService(Conf.ServiceName)
+	if err != nil {
+		return err
+	}
+	err = WaitAndRetryHubConnect()
+	if err != nil {
+		return err
+	}
+
+	err = VerifyHubVersion(target)
+	if err != nil {
+		return err
+	}
+	gplog.Info("Upgraded gp to version %s (commit %s) on all hosts", target.Version, target.Commit)
+
+	return nil
+}
+
+// UpgradeToolsFunc has the hub install binary on every host, logging its
+// progress and printing the hosts on which it failed to outfile.
+func UpgradeToolsFunc(binary string, batchSize int, outfile io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	stream, err := client.UpgradeTools(context.Background(), &idl.UpgradeToolsRequest{
+		Binary:    binary,
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return fmt.Errorf("could not upgrade gp: %w", err)
+	}
+
+	var results []*idl.HostResult
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Show where the upgrade failed before why it was abandoned
+			CheckHostResults(outfile, "upgrade gp", results)
+			return fmt.Errorf("could not upgrade gp: %w", err)
+		}
+
+		switch update := reply.Update.(type) {
+		case *idl.UpgradeToolsReply_Progress:
+			gplog.Info("%s", update.Progress)
+		case *idl.UpgradeToolsReply_Result:
+			results = append(results, update.Result)
+		}
+	}
+
+	return CheckHostResults(outfile, "upgrade gp", results)
+}
+
+// VerifyHubVersionFunc returns an error unless the hub runs the target build
+// of gp.
+func VerifyHubVersionFunc(target *idl.VersionInfo) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	reply, err := client.Version(context.Background(), &idl.VersionRequest{Client: utils.GetVersionInfo()})
+	if err != nil {
+		return fmt.Errorf("could not get the version of the hub: %w", err)
+	}
+
+	if reply.Hub == nil || !utils.SameBuild(reply.Hub, target) {
+		return fmt.Errorf("hub did not come back running gp %s (commit %s); check %s/bin/gp on the hub host", target.Version, target.Commit, Conf.GpHome)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpgradeTools(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	mockUpgradeStream := func(replies []*idl.UpgradeToolsReply, streamErr error) {
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			stream := mock_idl.NewMockHub_UpgradeToolsClient(ctrl)
+			for _, reply := range replies {
+				stream.EXPECT().Recv().Return(reply, nil)
+			}
+			stream.EXPECT().Recv().Return(nil, streamErr)
+
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().UpgradeTools(gomock.Any(), &idl.UpgradeToolsRequest{Binary: "/usr/local/gp", BatchSize: 2}).Return(stream, nil)
+			return hubClient, nil
+		}
+	}
+	result := func(result *idl.HostResult) *idl.UpgradeToolsReply {
+		return &idl.UpgradeToolsReply{Update: &idl.UpgradeToolsReply_Result{Result: result}}
+	}
+	progress := &idl.UpgradeToolsReply{Update: &idl.UpgradeToolsReply_Progress{Progress: "Restarting the agents on hosts sdw1, sdw2"}}
+
+	t.Run("succeeds when every host was upgraded", func(t *testing.T) {
+		defer resetCLIVars()
+		mockUpgradeStream([]*idl.UpgradeToolsReply{
+			progress,
+			result(&idl.HostResult{Host: "sdw1", Success: true}),
+			result(&idl.HostResult{Host: "sdw2", Success: true}),
+		}, io.EOF)
+
+		var buf bytes.Buffer
+		err := cli.UpgradeTools("/usr/local/gp", 2, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if buf.Len() != 0 {
+			t.Fatalf("got %q, want no output", buf.String())
+		}
+	})
+
+	t.Run("prints the failed hosts when the upgrade was rolled back", func(t *testing.T) {
+		defer resetCLIVars()
+		mockUpgradeStream([]*idl.UpgradeToolsReply{
+			progress,
+			result(&idl.HostResult{Host: "sdw1", Code: int32(codes.Aborted), Message: "rolled back to the previous gp binary"}),
+			result(&idl.HostResult{Host: "sdw2", Code: int32(codes.Unavailable), Message: "agent on host sdw2 is unreachable"}),
+		}, status.Error(codes.Aborted, "agents on hosts sdw2 did not come back running gp 2.0.0; rolled back the upgrade"))
+
+		var buf bytes.Buffer
+		err := cli.UpgradeTools("/usr/local/gp", 2, &buf)
+		if err == nil || !strings.Contains(err.Error(), "rolled back the upgrade") {
+			t.Fatalf("got %v, want the upgrade to be rolled back", err)
+		}
+		if !strings.Contains(buf.String(), "sdw2\tfailed\tUnavailable\tagent on host sdw2 is unreachable") {
+			t.Fatalf("got %q, want the failed hosts to be printed", buf.String())
+		}
+	})
+}
+
+func TestRunUpgradeTools(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	binary := filepath.Join(t.TempDir(), "gp")
+	err := os.WriteFile(binary, []byte("#!/bin/sh\necho 'gp version 2.0.0 (commit abc123, protocol version 1)'\n"), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	parseFlags := func(t *testing.T, args ...string) {
+		t.Helper()
+
+		cmd, _, err := cli.RootCommand().Find([]string{"upgrade-tools"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = cmd.ParseFlags(args)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+
+	var calls []string
+	record := func(call string) func() error {
+		return func() error {
+			calls = append(calls, call)
+			return nil
+		}
+	}
+	mockRestart := func() {
+		calls = nil
+		cli.StopHubService = record("stop hub")
+		cli.StartHubService = func(serviceName string) error {
+			return record("start hub")()
+		}
+		cli.WaitAndRetryHubConnect = record("connect to hub")
+		cli.VerifyHubVersion = func(target *idl.VersionInfo) error {
+			if target.Version != "2.0.0" || target.Commit != "abc123" {
+				t.Fatalf("got %+v, want version 2.0.0 (commit abc123)", target)
+			}
+			return record("verify hub version")()
+		}
+	}
+
+	t.Run("restarts the hub once the agents were upgraded", func(t *testing.T) {
+		defer resetCLIVars()
+		parseFlags(t, "--binary", binary, "--batch-size", "3")
+		mockRestart()
+		cli.UpgradeTools = func(path string, batchSize int, outfile io.Writer) error {
+			if path != binary || batchSize != 3 {
+				t.Fatalf("got %s and %d, want %s and 3", path, batchSize, binary)
+			}
+			return record("upgrade agents")()
+		}
+
+		err := cli.RunUpgradeTools(nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"upgrade agents", "stop hub", "start hub", "connect to hub", "verify hub version"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Fatalf("got %v, want %v", calls, expected)
+		}
+	})
+
+	t.Run("leaves the hub running when the agents could not be upgraded", func(t *testing.T) {
+		defer resetCLIVars()
+		parseFlags(t, "--binary", binary)
+		mockRestart()
+		expected := errors.New("could not upgrade gp")
+		cli.UpgradeTools = func(path string, batchSize int, outfile io.Writer) error {
+			return expected
+		}
+
+		err := cli.RunUpgradeTools(nil, nil)
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+		if len(calls) != 0 {
+			t.Fatalf("got %v, want the hub not to be restarted", calls)
+		}
+	})
+
+	t.Run("rejects a binary which is not gp before contacting the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		parseFlags(t, "--binary", "/bin/true")
+		cli.UpgradeTools = func(path string, batchSize int, outfile io.Writer) error {
+			t.Fatalf("unexpected upgrade of %s", path)
+			return nil
+		}
+
+		err := cli.RunUpgradeTools(nil, nil)
+		if err == nil || !strings.Contains(err.Error(), "/bin/true is not a gp binary") {
+			t.Fatalf("got %v, want an error about the binary", err)
+		}
+	})
+}
+
+func TestVerifyHubVersion(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns an error when the hub does not run the new version", func(t *testing.T) {
+		defer resetCLIVars()
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(&idl.VersionReply{
+				Hub: &idl.VersionInfo{Host: "cdw", Version: "1.0.0", Commit: "def456", ProtocolVersion: 1},
+			}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.VerifyHubVersion(&idl.VersionInfo{Version: "2.0.0", Commit: "abc123", ProtocolVersion: 1})
+		if err == nil || !strings.HasPrefix(err.Error(), "hub did not come back running gp 2.0.0 (commit abc123)") {
+			t.Fatalf("got %v, want the hub to be reported", err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ShowClusterVersion = ShowClusterVersionFunc
+
+	versionCluster bool
+)
+
+func versionCmd() *cobra.Command {
+	versionCmd := &cobra.Command{
+		Use:   "version [--cluster]",
+		Short: "Display the version of gp",
+		Long: `Display the version, commit and protocol version of this gp binary. With
+--cluster, display those of the hub and of the agent on every host too, and
+report the hosts running a different version, such as those left behind by a
+partial upgrade of GPHOME. Hosts speaking a different protocol version cannot
+be managed until they are upgraded.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Printing the version of the binary needs no configuration
+			if !versionCluster {
+				return nil
+			}
+			return InitializeCommand(cmd, args)
+		},
+		RunE: RunVersion,
+	}
+
+	versionCmd.Flags().BoolVar(&versionCluster, "cluster", false, `Display the version running on every host`)
+
+	return versionCmd
+}
+
+func RunVersion(cmd *cobra.Command, args []string) error {
+	if !versionCluster {
+		fmt.Println(utils.VersionString(utils.GetVersionInfo()))
+		return nil
+	}
+
+	return ShowClusterVersion(os.Stdout)
+}
+
+// ShowClusterVersionFunc prints the version of gp running in the CLI, the hub
+// and every agent. It returns an error if any of them speaks a different
+// protocol version than the CLI, and warns if any runs a different build.
+func ShowClusterVersionFunc(outfile io.Writer) error {
+	client, err := ConnectToHub(Conf)
+	if err != nil {
+		return fmt.Errorf("could not connect to hub; is the hub running? Error: %v", err)
+	}
+
+	local := utils.GetVersionInfo()
+	reply, err := client.Version(context.Background(), &idl.VersionRequest{Client: local})
+	if err != nil {
+		return fmt.Errorf("could not get the version of the hub: %w", err)
+	}
+
+	type process struct {
+		role string
+		info *idl.VersionInfo
+	}
+	processes := []process{{"cli", local}, {"hub", reply.Hub}}
+	for _, agent := range reply.Agents {
+		processes = append(processes, process{"agent", agent})
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "HOST\tROLE\tVERSION\tCOMMIT\tPROTOCOL")
+	var incompatible, differing []string
+	for _, p := range processes {
+		if p.info == nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", p.info.Host, p.role, p.info.Version, p.info.Commit, p.info.ProtocolVersion)
+
+		name := fmt.Sprintf("%s on host %s", p.role, p.info.Host)
+		if utils.CheckVersionCompatibility(p.info) != nil {
+			incompatible = append(incompatible, name)
+		} else if utils.VersionDiffers(p.info) {
+			differing = append(differing, name)
+		}
+	}
+	w.Flush()
+
+	if len(differing) > 0 {
+		gplog.Warn("Some processes run a different version of gp than this CLI: %s", strings.Join(differing, ", "))
+	}
+
+	err = CheckHostResults(outfile, "get the version of the agents", reply.GetResults())
+	if err != nil {
+		return err
+	}
+
+	if len(incompatible) > 0 {
+		return fmt.Errorf("some processes speak a different protocol version than this CLI: %s; upgrade gp on their hosts", strings.Join(incompatible, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gp/cli"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestShowClusterVersion(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	mockHubVersion := func(reply *idl.VersionReply) {
+		cli.ConnectToHub = func(conf *hub.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().Version(gomock.Any(), &idl.VersionRequest{Client: utils.GetVersionInfo()}).Return(reply, nil)
+			return hubClient, nil
+		}
+	}
+	current := func(host string) *idl.VersionInfo {
+		return &idl.VersionInfo{Host: host, Version: utils.Version, Commit: utils.Commit, ProtocolVersion: utils.ProtocolVersion}
+	}
+
+	t.Run("prints the version running on every host", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubVersion(&idl.VersionReply{
+			Hub:     current("cdw"),
+			Agents:  []*idl.VersionInfo{current("sdw1"), {Host: "sdw2", Version: "0.1.0", Commit: "abc", ProtocolVersion: utils.ProtocolVersion}},
+			Results: []*idl.HostResult{{Host: "sdw1", Success: true}, {Host: "sdw2", Success: true}},
+		})
+
+		var buf bytes.Buffer
+		err := cli.ShowClusterVersion(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "sdw2\tagent\t0.1.0\t\tabc\t\t1\n"
+		if !strings.Contains(buf.String(), expected) || !strings.Contains(buf.String(), "cdw\thub\t") {
+			t.Fatalf("got %q, want it to contain the hub and %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("returns an error when a host speaks another protocol version", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubVersion(&idl.VersionReply{
+			Hub:     current("cdw"),
+			Agents:  []*idl.VersionInfo{{Host: "sdw1", Version: "9.0.0", ProtocolVersion: utils.ProtocolVersion + 1}},
+			Results: []*idl.HostResult{{Host: "sdw1", Success: true}},
+		})
+
+		var buf bytes.Buffer
+		err := cli.ShowClusterVersion(&buf)
+		expected := "some processes speak a different protocol version than this CLI: agent on host sdw1; upgrade gp on their hosts"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("returns an error when the version of some agents is unknown", func(t *testing.T) {
+		defer resetCLIVars()
+		mockHubVersion(&idl.VersionReply{
+			Hub:     current("cdw"),
+			Agents:  []*idl.VersionInfo{},
+			Results: []*idl.HostResult{{Host: "sdw1", Code: 9, Message: "agent on host sdw1 predates version reporting; upgrade gp on host sdw1"}},
+		})
+
+		var buf bytes.Buffer
+		err := cli.ShowClusterVersion(&buf)
+		expected := "could not get the version of the agents: failed on all 1 hosts"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -1,14 +1,60 @@
 package constants
 
+import "time"
+
 const (
 	DefaultHubLogDir   = "/tmp"
 	DefaultHubPort     = 4242
 	DefaultAgentPort   = 8000
 	DefaultServiceName = "gp"
+	DefaultSSHPort     = 22
+	DefaultSSHTimeout  = 10 * time.Second
+	MinKeepaliveTime   = 10 * time.Second // agents reject keepalive pings from the hub sent more often than this
+	HealthCheckTimeout = 3 * time.Second
 	ConfigFileName     = "gp.conf"
 	ShellPath          = "/bin/bash"
-	GpSSH              = "gpssh"
 	MaxRetries         = 10
 	PlatformDarwin     = "darwin"
 	PlatformLinux      = "linux"
+	FileChunkSize      = 64 * 1024 // size of the chunks in which files are streamed between hub and agents
+
+	// Certificates created by gp certs are kept in this directory under GPHOME
+	CertificatesDir     = "certificates"
+	CAValidity          = 10 * 365 * 24 * time.Hour
+	CertificateValidity = 365 * 24 * time.Hour
+	// gp status warns about certificates expiring within this window by default
+	CertificateExpiryWarning = 30 * 24 * time.Hour
+
+	// Subsystems reported by the health service of the hub and agents, next to
+	// the overall health which is reported for the empty service name
+	HealthServing           = "serving"
+	HealthCredentials       = "credentials"
+	HealthAgentConnectivity = "agent-connectivity"
+
+	// Formats of the log files written by gp, and their default rotation
+	LogFormatText        = "text"
+	LogFormatJSON        = "json"
+	DefaultLogMaxSizeMB  = 100
+	DefaultLogMaxAgeDays = 7
+	DefaultLogMaxBackups = 5
+
+	// The agents run as this command, which names their log file
+	AgentCommandPath = "gp agent"
+
+	// Every RPC served by the hub and agents is recorded in these files under the log directory
+	HubAuditFileName   = "gp_hub_audit.log"
+	AgentAuditFileName = "gp_agent_audit.log"
+
+	// gRPC metadata key carrying the ID of the CLI invocation a request belongs to
+	RequestIDMetadataKey = "gp-request-id"
+
+	// Exit codes used when an operation fanned out to the agent hosts fails
+	ExitCodePartialFailure = 2
+	ExitCodeTotalFailure   = 3
+
+	// gp upgrade-tools restarts this many agents at a time by default
+	DefaultUpgradeBatchSize = 5
+
+	// This many previous versions of gp.conf are kept in its history
+	ConfigHistorySize = 10
 )
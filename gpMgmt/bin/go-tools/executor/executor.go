@@ -0,0 +1,101 @@
+// Package executor runs commands on a set of remote hosts in parallel and
+// reports the outcome on each host individually.
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Executor runs a shell command on every given host. Implementations must run
+// the command on all hosts concurrently and must return exactly one Result per
+// host, in the same order as the hosts were passed in.
+type Executor interface {
+	Run(ctx context.Context, hosts []string, command string) []Result
+	RunWithInput(ctx context.Context, hosts []string, command string, input []byte) []Result
+}
+
+// Result is the outcome of running a command on a single host.
+type Result struct {
+	Host     string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+
+	// Err is set when the command could not be run at all, for example
+	// because the host could not be reached or authentication failed. In
+	// that case ExitCode, Stdout and Stderr are meaningless.
+	Err error
+}
+
+// Failure returns an error describing why the command failed on this host, or
+// nil if it ran and exited successfully.
+func (r Result) Failure() error {
+	if r.Err != nil {
+		return fmt.Errorf("host %s: %w", r.Host, r.Err)
+	}
+
+	if r.ExitCode != 0 {
+		stderr := strings.TrimSpace(r.Stderr)
+		if stderr == "" {
+			return fmt.Errorf("host %s: exit status %d", r.Host, r.ExitCode)
+		}
+
+		return fmt.Errorf("host %s: exit status %d: %s", r.Host, r.ExitCode, stderr)
+	}
+
+	return nil
+}
+
+// Errors combines the failures of all hosts into a single error, or returns
+// nil if the command succeeded everywhere.
+func Errors(results []Result) error {
+	var failures []string
+	for _, result := range results {
+		if err := result.Failure(); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s", strings.Join(failures, "; "))
+}
+
+// WriteFile writes contents to dest on every host. The file is first written
+// to a temporary file next to dest and then renamed, so readers never observe
+// a partially written file.
+func WriteFile(ctx context.Context, e Executor, hosts []string, contents []byte, mode os.FileMode, dest string) []Result {
+	tmp := dest + ".tmp"
+	command := fmt.Sprintf("mkdir -p %s && cat > %s && chmod %o %s && mv -f %s %s",
+		Quote(filepath.Dir(dest)), Quote(tmp), mode.Perm(), Quote(tmp), Quote(tmp), Quote(dest))
+
+	return e.RunWithInput(ctx, hosts, command, contents)
+}
+
+// CopyFile copies the local file src to dest on every host, preserving its
+// permission bits.
+func CopyFile(ctx context.Context, e Executor, hosts []string, src string, dest string) ([]Result, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return WriteFile(ctx, e, hosts, contents, info.Mode(), dest), nil
+}
+
+// Quote returns s quoted for safe use as a single word in a POSIX shell
+// command line.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,240 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Dialer opens the network connection to a host over which the SSH session is
+// established.
+type Dialer func(ctx context.Context, network string, address string) (net.Conn, error)
+
+// SSHExecutor runs commands on remote hosts over SSH. Like gpssh, it expects
+// passwordless SSH to already be set up between the coordinator and all
+// segment hosts.
+type SSHExecutor struct {
+	User    string
+	Port    int
+	Timeout time.Duration
+
+	// AuthMethods defaults to the keys held by the running ssh-agent and the
+	// user's default private keys in ~/.ssh.
+	AuthMethods []ssh.AuthMethod
+	// HostKeyCallback defaults to verifying hosts against ~/.ssh/known_hosts.
+	HostKeyCallback ssh.HostKeyCallback
+	// Dialer defaults to a plain TCP dialer.
+	Dialer Dialer
+
+	once      sync.Once
+	config    *ssh.ClientConfig
+	configErr error
+}
+
+func NewSSHExecutor() *SSHExecutor {
+	username := os.Getenv("USER")
+	if current, err := user.Current(); err == nil {
+		username = current.Username
+	}
+
+	return &SSHExecutor{
+		User:    username,
+		Port:    constants.DefaultSSHPort,
+		Timeout: constants.DefaultSSHTimeout,
+	}
+}
+
+func (e *SSHExecutor) Run(ctx context.Context, hosts []string, command string) []Result {
+	return e.RunWithInput(ctx, hosts, command, nil)
+}
+
+func (e *SSHExecutor) RunWithInput(ctx context.Context, hosts []string, command string, input []byte) []Result {
+	results := make([]Result, len(hosts))
+
+	config, err := e.clientConfig()
+	if err != nil {
+		for i, host := range hosts {
+			results[i] = Result{Host: host, Err: err}
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		i, host := i, host
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.runOnHost(ctx, config, host, command, input)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (e *SSHExecutor) runOnHost(ctx context.Context, config *ssh.ClientConfig, host string, command string, input []byte) Result {
+	result := Result{Host: host}
+
+	client, err := e.connect(ctx, config, host)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer client.Close()
+
+	// Closing the client unblocks session.Run if the context is cancelled
+	// while the command is still running.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-done:
+		}
+	}()
+
+	session, err := client.NewSession()
+	if err != nil {
+		result.Err = fmt.Errorf("could not open session: %w", err)
+		return result
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if input != nil {
+		session.Stdin = bytes.NewReader(input)
+	}
+
+	err = session.Run(command)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case ctx.Err() != nil:
+		result.Err = ctx.Err()
+	default:
+		result.Err = fmt.Errorf("could not run command: %w", err)
+	}
+
+	return result
+}
+
+func (e *SSHExecutor) connect(ctx context.Context, config *ssh.ClientConfig, host string) (*ssh.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.Timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(host, strconv.Itoa(e.Port))
+	dial := e.Dialer
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	conn, err := dial(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", address, err)
+	}
+
+	// The SSH handshake itself is not context aware, so bound it with a
+	// deadline on the underlying connection instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not establish ssh connection to %s: %w", address, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(sshConn, channels, requests), nil
+}
+
+func (e *SSHExecutor) clientConfig() (*ssh.ClientConfig, error) {
+	e.once.Do(func() {
+		auth := e.AuthMethods
+		if auth == nil {
+			auth = defaultAuthMethods()
+		}
+
+		hostKeyCallback := e.HostKeyCallback
+		if hostKeyCallback == nil {
+			hostKeyCallback, e.configErr = defaultHostKeyCallback()
+			if e.configErr != nil {
+				return
+			}
+		}
+
+		e.config = &ssh.ClientConfig{
+			User:            e.User,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         e.Timeout,
+		}
+	})
+
+	return e.config, e.configErr
+}
+
+func defaultAuthMethods() []ssh.AuthMethod {
+	var signers []ssh.Signer
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			if agentSigners, err := agent.NewClient(conn).Signers(); err == nil {
+				signers = append(signers, agentSigners...)
+			}
+		}
+	}
+
+	home, _ := os.UserHomeDir()
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		key, err := os.ReadFile(filepath.Join(home, ".ssh", name))
+		if err != nil {
+			continue
+		}
+
+		// Passphrase protected keys can only be used through the ssh-agent
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	return []ssh.AuthMethod{ssh.PublicKeys(signers...)}
+}
+
+func defaultHostKeyCallback() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine home directory: %w", err)
+	}
+
+	callback, err := knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		return nil, fmt.Errorf("could not load known hosts: %w", err)
+	}
+
+	return callback, nil
+}
@@ -0,0 +1,262 @@
+package executor_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/executor"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHExecutor(t *testing.T) {
+	clientSigner := newSigner(t)
+	address, hostKey := startSSHServer(t, clientSigner.PublicKey())
+
+	newExecutor := func() *executor.SSHExecutor {
+		e := executor.NewSSHExecutor()
+		e.AuthMethods = []ssh.AuthMethod{ssh.PublicKeys(clientSigner)}
+		e.HostKeyCallback = ssh.FixedHostKey(hostKey)
+		e.Dialer = func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			if strings.HasPrefix(addr, "unreachable") {
+				return nil, errors.New("connection refused")
+			}
+
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}
+
+		return e
+	}
+
+	t.Run("runs the command on all hosts and returns the results in host order", func(t *testing.T) {
+		results := newExecutor().Run(context.Background(), []string{"sdw1", "sdw2", "sdw3"}, "echo hello")
+
+		expected := []executor.Result{
+			{Host: "sdw1", Stdout: "hello\n"},
+			{Host: "sdw2", Stdout: "hello\n"},
+			{Host: "sdw3", Stdout: "hello\n"},
+		}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("got %+v, want %+v", results, expected)
+		}
+
+		err := executor.Errors(results)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("reports the exit code and stderr of a failed command", func(t *testing.T) {
+		results := newExecutor().Run(context.Background(), []string{"sdw1"}, "echo oops >&2; exit 3")
+
+		expected := []executor.Result{{Host: "sdw1", ExitCode: 3, Stderr: "oops\n"}}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("got %+v, want %+v", results, expected)
+		}
+
+		err := executor.Errors(results)
+		expectedErr := "host sdw1: exit status 3: oops"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("reports unreachable hosts without affecting the other hosts", func(t *testing.T) {
+		results := newExecutor().Run(context.Background(), []string{"sdw1", "unreachable"}, "true")
+
+		if results[0].Failure() != nil {
+			t.Fatalf("unexpected error: %#v", results[0].Failure())
+		}
+
+		expectedErr := "host unreachable: could not connect to unreachable:22: connection refused"
+		if results[1].Failure() == nil || results[1].Failure().Error() != expectedErr {
+			t.Fatalf("got %v, want %v", results[1].Failure(), expectedErr)
+		}
+	})
+
+	t.Run("fails on every host when the host key does not match", func(t *testing.T) {
+		e := newExecutor()
+		e.HostKeyCallback = ssh.FixedHostKey(newSigner(t).PublicKey())
+
+		results := e.Run(context.Background(), []string{"sdw1", "sdw2"}, "true")
+		for _, result := range results {
+			if result.Err == nil {
+				t.Fatalf("expected an error on host %s", result.Host)
+			}
+		}
+	})
+
+	t.Run("passes input to the remote command", func(t *testing.T) {
+		results := newExecutor().RunWithInput(context.Background(), []string{"sdw1"}, "cat", []byte("some input"))
+
+		if results[0].Stdout != "some input" {
+			t.Fatalf("got %q, want %q", results[0].Stdout, "some input")
+		}
+	})
+
+	t.Run("copies a file to all hosts", func(t *testing.T) {
+		dir := t.TempDir()
+		src := filepath.Join(dir, "src")
+		err := os.WriteFile(src, []byte("contents"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		dest := filepath.Join(dir, "new dir", "dest")
+		results, err := executor.CopyFile(context.Background(), newExecutor(), []string{"sdw1"}, src, dest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = executor.Errors(results)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(contents) != "contents" {
+			t.Fatalf("got %q, want %q", contents, "contents")
+		}
+
+		info, err := os.Stat(dest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("got %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+		}
+	})
+}
+
+func TestQuote(t *testing.T) {
+	cases := map[string]string{
+		"plain":          "'plain'",
+		"with space":     "'with space'",
+		"it's":           `'it'\''s'`,
+		"$(rm -rf /tmp)": "'$(rm -rf /tmp)'",
+	}
+
+	for input, expected := range cases {
+		result := executor.Quote(input)
+		if result != expected {
+			t.Fatalf("got %q, want %q", result, expected)
+		}
+	}
+}
+
+func newSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return signer
+}
+
+// startSSHServer starts an in-process SSH server which only accepts the given
+// client key and runs every requested command locally through the shell.
+func startSSHServer(t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey) {
+	t.Helper()
+
+	hostSigner := newSigner(t)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, nil
+			}
+
+			return nil, errors.New("unknown public key")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveSSHConn(conn, config)
+		}
+	}()
+
+	return listener.Addr().String(), hostSigner.PublicKey()
+}
+
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			defer channel.Close()
+
+			for request := range requests {
+				if request.Type != "exec" {
+					_ = request.Reply(false, nil)
+					continue
+				}
+
+				length := binary.BigEndian.Uint32(request.Payload)
+				command := string(request.Payload[4 : 4+length])
+				_ = request.Reply(true, nil)
+
+				cmd := exec.Command(constants.ShellPath, "-c", command)
+				cmd.Stdin = channel
+				cmd.Stdout = channel
+				cmd.Stderr = channel.Stderr()
+
+				status := make([]byte, 4)
+				var exitErr *exec.ExitError
+				if err := cmd.Run(); errors.As(err, &exitErr) {
+					binary.BigEndian.PutUint32(status, uint32(exitErr.ExitCode()))
+				}
+				_, _ = channel.SendRequest("exit-status", false, status)
+
+				return
+			}
+		}()
+	}
+}
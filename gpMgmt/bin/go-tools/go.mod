@@ -7,6 +7,7 @@ require (
 	github.com/greenplum-db/gp-common-go-libs v1.0.11
 	github.com/spf13/cobra v1.6.1
 	github.com/spf13/viper v1.16.0
+	golang.org/x/crypto v0.17.0
 	google.golang.org/grpc v1.56.3
 	google.golang.org/protobuf v1.30.0
 )
@@ -38,7 +39,6 @@ require (
 	github.com/spf13/jwalterweatherman v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/subosito/gotenv v1.4.2 // indirect
-	golang.org/x/crypto v0.17.0 // indirect
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sys v0.15.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
@@ -0,0 +1,92 @@
+package hub
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// AgentCertificates reports the certificate each agent currently presents. A
+// new connection is made to every agent for this, as the existing connections
+// keep using the certificate they were established with, which lets callers
+// confirm that rotated certificates have been picked up.
+func (s *Server) AgentCertificates(ctx context.Context, in *idl.AgentCertificatesRequest) (*idl.AgentCertificatesReply, error) {
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.AgentCertificatesReply{}, err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return &idl.AgentCertificatesReply{}, err
+	}
+
+	creds, err := s.Credentials.LoadClientCredentials(utils.RoleHub)
+	if err != nil {
+		return &idl.AgentCertificatesReply{}, grpcStatus.Errorf(codes.Internal, "could not load credentials: %v", err)
+	}
+
+	var mutex sync.Mutex
+	certificates := make(map[string]*idl.CertificateInfo, len(conns))
+	request := func(conn *Connection) error {
+		info, err := s.agentCertificate(ctx, creds, conn.Hostname)
+		if err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		certificates[conn.Hostname] = info
+
+		return nil
+	}
+
+	reply := &idl.AgentCertificatesReply{Results: ExecuteRPC(ctx, conns, request)}
+	for _, conn := range conns {
+		if info, ok := certificates[conn.Hostname]; ok {
+			reply.Certificates = append(reply.Certificates, info)
+		}
+	}
+
+	return reply, nil
+}
+
+// agentCertificate performs a TLS handshake with the agent on host and
+// returns the certificate it presented.
+func (s *Server) agentCertificate(ctx context.Context, creds credentials.TransportCredentials, host string) (*idl.CertificateInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, DialTimeout)
+	defer cancel()
+
+	dial := s.grpcDialer
+	if dial == nil {
+		dial = func(ctx context.Context, address string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		}
+	}
+
+	conn, err := dial(ctx, net.JoinHostPort(host, strconv.Itoa(s.AgentPort)))
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unavailable, "could not connect to agent on host %s: %v", host, err)
+	}
+	defer conn.Close()
+
+	tlsConn, authInfo, err := creds.ClientHandshake(ctx, host, conn)
+	if err != nil {
+		return nil, grpcStatus.Errorf(codes.Unavailable, "could not complete TLS handshake with agent on host %s: %v", host, err)
+	}
+	defer tlsConn.Close()
+
+	tlsInfo, ok := authInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, grpcStatus.Errorf(codes.Internal, "agent on host %s did not present a certificate", host)
+	}
+
+	return utils.NewCertificateInfo(host, tlsInfo.State.PeerCertificates[0]), nil
+}
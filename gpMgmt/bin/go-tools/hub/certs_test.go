@@ -0,0 +1,148 @@
+package hub_test
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestAgentCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	credentials := &utils.GpCredentials{
+		CACertPath:    filepath.Join(dir, utils.CACertFileName),
+		CAKeyPath:     filepath.Join(dir, utils.CAKeyFileName),
+		HubCertPath:   filepath.Join(dir, utils.HubCertFileName),
+		HubKeyPath:    filepath.Join(dir, utils.HubKeyFileName),
+		AgentCertPath: filepath.Join(dir, utils.AgentCertFileName),
+		AgentKeyPath:  filepath.Join(dir, utils.AgentKeyFileName),
+	}
+	err = ca.Write(credentials.CACertPath, credentials.CAKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = ca.IssueLocalCertificate(utils.RoleHub, "cdw", []string{"cdw"}, time.Hour, credentials.HubCertPath, credentials.HubKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	issueAgentCertificate := func() string {
+		err := ca.IssueLocalCertificate(utils.RoleAgent, "sdw1", []string{"sdw1"}, time.Hour, credentials.AgentCertPath, credentials.AgentKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		certPEM, err := os.ReadFile(credentials.AgentCertPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		fingerprint, err := utils.PEMFingerprint(certPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return fingerprint
+	}
+	fingerprint := issueAgentCertificate()
+
+	serverCredentials, err := credentials.LoadServerCredentials(utils.RoleAgent)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	agentServer := grpc.NewServer(grpc.Creds(serverCredentials))
+	defer agentServer.Stop()
+
+	idl.RegisterAgentServer(agentServer, &agent.Server{})
+	go func() {
+		if err := agentServer.Serve(listener); err != nil {
+			log.Fatalf("server exited with error: %v", err)
+		}
+	}()
+
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
+	}
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	hubServer := hub.New(hubConfig, dialer)
+
+	t.Run("reports the certificate presented by each agent", func(t *testing.T) {
+		reply, err := hubServer.AgentCertificates(context.Background(), &idl.AgentCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Results) != 1 || !reply.Results[0].Success {
+			t.Fatalf("got %+v, want a single success", reply.Results)
+		}
+		if len(reply.Certificates) != 1 || reply.Certificates[0].Fingerprint != fingerprint || reply.Certificates[0].Subject != "sdw1" {
+			t.Fatalf("got %+v, want certificate %s for sdw1", reply.Certificates, fingerprint)
+		}
+	})
+
+	t.Run("reports a rotated certificate without restarting the agent", func(t *testing.T) {
+		// Make sure the new files do not share the modification time of the old ones
+		time.Sleep(10 * time.Millisecond)
+		rotated := issueAgentCertificate()
+		if rotated == fingerprint {
+			t.Fatalf("expected a new certificate to be issued")
+		}
+
+		reply, err := hubServer.AgentCertificates(context.Background(), &idl.AgentCertificatesRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Certificates) != 1 || reply.Certificates[0].Fingerprint != rotated {
+			t.Fatalf("got %+v, want certificate %s", reply.Certificates, rotated)
+		}
+	})
+
+	t.Run("reports the certificate presented by the hub", func(t *testing.T) {
+		reply, err := hubServer.Status(context.Background(), &idl.StatusHubRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if reply.Certificate.Subject != "cdw" || reply.Certificate.Issuer != "test CA" || len(reply.Certificate.Sans) != 1 || reply.Certificate.Sans[0] != "cdw" {
+			t.Fatalf("got %+v, want the hub certificate", reply.Certificate)
+		}
+	})
+
+	t.Run("errors out when a host is not part of the cluster", func(t *testing.T) {
+		_, err := hubServer.AgentCertificates(context.Background(), &idl.AgentCertificatesRequest{Hosts: []string{"sdw2"}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", status.Code(err), codes.InvalidArgument)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// ConfigVersion is the version of the gp.conf schema written by this build.
+// Files written before the schema was versioned have no configVersion key and
+// are treated as version 0.
+const ConfigVersion = 1
+
+// configMigration upgrades the keys of a configuration file by one version.
+type configMigration func(keys map[string]json.RawMessage) error
+
+// configMigrations[i] upgrades a configuration file from version i to i+1, so
+// that a file of any older version is upgraded by applying them in turn. A
+// change to the schema bumps ConfigVersion and appends a migration here.
+var configMigrations = []configMigration{
+	// Version 1 only introduced configVersion itself
+	func(keys map[string]json.RawMessage) error { return nil },
+}
+
+// requiredConfigKeys are the keys every configuration file must contain once
+// migrated; a missing one would otherwise silently load as a zero value.
+var requiredConfigKeys = []string{
+	"hubPort",
+	"agentPort",
+	"hostnames",
+	"hubLogDir",
+	"serviceName",
+	"gphome",
+	"Credentials",
+}
+
+// migrateConfig upgrades the contents of a configuration file to
+// ConfigVersion.
+func migrateConfig(contents []byte) ([]byte, error) {
+	var keys map[string]json.RawMessage
+	err := json.Unmarshal(contents, &keys)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if raw, ok := keys["configVersion"]; ok {
+		err = json.Unmarshal(raw, &version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configVersion %s: %w", raw, err)
+		}
+	}
+	if version < 0 || version > ConfigVersion {
+		return nil, fmt.Errorf("unsupported configVersion %d: this version of gp supports up to %d; upgrade gp to use this file", version, ConfigVersion)
+	}
+
+	for from := version; from < ConfigVersion; from++ {
+		err = configMigrations[from](keys)
+		if err != nil {
+			return nil, fmt.Errorf("could not migrate from configVersion %d to %d: %w", from, from+1, err)
+		}
+	}
+	if version < ConfigVersion {
+		gplog.Debug("Migrated configuration from configVersion %d to %d", version, ConfigVersion)
+	}
+	keys["configVersion"] = json.RawMessage(fmt.Sprint(ConfigVersion))
+
+	return json.Marshal(keys)
+}
+
+// decodeConfig decodes the migrated contents of a configuration file into
+// conf. It rejects keys which conf does not have and required keys which are
+// missing, so that a misspelled or renamed key is not silently ignored.
+func decodeConfig(contents []byte, conf *Config) error {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(conf)
+	if err != nil {
+		return err
+	}
+
+	var keys map[string]json.RawMessage
+	err = json.Unmarshal(contents, &keys)
+	if err != nil {
+		return err
+	}
+	for _, key := range requiredConfigKeys {
+		if _, ok := keys[key]; !ok {
+			return fmt.Errorf("missing required key %q", key)
+		}
+	}
+
+	return nil
+}
+
+// ConfigHistoryEntry is a previous version of a configuration file, kept when
+// the file was overwritten.
+type ConfigHistoryEntry struct {
+	Number int
+	Path   string
+	Saved  time.Time
+}
+
+// configHistoryDir returns the directory in which the previous versions of
+// the configuration file are kept.
+func configHistoryDir(configFilePath string) string {
+	return configFilePath + ".history"
+}
+
+// ConfigHistory returns the previous versions of the configuration file kept
+// in its history, newest first.
+func ConfigHistory(configFilePath string) ([]ConfigHistoryEntry, error) {
+	dir := configHistoryDir(configFilePath)
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read configuration history %s: %w", dir, err)
+	}
+
+	prefix := filepath.Base(configFilePath) + "."
+	entries := make([]ConfigHistoryEntry, 0, len(files))
+	for _, file := range files {
+		// Skip anything else, such as the temporary file of an interrupted write
+		number, err := strconv.Atoi(strings.TrimPrefix(file.Name(), prefix))
+		if !strings.HasPrefix(file.Name(), prefix) || err != nil || number <= 0 {
+			continue
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			return nil, fmt.Errorf("could not read configuration history %s: %w", dir, err)
+		}
+		entries = append(entries, ConfigHistoryEntry{Number: number, Path: filepath.Join(dir, file.Name()), Saved: info.ModTime()})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Number > entries[j].Number
+	})
+
+	return entries, nil
+}
+
+// saveConfigHistory keeps the current contents of the configuration file as
+// the newest entry of its history before they are replaced by next, and drops
+// the entries beyond constants.ConfigHistorySize. Nothing is kept if there is
+// no file yet or its contents do not change.
+func saveConfigHistory(configFilePath string, next []byte) error {
+	current, err := os.ReadFile(configFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not back up configuration file %s: %w", configFilePath, err)
+	}
+	// An empty file, such as one truncated by a crash, is not worth restoring
+	if len(bytes.TrimSpace(current)) == 0 || bytes.Equal(current, next) {
+		return nil
+	}
+
+	entries, err := ConfigHistory(configFilePath)
+	if err != nil {
+		return err
+	}
+
+	number := 1
+	if len(entries) > 0 {
+		number = entries[0].Number + 1
+	}
+	path := filepath.Join(configHistoryDir(configFilePath), fmt.Sprintf("%s.%d", filepath.Base(configFilePath), number))
+	err = utils.WriteFileAtomically(path, current, 0644)
+	if err != nil {
+		return fmt.Errorf("could not back up configuration file %s: %w", configFilePath, err)
+	}
+	gplog.Debug("Kept the previous configuration as %s", path)
+
+	for i, entry := range entries {
+		if i < constants.ConfigHistorySize-1 {
+			continue
+		}
+
+		err = os.Remove(entry.Path)
+		if err != nil {
+			gplog.Warn("Could not remove old configuration %s: %v", entry.Path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// CheckConfig compares the configuration file on the requested agent hosts
+// with that of the hub, and reports the keys whose values differ on each host.
+func (s *Server) CheckConfig(ctx context.Context, in *idl.CheckConfigRequest) (*idl.CheckConfigReply, error) {
+	checksum, fields, err := s.readConfigFile()
+	if err != nil {
+		return &idl.CheckConfigReply{}, err
+	}
+
+	err = s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.CheckConfigReply{}, err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return &idl.CheckConfigReply{}, err
+	}
+
+	drifts, results := checkConfigDrift(ctx, conns, checksum, fields)
+
+	return &idl.CheckConfigReply{Sha256: checksum, Drifts: drifts, Results: results}, nil
+}
+
+// SyncConfig copies the configuration file of the hub to the requested agent
+// hosts whose file differs from it. The agents only apply the copied file when
+// they are restarted.
+func (s *Server) SyncConfig(ctx context.Context, in *idl.SyncConfigRequest) (*idl.SyncConfigReply, error) {
+	checksum, fields, err := s.readConfigFile()
+	if err != nil {
+		return &idl.SyncConfigReply{}, err
+	}
+
+	info, err := utils.NewFileInfo(s.ConfigFilePath)
+	if err != nil {
+		return &idl.SyncConfigReply{}, grpcStatus.Errorf(codes.FailedPrecondition, "could not read configuration file: %v", err)
+	}
+
+	err = s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.SyncConfigReply{}, err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return &idl.SyncConfigReply{}, err
+	}
+
+	drifts, results := checkConfigDrift(ctx, conns, checksum, fields)
+
+	drifted := make(map[string]bool, len(drifts))
+	for _, drift := range drifts {
+		drifted[drift.Host] = true
+	}
+	var driftedConns []*Connection
+	for _, conn := range conns {
+		if drifted[conn.Hostname] {
+			driftedConns = append(driftedConns, conn)
+		}
+	}
+
+	syncResults := ExecuteRPC(ctx, driftedConns, func(conn *Connection) error {
+		err := PutFile(ctx, conn.AgentClient, s.ConfigFilePath, info)
+		if err != nil {
+			return fmt.Errorf("could not copy %s to host %s: %w", s.ConfigFilePath, conn.Hostname, err)
+		}
+
+		return nil
+	})
+
+	// The outcome of the copy replaces that of the check on the drifted hosts
+	syncResultsByHost := make(map[string]*idl.HostResult, len(syncResults))
+	synced := []string{}
+	for _, result := range syncResults {
+		syncResultsByHost[result.Host] = result
+		if result.Success {
+			synced = append(synced, result.Host)
+		}
+	}
+	for i, result := range results {
+		if syncResult, ok := syncResultsByHost[result.Host]; ok {
+			results[i] = syncResult
+		}
+	}
+	if len(synced) > 0 {
+		utils.LogInfo(ctx, "Copied the configuration file to hosts %v", synced)
+	}
+
+	return &idl.SyncConfigReply{SyncedHosts: synced, Results: results}, nil
+}
+
+// readConfigFile returns the checksum of the configuration file of the hub,
+// and the values of its keys. The file is read again, as it may have been
+// changed since the hub was started.
+func (s *Server) readConfigFile() (string, map[string]string, error) {
+	if s.ConfigFilePath == "" {
+		return "", nil, grpcStatus.Error(codes.FailedPrecondition, "the hub was not started from a configuration file")
+	}
+
+	contents, err := os.ReadFile(s.ConfigFilePath)
+	if err != nil {
+		return "", nil, grpcStatus.Errorf(codes.FailedPrecondition, "could not read configuration file: %v", err)
+	}
+
+	conf := &Config{}
+	err = conf.Parse(contents)
+	if err != nil {
+		return "", nil, grpcStatus.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	encoded, err := json.Marshal(conf)
+	if err != nil {
+		return "", nil, grpcStatus.Errorf(codes.Internal, "could not encode configuration: %v", err)
+	}
+
+	fields, err := configFields(encoded)
+	if err != nil {
+		return "", nil, grpcStatus.Errorf(codes.Internal, "%v", err)
+	}
+
+	return utils.Checksum(contents), fields, nil
+}
+
+// checkConfigDrift asks every agent for its configuration file and compares
+// it with that of the hub, described by checksum and fields. It returns a
+// drift for every host whose file differs, in the order of conns.
+func checkConfigDrift(ctx context.Context, conns []*Connection, checksum string, fields map[string]string) ([]*idl.ConfigDrift, []*idl.HostResult) {
+	var mutex sync.Mutex
+	driftsByHost := make(map[string]*idl.ConfigDrift)
+
+	request := func(conn *Connection) error {
+		drift := &idl.ConfigDrift{Host: conn.Hostname}
+
+		reply, err := conn.AgentClient.ConfigFile(ctx, &idl.ConfigFileAgentRequest{})
+		switch {
+		case grpcStatus.Code(err) == codes.NotFound:
+			// A missing file is drift which a sync repairs
+			drift.Error = grpcStatus.Convert(err).Message()
+		case err != nil:
+			return fmt.Errorf("could not get the configuration file of host %s: %w", conn.Hostname, err)
+		case reply.Sha256 == checksum:
+			return nil
+		case reply.ParseError != "":
+			drift.Sha256 = reply.Sha256
+			drift.Error = reply.ParseError
+		default:
+			drift.Sha256 = reply.Sha256
+			hostFields, err := configFields(reply.Config)
+			if err != nil {
+				return fmt.Errorf("could not read the configuration of host %s: %w", conn.Hostname, err)
+			}
+			drift.Fields = diffConfigFields(fields, hostFields)
+		}
+
+		mutex.Lock()
+		driftsByHost[conn.Hostname] = drift
+		mutex.Unlock()
+
+		return nil
+	}
+
+	results := ExecuteRPC(ctx, conns, request)
+
+	drifts := []*idl.ConfigDrift{}
+	for _, conn := range conns {
+		if drift, ok := driftsByHost[conn.Hostname]; ok {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts, results
+}
+
+// configFields flattens a configuration encoded as JSON into the JSON encoded
+// values of its keys, naming the keys of nested objects by their path, such
+// as Credentials.caCert.
+func configFields(encoded []byte) (map[string]string, error) {
+	var keys map[string]json.RawMessage
+	err := json.Unmarshal(encoded, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode configuration: %w", err)
+	}
+
+	fields := make(map[string]string)
+	var flatten func(prefix string, keys map[string]json.RawMessage)
+	flatten = func(prefix string, keys map[string]json.RawMessage) {
+		for key, value := range keys {
+			var nested map[string]json.RawMessage
+			if json.Unmarshal(value, &nested) == nil && nested != nil {
+				flatten(prefix+key+".", nested)
+				continue
+			}
+			fields[prefix+key] = string(value)
+		}
+	}
+	flatten("", keys)
+
+	return fields, nil
+}
+
+// diffConfigFields returns the keys whose values differ between the hub and a
+// host, in order.
+func diffConfigFields(hub map[string]string, host map[string]string) []*idl.ConfigFieldDiff {
+	keys := make(map[string]bool, len(hub)+len(host))
+	for key := range hub {
+		keys[key] = true
+	}
+	for key := range host {
+		keys[key] = true
+	}
+
+	diffs := []*idl.ConfigFieldDiff{}
+	for key := range keys {
+		if hub[key] != host[key] {
+			diffs = append(diffs, &idl.ConfigFieldDiff{Key: key, HubValue: hub[key], HostValue: host[key]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+
+	return diffs
+}
@@ -0,0 +1,170 @@
+package hub_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestConfigDrift(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	hubContents := `{"hubPort": 4242, "agentPort": 8000, "hostnames": ["sdw1", "sdw2", "sdw3", "sdw4"], "hubLogDir": "/tmp",
+		"serviceName": "gp", "gphome": "/usr/local/gp", "Credentials": {"caCert": "/path/to/ca.pem"}}`
+	// The agent on sdw2 runs with a stale port and CA after a manual edit
+	staleContents := strings.NewReplacer(`"agentPort": 8000`, `"agentPort": 9000`, `"caCert": "/path/to/ca.pem"`, `"caCert": "/old/ca.pem"`).Replace(hubContents)
+
+	// configFileReply is the reply of an agent whose configuration file has the given contents
+	configFileReply := func(t *testing.T, contents string) *idl.ConfigFileAgentReply {
+		t.Helper()
+
+		conf := &hub.Config{}
+		err := conf.Parse([]byte(contents))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		encoded, err := json.Marshal(conf)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return &idl.ConfigFileAgentReply{Sha256: utils.Checksum([]byte(contents)), Config: encoded}
+	}
+
+	setup := func(t *testing.T, ctrl *gomock.Controller) (*hub.Server, map[string]*mock_idl.MockAgentClient) {
+		t.Helper()
+
+		hubServer := hub.New(&hub.Config{
+			Port:        constants.DefaultHubPort,
+			AgentPort:   constants.DefaultAgentPort,
+			Hostnames:   []string{"sdw1", "sdw2", "sdw3", "sdw4"},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      "/usr/local/gp",
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, nil)
+		hubServer.ConfigFilePath = filepath.Join(t.TempDir(), "gp.conf")
+		err := os.WriteFile(hubServer.ConfigFilePath, []byte(hubContents), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		clients := make(map[string]*mock_idl.MockAgentClient)
+		for _, host := range []string{"sdw1", "sdw2", "sdw3"} {
+			clients[host] = mock_idl.NewMockAgentClient(ctrl)
+			hubServer.Conns = append(hubServer.Conns, &hub.Connection{AgentClient: clients[host], Hostname: host})
+		}
+		unreachable := &hub.Connection{Hostname: "sdw4"}
+		unreachable.SetHealthy(false)
+		hubServer.Conns = append(hubServer.Conns, unreachable)
+
+		clients["sdw1"].EXPECT().ConfigFile(gomock.Any(), gomock.Any()).Return(configFileReply(t, hubContents), nil)
+		clients["sdw2"].EXPECT().ConfigFile(gomock.Any(), gomock.Any()).Return(configFileReply(t, staleContents), nil)
+		clients["sdw3"].EXPECT().ConfigFile(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "configuration file /usr/local/gp/gp.conf does not exist"))
+
+		return hubServer, clients
+	}
+
+	t.Run("reports the keys which differ on each host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		hubServer, _ := setup(t, ctrl)
+
+		reply, err := hubServer.CheckConfig(context.Background(), &idl.CheckConfigRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if reply.Sha256 != utils.Checksum([]byte(hubContents)) {
+			t.Fatalf("got %s, want the checksum of the file of the hub", reply.Sha256)
+		}
+		expected := []*idl.ConfigDrift{
+			{
+				Host:   "sdw2",
+				Sha256: utils.Checksum([]byte(staleContents)),
+				Fields: []*idl.ConfigFieldDiff{
+					{Key: "Credentials.caCert", HubValue: `"/path/to/ca.pem"`, HostValue: `"/old/ca.pem"`},
+					{Key: "agentPort", HubValue: "8000", HostValue: "9000"},
+				},
+			},
+			{Host: "sdw3", Error: "configuration file /usr/local/gp/gp.conf does not exist"},
+		}
+		if !reflect.DeepEqual(reply.Drifts, expected) {
+			t.Fatalf("got %+v, want %+v", reply.Drifts, expected)
+		}
+
+		codesByHost := make(map[string]codes.Code)
+		for _, result := range reply.Results {
+			codesByHost[result.Host] = codes.Code(result.Code)
+		}
+		expectedCodes := map[string]codes.Code{"sdw1": codes.OK, "sdw2": codes.OK, "sdw3": codes.OK, "sdw4": codes.Unavailable}
+		if !reflect.DeepEqual(codesByHost, expectedCodes) {
+			t.Fatalf("got %v, want %v", codesByHost, expectedCodes)
+		}
+	})
+
+	t.Run("copies the file of the hub to the hosts where it differs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		hubServer, clients := setup(t, ctrl)
+
+		var sent []*idl.FileInfo
+		for _, host := range []string{"sdw2", "sdw3"} {
+			stream := mock_idl.NewMockAgent_PutFileClient(ctrl)
+			stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(request *idl.PutFileRequest) error {
+				if info := request.GetInfo(); info != nil {
+					sent = append(sent, info)
+				}
+				return nil
+			}).AnyTimes()
+			stream.EXPECT().CloseAndRecv().Return(&idl.PutFileReply{}, nil)
+			clients[host].EXPECT().PutFile(gomock.Any()).Return(stream, nil)
+		}
+
+		reply, err := hubServer.SyncConfig(context.Background(), &idl.SyncConfigRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(reply.SyncedHosts, []string{"sdw2", "sdw3"}) {
+			t.Fatalf("got %v, want %v", reply.SyncedHosts, []string{"sdw2", "sdw3"})
+		}
+		if len(sent) != 2 || sent[0].Path != hubServer.ConfigFilePath || sent[0].Sha256 != utils.Checksum([]byte(hubContents)) {
+			t.Fatalf("got %+v, want the file of the hub to be sent to two hosts", sent)
+		}
+		if len(reply.Results) != 4 || !reply.Results[1].Success || !reply.Results[2].Success || reply.Results[3].Success {
+			t.Fatalf("got %+v, want only the unreachable host to fail", reply.Results)
+		}
+	})
+
+	t.Run("errors out when the hub was not started from a configuration file", func(t *testing.T) {
+		hubServer := hub.New(&hub.Config{}, nil)
+
+		_, err := hubServer.CheckConfig(context.Background(), &idl.CheckConfigRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", err, codes.FailedPrecondition)
+		}
+	})
+}
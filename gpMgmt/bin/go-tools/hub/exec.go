@@ -0,0 +1,125 @@
+package hub
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// Exec runs a command on the requested agent hosts, forwarding their output to
+// the caller tagged with the host it came from as it arrives. The per-host
+// results are sent once the command has finished everywhere.
+func (s *Server) Exec(in *idl.ExecRequest, stream idl.Hub_ExecServer) error {
+	ctx := stream.Context()
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return err
+	}
+
+	// Output from all the hosts is multiplexed onto the one stream, which
+	// must not be written to concurrently.
+	var mutex sync.Mutex
+	send := func(reply *idl.ExecReply) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return stream.Send(reply)
+	}
+
+	request := func(conn *Connection) error {
+		agentStream, err := conn.AgentClient.Exec(ctx, &idl.ExecAgentRequest{Command: in.Command})
+		if err != nil {
+			return fmt.Errorf("could not run command on host %s: %w", conn.Hostname, err)
+		}
+
+		for {
+			reply, err := agentStream.Recv()
+			if err == io.EOF {
+				return fmt.Errorf("could not run command on host %s: agent did not report an exit code", conn.Hostname)
+			}
+			if err != nil {
+				return fmt.Errorf("could not run command on host %s: %w", conn.Hostname, err)
+			}
+
+			switch output := reply.Output.(type) {
+			case *idl.ExecAgentReply_Stdout:
+				err = send(&idl.ExecReply{Host: conn.Hostname, Output: &idl.ExecReply_Stdout{Stdout: output.Stdout}})
+			case *idl.ExecAgentReply_Stderr:
+				err = send(&idl.ExecReply{Host: conn.Hostname, Output: &idl.ExecReply_Stderr{Stderr: output.Stderr}})
+			case *idl.ExecAgentReply_ExitCode:
+				if output.ExitCode != 0 {
+					return fmt.Errorf("command exited with status %d on host %s", output.ExitCode, conn.Hostname)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	results := ExecuteRPC(ctx, conns, request)
+	for _, result := range results {
+		err = send(&idl.ExecReply{Host: result.Host, Output: &idl.ExecReply_Result{Result: result}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectHosts returns the given hosts, or all hosts if no hosts were given.
+func (s *Server) selectHosts(hosts []string) ([]string, error) {
+	all := s.agentHostnames()
+	if len(hosts) == 0 {
+		return all, nil
+	}
+
+	configured := make(map[string]bool, len(all))
+	for _, host := range all {
+		configured[host] = true
+	}
+
+	for _, host := range hosts {
+		if !configured[host] {
+			return nil, grpcStatus.Errorf(codes.InvalidArgument, "host %s is not part of the cluster", host)
+		}
+	}
+
+	return hosts, nil
+}
+
+// selectConns returns the connections to the given hosts, in the order they
+// were given, or all connections if no hosts were given.
+func (s *Server) selectConns(hosts []string) ([]*Connection, error) {
+	all := s.agentConns()
+	if len(hosts) == 0 {
+		return all, nil
+	}
+
+	connsByHost := make(map[string]*Connection, len(all))
+	for _, conn := range all {
+		connsByHost[conn.Hostname] = conn
+	}
+
+	conns := make([]*Connection, 0, len(hosts))
+	for _, host := range hosts {
+		conn, ok := connsByHost[host]
+		if !ok {
+			return nil, grpcStatus.Errorf(codes.InvalidArgument, "host %s is not part of the cluster", host)
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}
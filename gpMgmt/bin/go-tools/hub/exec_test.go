@@ -0,0 +1,177 @@
+package hub_test
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+type execServerStream struct {
+	grpc.ServerStream
+	replies []*idl.ExecReply
+}
+
+func (s *execServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *execServerStream) Send(reply *idl.ExecReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func mockExecClient(ctrl *gomock.Controller, replies ...*idl.ExecAgentReply) *mock_idl.MockAgentClient {
+	stream := mock_idl.NewMockAgent_ExecClient(ctrl)
+	calls := make([]*gomock.Call, 0, len(replies)+1)
+	for _, reply := range replies {
+		calls = append(calls, stream.EXPECT().Recv().Return(reply, nil))
+	}
+	calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF).AnyTimes())
+	gomock.InOrder(calls...)
+
+	client := mock_idl.NewMockAgentClient(ctrl)
+	client.EXPECT().Exec(gomock.Any(), &idl.ExecAgentRequest{Command: "some command"}, gomock.Any()).Return(stream, nil)
+
+	return client
+}
+
+func TestExec(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
+	}
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	t.Run("streams the output of every host followed by their results", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mockExecClient(ctrl,
+			&idl.ExecAgentReply{Output: &idl.ExecAgentReply_Stdout{Stdout: []byte("out1\n")}},
+			&idl.ExecAgentReply{Output: &idl.ExecAgentReply_ExitCode{ExitCode: 0}},
+		)
+		sdw2 := mockExecClient(ctrl,
+			&idl.ExecAgentReply{Output: &idl.ExecAgentReply_Stderr{Stderr: []byte("err2\n")}},
+			&idl.ExecAgentReply{Output: &idl.ExecAgentReply_ExitCode{ExitCode: 3}},
+		)
+
+		hubServer := hub.New(hubConfig, nil)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		stream := &execServerStream{}
+		err := hubServer.Exec(&idl.ExecRequest{Command: "some command"}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		// The output of the hosts may be interleaved in any order, but the
+		// results always come last and in host order.
+		output := stream.replies[:2]
+		sort.Slice(output, func(i, j int) bool { return output[i].Host < output[j].Host })
+		expected := []*idl.ExecReply{
+			{Host: "sdw1", Output: &idl.ExecReply_Stdout{Stdout: []byte("out1\n")}},
+			{Host: "sdw2", Output: &idl.ExecReply_Stderr{Stderr: []byte("err2\n")}},
+			{Host: "sdw1", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw1", Success: true}}},
+			{Host: "sdw2", Output: &idl.ExecReply_Result{Result: &idl.HostResult{
+				Host:    "sdw2",
+				Code:    int32(codes.Unknown),
+				Message: "command exited with status 3 on host sdw2",
+			}}},
+		}
+		if !reflect.DeepEqual(stream.replies, expected) {
+			t.Fatalf("got %+v, want %+v", stream.replies, expected)
+		}
+	})
+
+	t.Run("only runs the command on the requested hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw2 := mockExecClient(ctrl,
+			&idl.ExecAgentReply{Output: &idl.ExecAgentReply_ExitCode{ExitCode: 0}},
+		)
+
+		hubServer := hub.New(hubConfig, nil)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		stream := &execServerStream{}
+		err := hubServer.Exec(&idl.ExecRequest{Hosts: []string{"sdw2"}, Command: "some command"}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.ExecReply{
+			{Host: "sdw2", Output: &idl.ExecReply_Result{Result: &idl.HostResult{Host: "sdw2", Success: true}}},
+		}
+		if !reflect.DeepEqual(stream.replies, expected) {
+			t.Fatalf("got %+v, want %+v", stream.replies, expected)
+		}
+	})
+
+	t.Run("reports hosts whose agent fails to run the command", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+
+		hubServer := hub.New(hubConfig, nil)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		stream := &execServerStream{}
+		err := hubServer.Exec(&idl.ExecRequest{Command: "some command"}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		result := stream.replies[0].GetResult()
+		if result.Success || codes.Code(result.Code) != codes.Unavailable {
+			t.Fatalf("got %+v, want an unavailable failure", result)
+		}
+	})
+
+	t.Run("errors out when given a host that is not part of the cluster", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, nil)
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}}
+
+		err := hubServer.Exec(&idl.ExecRequest{Hosts: []string{"sdw3"}, Command: "some command"}, &execServerStream{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want an invalid argument error", err)
+		}
+	})
+}
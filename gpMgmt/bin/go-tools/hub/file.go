@@ -0,0 +1,156 @@
+package hub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// CopyFile copies a file on the hub host, or on the requested source host, to
+// the requested agent hosts, preserving its mode and owner. A file on another
+// host is fetched to the hub host first.
+func (s *Server) CopyFile(ctx context.Context, in *idl.CopyFileRequest) (*idl.CopyFileReply, error) {
+	if !filepath.IsAbs(in.Source) || !filepath.IsAbs(in.Destination) {
+		return &idl.CopyFileReply{}, grpcStatus.Errorf(codes.InvalidArgument, "source and destination paths must be absolute")
+	}
+
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.CopyFileReply{}, err
+	}
+
+	source := in.Source
+	var info *idl.FileInfo
+	if in.SourceHost == "" {
+		info, err = utils.NewFileInfo(in.Source)
+		if err != nil {
+			return &idl.CopyFileReply{}, grpcStatus.Errorf(codes.InvalidArgument, "could not read %s: %v", in.Source, err)
+		}
+	} else {
+		sourceConns, err := s.selectConns([]string{in.SourceHost})
+		if err != nil {
+			return &idl.CopyFileReply{}, err
+		}
+
+		file, err := os.CreateTemp("", filepath.Base(in.Source)+".*")
+		if err != nil {
+			return &idl.CopyFileReply{}, fmt.Errorf("could not create temporary file: %w", err)
+		}
+		file.Close()
+		defer os.Remove(file.Name())
+
+		source = file.Name()
+		info, err = GetFile(ctx, sourceConns[0].AgentClient, in.Source, source)
+		if err != nil {
+			status := grpcStatus.Convert(err)
+			return &idl.CopyFileReply{}, grpcStatus.Errorf(status.Code(), "could not copy %s from host %s: %s", in.Source, in.SourceHost, status.Message())
+		}
+	}
+	info.Path = in.Destination
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return &idl.CopyFileReply{}, err
+	}
+
+	request := func(conn *Connection) error {
+		err := PutFile(ctx, conn.AgentClient, source, info)
+		if err != nil {
+			return fmt.Errorf("could not copy %s to host %s: %w", in.Source, conn.Hostname, err)
+		}
+
+		return nil
+	}
+
+	return &idl.CopyFileReply{Results: ExecuteRPC(ctx, conns, request)}, nil
+}
+
+// PutFile streams the local file src to an agent, which writes it according
+// to info and verifies its checksum.
+func PutFile(ctx context.Context, client idl.AgentClient, src string, info *idl.FileInfo) error {
+	stream, err := client.PutFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Send only returns io.EOF when the agent has aborted the transfer; the
+	// reason is reported by CloseAndRecv.
+	err = stream.Send(&idl.PutFileRequest{Data: &idl.PutFileRequest_Info{Info: info}})
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if err == nil {
+		err = utils.ReadFileChunks(src, func(chunk []byte) error {
+			return stream.Send(&idl.PutFileRequest{Data: &idl.PutFileRequest_Chunk{Chunk: chunk}})
+		})
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// GetFile streams the file src from an agent into the local file dst, verifying
+// its checksum, and returns the FileInfo the agent sent for it.
+func GetFile(ctx context.Context, client idl.AgentClient, src string, dst string) (*idl.FileInfo, error) {
+	stream, err := client.GetFile(ctx, &idl.GetFileRequest{Path: src})
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	info := reply.GetInfo()
+	if info == nil {
+		return nil, grpcStatus.Errorf(codes.Internal, "expected the file info of %s to be sent first", src)
+	}
+
+	file, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s: %w", dst, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	writer := io.MultiWriter(file, hash)
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = writer.Write(reply.GetChunk())
+		if err != nil {
+			return nil, fmt.Errorf("could not write %s: %w", dst, err)
+		}
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not write %s: %w", dst, err)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if checksum != info.Sha256 {
+		return nil, grpcStatus.Errorf(codes.DataLoss, "checksum mismatch for %s: got %s, want %s", src, checksum, info.Sha256)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,193 @@
+package hub_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestCopyFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	agentServer := grpc.NewServer()
+	defer agentServer.Stop()
+
+	idl.RegisterAgentServer(agentServer, &agent.Server{})
+	go func() {
+		if err := agentServer.Serve(listener); err != nil {
+			log.Fatalf("server exited with error: %v", err)
+		}
+	}()
+
+	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
+	}
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	// Make the file span several chunks
+	contents := make([]byte, 3*constants.FileChunkSize+10)
+	for i := range contents {
+		contents[i] = byte(i)
+	}
+	err := os.WriteFile(source, contents, 0640)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("copies the file to the agent hosts", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		destination := filepath.Join(dir, "new dir", "destination")
+
+		reply, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{Source: source, Destination: destination})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Results) != 1 || !reply.Results[0].Success {
+			t.Fatalf("got %+v, want a single success", reply.Results)
+		}
+
+		result, err := os.ReadFile(destination)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(result) != string(contents) {
+			t.Fatalf("copied file contents do not match the source file")
+		}
+
+		info, err := os.Stat(destination)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Fatalf("got %v, want %v", info.Mode().Perm(), os.FileMode(0640))
+		}
+	})
+
+	t.Run("copies a file on an agent host to the agent hosts", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		destination := filepath.Join(dir, "fetched")
+
+		reply, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{SourceHost: "sdw1", Source: source, Destination: destination})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Results) != 1 || !reply.Results[0].Success {
+			t.Fatalf("got %+v, want a single success", reply.Results)
+		}
+
+		result, err := os.ReadFile(destination)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(result) != string(contents) {
+			t.Fatalf("copied file contents do not match the source file")
+		}
+
+		info, err := os.Stat(destination)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Fatalf("got %v, want %v", info.Mode().Perm(), os.FileMode(0640))
+		}
+	})
+
+	t.Run("errors out when the file does not exist on the source host", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		_, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{
+			SourceHost:  "sdw1",
+			Source:      filepath.Join(dir, "does-not-exist"),
+			Destination: filepath.Join(dir, "destination"),
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want a not found error", err)
+		}
+	})
+
+	t.Run("errors out when the source host is not part of the cluster", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		_, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{SourceHost: "sdw9", Source: source, Destination: filepath.Join(dir, "destination")})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want an invalid argument error", err)
+		}
+	})
+
+	t.Run("errors out when the source file does not exist", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		_, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{
+			Source:      filepath.Join(dir, "does-not-exist"),
+			Destination: filepath.Join(dir, "destination"),
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want an invalid argument error", err)
+		}
+	})
+
+	t.Run("errors out when the paths are not absolute", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		_, err := hubServer.CopyFile(context.Background(), &idl.CopyFileRequest{Source: source, Destination: "destination"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want an invalid argument error", err)
+		}
+	})
+}
+
+func TestGetFile(t *testing.T) {
+	t.Run("rejects a file whose contents do not match its checksum", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		stream := mock_idl.NewMockAgent_GetFileClient(ctrl)
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&idl.GetFileReply{Data: &idl.GetFileReply_Info{Info: &idl.FileInfo{Path: "/src", Sha256: utils.Checksum([]byte("contents"))}}}, nil),
+			stream.EXPECT().Recv().Return(&idl.GetFileReply{Data: &idl.GetFileReply_Chunk{Chunk: []byte("corrupted")}}, nil),
+			stream.EXPECT().Recv().Return(nil, io.EOF),
+		)
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().GetFile(gomock.Any(), &idl.GetFileRequest{Path: "/src"}).Return(stream, nil)
+
+		_, err := hub.GetFile(context.Background(), client, "/src", filepath.Join(t.TempDir(), "dst"))
+		if status.Code(err) != codes.DataLoss {
+			t.Fatalf("got %v, want %v", err, codes.DataLoss)
+		}
+	})
+}
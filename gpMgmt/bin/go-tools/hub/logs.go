@@ -0,0 +1,303 @@
+package hub
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// LogManifestFileName is the name of the manifest in a log bundle.
+const LogManifestFileName = "manifest.json"
+
+// LogManifest describes the contents of a log bundle.
+type LogManifest struct {
+	Created time.Time         `json:"created"`
+	Since   *time.Time        `json:"since,omitempty"`
+	Until   *time.Time        `json:"until,omitempty"`
+	Hosts   []LogManifestHost `json:"hosts"`
+}
+
+// LogManifestHost lists the files collected from a host. Error is set if the
+// logs of the host could not be collected.
+type LogManifestHost struct {
+	Host  string   `json:"host"`
+	Role  string   `json:"role"`
+	Files []string `json:"files"`
+	Error string   `json:"error,omitempty"`
+}
+
+// CollectLogs collects the logs of the hub host and of the requested agent
+// hosts into a tar.gz archive at the requested destination on the hub host.
+// Every host has its own directory in the archive, and a manifest lists the
+// files collected from each of them. The archive is written even if the logs
+// of some hosts could not be collected.
+func (s *Server) CollectLogs(ctx context.Context, in *idl.CollectLogsRequest) (*idl.CollectLogsReply, error) {
+	if !filepath.IsAbs(in.Destination) {
+		return &idl.CollectLogsReply{}, grpcStatus.Errorf(codes.InvalidArgument, "destination path %s is not absolute", in.Destination)
+	}
+
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.CollectLogsReply{}, err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return &idl.CollectLogsReply{}, err
+	}
+
+	// The archives sent by the agents are kept next to the destination until
+	// all of them have been received.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(in.Destination), ".gp-logs-*")
+	if err != nil {
+		return &idl.CollectLogsReply{}, fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	request := func(conn *Connection) error {
+		err := receiveLogs(ctx, conn, in, filepath.Join(tmpDir, conn.Hostname+".tar"))
+		if err != nil {
+			return fmt.Errorf("could not collect logs from host %s: %w", conn.Hostname, err)
+		}
+
+		return nil
+	}
+
+	results := ExecuteRPC(ctx, conns, request)
+
+	err = s.writeLogBundle(in, tmpDir, results)
+	if err != nil {
+		return &idl.CollectLogsReply{}, err
+	}
+	utils.LogInfo(ctx, "Wrote logs of %d hosts to %s", len(results), in.Destination)
+
+	return &idl.CollectLogsReply{Results: results}, nil
+}
+
+func receiveLogs(ctx context.Context, conn *Connection, in *idl.CollectLogsRequest, path string) error {
+	stream, err := conn.AgentClient.CollectLogs(ctx, &idl.CollectLogsAgentRequest{
+		Since:          in.Since,
+		Until:          in.Until,
+		SegmentLogDirs: in.SegmentLogDirs,
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return file.Close()
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = file.Write(reply.Chunk)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) writeLogBundle(in *idl.CollectLogsRequest, tmpDir string, results []*idl.HostResult) error {
+	tmp, err := os.CreateTemp(filepath.Dir(in.Destination), fmt.Sprintf(".%s.*", filepath.Base(in.Destination)))
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", in.Destination, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once the file has been renamed
+	defer tmp.Close()
+
+	gz := gzip.NewWriter(tmp)
+	archive := utils.NewLogArchive(gz)
+
+	root := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(in.Destination), ".tgz"), ".tar.gz")
+	manifest := LogManifest{Created: time.Now().UTC(), Hosts: make([]LogManifestHost, 0, len(results)+1)}
+	if in.Since != 0 {
+		since := time.Unix(in.Since, 0).UTC()
+		manifest.Since = &since
+	}
+	if in.Until != 0 {
+		until := time.Unix(in.Until, 0).UTC()
+		manifest.Until = &until
+	}
+
+	hostname, _ := os.Hostname()
+	var since time.Time
+	if manifest.Since != nil {
+		since = *manifest.Since
+	}
+	files := []string{}
+	if s.LogDir != "" {
+		files, err = archive.AddLogFiles(filepath.Join(root, hostname, "hub"), s.LogDir, "gp_*", since)
+		if err != nil {
+			return err
+		}
+	}
+	manifest.Hosts = append(manifest.Hosts, LogManifestHost{Host: hostname, Role: "hub", Files: files})
+
+	for _, result := range results {
+		host := LogManifestHost{Host: result.Host, Role: "agent", Files: []string{}}
+		if result.Success {
+			host.Files, err = addHostLogs(archive, filepath.Join(root, result.Host), filepath.Join(tmpDir, result.Host+".tar"))
+			if err != nil {
+				return err
+			}
+		} else {
+			host.Error = result.Message
+		}
+		manifest.Hosts = append(manifest.Hosts, host)
+	}
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+	err = archive.AddBytes(filepath.Join(root, LogManifestFileName), append(contents, '\n'))
+	if err != nil {
+		return err
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+	err = gz.Close()
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", in.Destination, err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", in.Destination, err)
+	}
+
+	err = os.Rename(tmp.Name(), in.Destination)
+	if err != nil {
+		return fmt.Errorf("could not move %s into place: %w", in.Destination, err)
+	}
+
+	return nil
+}
+
+func addHostLogs(archive *utils.LogArchive, prefix string, path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return archive.AddArchive(prefix, file)
+}
+
+// TailLogs tails the logs on the requested agent hosts, forwarding their
+// entries to the caller tagged with the host they came from as they arrive.
+// The result of each host is sent as soon as its logs are no longer tailed, so
+// that a host failing does not go unnoticed while the others are followed.
+func (s *Server) TailLogs(in *idl.TailLogsRequest, stream idl.Hub_TailLogsServer) error {
+	ctx := stream.Context()
+
+	// Validate the filter once here rather than failing on every host
+	_, err := utils.NewLogFilter(in.Severity, in.Pattern)
+	if err != nil {
+		return grpcStatus.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.connectToAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	conns, err := s.selectConns(in.Hosts)
+	if err != nil {
+		return err
+	}
+
+	// Entries from all the hosts are multiplexed onto the one stream, which
+	// must not be written to concurrently.
+	var mutex sync.Mutex
+	reported := make(map[string]bool, len(conns))
+	send := func(reply *idl.TailLogsReply) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if _, ok := reply.Output.(*idl.TailLogsReply_Result); ok {
+			if reported[reply.Host] {
+				return nil
+			}
+			reported[reply.Host] = true
+		}
+
+		return stream.Send(reply)
+	}
+
+	request := func(conn *Connection) error {
+		err := tailHostLogs(ctx, conn, in, send)
+		if err != nil {
+			err = fmt.Errorf("could not tail logs on host %s: %w", conn.Hostname, err)
+		}
+
+		sendErr := send(&idl.TailLogsReply{Host: conn.Hostname, Output: &idl.TailLogsReply_Result{Result: NewHostResult(conn.Hostname, err)}})
+		if err == nil {
+			err = sendErr
+		}
+
+		return err
+	}
+
+	// Hosts which could not be reached at all have not been reported yet
+	results := ExecuteRPC(ctx, conns, request)
+	for _, result := range results {
+		err = send(&idl.TailLogsReply{Host: result.Host, Output: &idl.TailLogsReply_Result{Result: result}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tailHostLogs(ctx context.Context, conn *Connection, in *idl.TailLogsRequest, send func(reply *idl.TailLogsReply) error) error {
+	stream, err := conn.AgentClient.TailLogs(ctx, &idl.TailLogsAgentRequest{
+		DataDirs: in.DataDirs,
+		Lines:    in.Lines,
+		Follow:   in.Follow,
+		Severity: in.Severity,
+		Pattern:  in.Pattern,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = send(&idl.TailLogsReply{Host: conn.Hostname, Output: &idl.TailLogsReply_Entry{Entry: reply}})
+		if err != nil {
+			return err
+		}
+	}
+}
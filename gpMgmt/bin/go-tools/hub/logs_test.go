@@ -0,0 +1,334 @@
+package hub_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+)
+
+func TestCollectLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	writeLog := func(path string, contents string, age time.Duration) {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = os.WriteFile(path, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		modified := time.Now().Add(-age)
+		err = os.Chtimes(path, modified, modified)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+
+	agentLogDir := filepath.Join(dir, "agent")
+	writeLog(filepath.Join(agentLogDir, "gp_agent.log"), "agent log", 0)
+	writeLog(filepath.Join(agentLogDir, "gp_agent.log.20230101-000000.000000"), "old agent log", 48*time.Hour)
+	writeLog(filepath.Join(agentLogDir, "gp_stop_agents.log"), "not an agent log", 0)
+	writeLog(filepath.Join(dir, "data", "gpseg0", "log", "gpdb.csv"), "segment log", 0)
+
+	hubLogDir := filepath.Join(dir, "hub")
+	writeLog(filepath.Join(hubLogDir, "gp_hub.log"), "hub log", 0)
+
+	agent.SetPlatform(&testutils.MockPlatform{LogCmd: exec.Command("echo", "journal")})
+	defer agent.ResetPlatform()
+
+	listener := bufconn.Listen(1024 * 1024)
+	agentServer := grpc.NewServer()
+	defer agentServer.Stop()
+
+	idl.RegisterAgentServer(agentServer, agent.New(agent.Config{ServiceName: "gp", LogDir: agentLogDir}))
+	go func() {
+		if err := agentServer.Serve(listener); err != nil {
+			log.Fatalf("server exited with error: %v", err)
+		}
+	}()
+
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1"},
+		LogDir:      hubLogDir,
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+	}
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+
+	readBundle := func(t *testing.T, path string) (map[string]string, hub.LogManifest) {
+		t.Helper()
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		files := make(map[string]string)
+		tr := tar.NewReader(gz)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			contents, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			files[header.Name] = string(contents)
+		}
+
+		var manifest hub.LogManifest
+		err = json.Unmarshal([]byte(files["bundle/manifest.json"]), &manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		delete(files, "bundle/manifest.json")
+
+		return files, manifest
+	}
+
+	t.Run("collects the recent logs of the hub and agents into one archive", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		destination := filepath.Join(t.TempDir(), "bundle.tar.gz")
+
+		reply, err := hubServer.CollectLogs(context.Background(), &idl.CollectLogsRequest{
+			Since:          time.Now().Add(-time.Hour).Unix(),
+			SegmentLogDirs: []string{filepath.Join(dir, "data", "*", "log")},
+			Destination:    destination,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(reply.Results) != 1 || !reply.Results[0].Success {
+			t.Fatalf("got %+v, want a single success", reply.Results)
+		}
+
+		files, manifest := readBundle(t, destination)
+
+		hostname, _ := os.Hostname()
+		segmentLog := filepath.Join("bundle", "sdw1", "segments", dir[1:], "data", "gpseg0", "log", "gpdb.csv")
+		expected := map[string]string{
+			"bundle/" + hostname + "/hub/gp_hub.log": "hub log",
+			"bundle/sdw1/agent/gp_agent.log":         "agent log",
+			"bundle/sdw1/journal/gp_agent.log":       "journal\n",
+			segmentLog:                               "segment log",
+		}
+		if !reflect.DeepEqual(files, expected) {
+			t.Fatalf("got %v, want %v", files, expected)
+		}
+
+		if len(manifest.Hosts) != 2 || manifest.Since == nil {
+			t.Fatalf("got %+v, want the hub and one agent host with the time window", manifest)
+		}
+		if manifest.Hosts[1].Host != "sdw1" || len(manifest.Hosts[1].Files) != 3 || manifest.Hosts[1].Error != "" {
+			t.Fatalf("got %+v, want the three files collected from sdw1", manifest.Hosts[1])
+		}
+	})
+
+	t.Run("records the hosts whose logs could not be collected in the manifest", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		destination := filepath.Join(t.TempDir(), "bundle.tar.gz")
+
+		reply, err := hubServer.CollectLogs(context.Background(), &idl.CollectLogsRequest{
+			SegmentLogDirs: []string{"relative/log"},
+			Destination:    destination,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(reply.Results) != 1 || codes.Code(reply.Results[0].Code) != codes.InvalidArgument {
+			t.Fatalf("got %+v, want a single InvalidArgument failure", reply.Results)
+		}
+
+		files, manifest := readBundle(t, destination)
+		if len(files) != 1 {
+			t.Fatalf("got %v, want only the hub log", files)
+		}
+		if manifest.Hosts[1].Error == "" {
+			t.Fatalf("got %+v, want the error of sdw1", manifest.Hosts[1])
+		}
+	})
+
+	t.Run("errors out when the destination is not absolute", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		_, err := hubServer.CollectLogs(context.Background(), &idl.CollectLogsRequest{Destination: "bundle.tar.gz"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", status.Code(err), codes.InvalidArgument)
+		}
+	})
+}
+
+type tailLogsServerStream struct {
+	grpc.ServerStream
+	replies []*idl.TailLogsReply
+}
+
+func (s *tailLogsServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *tailLogsServerStream) Send(reply *idl.TailLogsReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func TestTailLogs(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	agentLogDir := filepath.Join(dir, "agent")
+	segmentLogDir := filepath.Join(dir, "data", "gpseg0", "log")
+	for _, logDir := range []string{agentLogDir, segmentLogDir} {
+		err := os.MkdirAll(logDir, 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+
+	err := os.WriteFile(filepath.Join(agentLogDir, "gp_agent.log"), []byte(
+		"2023-01-01 00:00:00.000000 sdw1  [INFO] agent started\n"+
+			"2023-01-01 00:00:01.000000 sdw1  [ERROR] agent failed\n"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = os.WriteFile(filepath.Join(segmentLogDir, "gpdb-2023-01-01_000000.csv"), []byte(
+		`2023-01-01 00:00:02.000000 UTC,,,p1,th1,,,,,,,seg0,,,,,"ERROR","XX000","segment failed",,,,,,,0,,,,`+"\n"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	agentServer := grpc.NewServer()
+	defer agentServer.Stop()
+
+	idl.RegisterAgentServer(agentServer, agent.New(agent.Config{ServiceName: "gp", LogDir: agentLogDir}))
+	go func() {
+		if err := agentServer.Serve(listener); err != nil {
+			log.Fatalf("server exited with error: %v", err)
+		}
+	}()
+
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1"},
+		LogDir:      filepath.Join(dir, "hub"),
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+	}
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}
+
+	t.Run("sends the selected entries of the agent and segment logs of every host", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		stream := &tailLogsServerStream{}
+
+		err := hubServer.TailLogs(&idl.TailLogsRequest{
+			DataDirs: []string{filepath.Join(dir, "data", "gpseg*")},
+			Lines:    10,
+			Severity: "ERROR",
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(stream.replies) != 3 {
+			t.Fatalf("got %+v, want two entries and a result", stream.replies)
+		}
+
+		entries := make(map[string]string)
+		for _, reply := range stream.replies[:2] {
+			entry := reply.GetEntry()
+			if reply.Host != "sdw1" || entry == nil || entry.Severity != "ERROR" {
+				t.Fatalf("got %+v, want an error entry of sdw1", reply)
+			}
+			entries[entry.DataDir] = entry.Text
+		}
+
+		if entries[""] != "2023-01-01 00:00:01.000000 sdw1  [ERROR] agent failed" {
+			t.Fatalf("got %q, want the failure of the agent", entries[""])
+		}
+		segmentEntry := entries[filepath.Join(dir, "data", "gpseg0")]
+		if !strings.Contains(segmentEntry, "segment failed") {
+			t.Fatalf("got %q, want the failure of the segment", segmentEntry)
+		}
+
+		result := stream.replies[2].GetResult()
+		if result == nil || !result.Success {
+			t.Fatalf("got %+v, want the success of sdw1", stream.replies[2])
+		}
+	})
+
+	t.Run("sends the failure of a host whose logs could not be tailed", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+		stream := &tailLogsServerStream{}
+
+		err := hubServer.TailLogs(&idl.TailLogsRequest{DataDirs: []string{"relative/gpseg0"}}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(stream.replies) != 1 {
+			t.Fatalf("got %+v, want a single result", stream.replies)
+		}
+		result := stream.replies[0].GetResult()
+		if result == nil || codes.Code(result.Code) != codes.InvalidArgument {
+			t.Fatalf("got %+v, want an InvalidArgument failure", stream.replies[0])
+		}
+	})
+
+	t.Run("errors out when the severity is invalid", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, dialer)
+
+		err := hubServer.TailLogs(&idl.TailLogsRequest{Severity: "LOUD"}, &tailLogsServerStream{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", status.Code(err), codes.InvalidArgument)
+		}
+	})
+}
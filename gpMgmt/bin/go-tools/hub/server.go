@@ -3,24 +3,27 @@ package hub
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/greenplum-db/gpdb/gp/constants"
-
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/executor"
 	"github.com/greenplum-db/gpdb/gp/idl"
-	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
 	"github.com/greenplum-db/gpdb/gp/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	grpcStatus "google.golang.org/grpc/status"
 )
@@ -28,39 +31,138 @@ import (
 var (
 	platform                      = utils.GetPlatform()
 	DialTimeout                   = 3 * time.Second
+	KeepaliveTime                 = 30 * time.Second // how often idle connections to the agents are checked
+	KeepaliveTimeout              = 10 * time.Second
+	MaxReconnectDelay             = 30 * time.Second
+	EvictTimeout                  = 5 * time.Minute // how long a connection may stay unhealthy before it is re-created
 	ensureConnectionsAreReadyFunc = ensureConnectionsAreReady
-	execCommand                   = exec.Command
 )
 
+var remoteExecutor executor.Executor = executor.NewSSHExecutor()
+
 type Dialer func(context.Context, string) (net.Conn, error)
 
 type Config struct {
+	ConfigVersion int `json:"configVersion"` // version of the schema, set to ConfigVersion when written
+
 	Port        int      `json:"hubPort"`
 	AgentPort   int      `json:"agentPort"`
 	Hostnames   []string `json:"hostnames"`
 	LogDir      string   `json:"hubLogDir"` // log directory for the hub itself; utilities might go somewhere else
 	ServiceName string   `json:"serviceName"`
 	GpHome      string   `json:"gphome"`
+	// The log format defaults to text, and zero rotation limits disable rotation
+	LogFormat     string `json:"logFormat,omitempty"`
+	LogMaxSizeMB  int    `json:"logMaxSizeMB,omitempty"`
+	LogMaxAgeDays int    `json:"logMaxAgeDays,omitempty"`
+	LogMaxBackups int    `json:"logMaxBackups,omitempty"`
+	// Metrics are served over HTTP on these ports; zero disables them
+	MetricsPort      int `json:"hubMetricsPort,omitempty"`
+	AgentMetricsPort int `json:"agentMetricsPort,omitempty"`
 
 	Credentials utils.Credentials
+	// Authorization is optional; without it only the hub may call the agents and only the CLI the hub
+	Authorization *utils.AuthorizationPolicy `json:"authorization,omitempty"`
 }
 
 type Server struct {
 	*Config
-	Conns      []*Connection
-	grpcDialer Dialer
+	ConfigFilePath string // the file Config was loaded from, which ReloadConfig reads again
+	Conns          []*Connection
+	grpcDialer     Dialer
 
 	mutex      sync.Mutex
 	grpcServer *grpc.Server
 	listener   net.Listener
 	finish     chan struct{}
+	health     *health.Server
 }
 
 type Connection struct {
 	Conn          *grpc.ClientConn
 	AgentClient   idl.AgentClient
+	HealthClient  healthpb.HealthClient
 	Hostname      string
 	CancelContext func()
+
+	mutex          sync.Mutex
+	unhealthySince time.Time        // zero while the connection is healthy
+	version        *idl.VersionInfo // nil until the version handshake succeeded
+	incompatible   error            // set if the agent cannot be worked with
+}
+
+// Healthy reports whether the agent was reachable when the connection was last
+// checked.
+func (c *Connection) Healthy() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.unhealthySince.IsZero()
+}
+
+// SetHealthy records whether the agent is currently reachable.
+func (c *Connection) SetHealthy(healthy bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch {
+	case healthy:
+		c.unhealthySince = time.Time{}
+	case c.unhealthySince.IsZero():
+		c.unhealthySince = time.Now()
+	}
+}
+
+// AgentVersion returns the version the agent reported in the version
+// handshake, or nil if the handshake has not been done yet.
+func (c *Connection) AgentVersion() *idl.VersionInfo {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.version
+}
+
+// Incompatible returns why the agent cannot be worked with, or nil if it can.
+func (c *Connection) Incompatible() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.incompatible
+}
+
+// SetAgentVersion records the outcome of the version handshake.
+func (c *Connection) SetAgentVersion(version *idl.VersionInfo, incompatible error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.version = version
+	c.incompatible = incompatible
+}
+
+// shouldEvict reports whether the connection needs to be replaced by a new one,
+// either because it was closed or because it has been unhealthy for too long.
+func (c *Connection) shouldEvict() bool {
+	if c.Conn == nil {
+		return false
+	}
+
+	if c.Conn.GetState() == connectivity.Shutdown {
+		return true
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return !c.unhealthySince.IsZero() && time.Since(c.unhealthySince) > EvictTimeout
+}
+
+func (c *Connection) close() {
+	if c.Conn != nil {
+		c.Conn.Close()
+	}
+	if c.CancelContext != nil {
+		c.CancelContext()
+	}
 }
 
 func New(conf *Config, grpcDialer Dialer) *Server {
@@ -68,7 +170,12 @@ func New(conf *Config, grpcDialer Dialer) *Server {
 		Config:     conf,
 		grpcDialer: grpcDialer,
 		finish:     make(chan struct{}, 1),
+		health:     health.NewServer(),
 	}
+	h.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	h.health.SetServingStatus(constants.HealthServing, healthpb.HealthCheckResponse_NOT_SERVING)
+	h.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_UNKNOWN)
+	h.health.SetServingStatus(constants.HealthAgentConnectivity, healthpb.HealthCheckResponse_UNKNOWN)
 	return h
 }
 
@@ -81,18 +188,47 @@ func (s *Server) Start() error {
 		return fmt.Errorf("could not listen on port %d: %w", s.Port, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
+	err = s.Authorization.Validate()
+	if err != nil {
+		listener.Close()
+		return err
 	}
 
-	credentials, err := s.Credentials.LoadServerCredentials()
+	credentials, err := s.Credentials.LoadServerCredentials(utils.RoleHub)
 	if err != nil {
+		s.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_NOT_SERVING)
+		listener.Close()
 		return err
 	}
+	s.health.SetServingStatus(constants.HealthCredentials, healthpb.HealthCheckResponse_SERVING)
+	// Certificates are reloaded when their files change, or on demand through SIGHUP
+	defer utils.ReloadCredentialsOnSignal()()
+
+	var auditLog *utils.AuditLog
+	if s.LogDir != "" {
+		auditLog, err = utils.OpenAuditLog(filepath.Join(s.LogDir, constants.HubAuditFileName))
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer auditLog.Close()
+	}
+
+	var metrics *utils.Metrics
+	if s.MetricsPort != 0 {
+		metrics = s.newMetrics()
+		metricsServer, err := utils.ServeMetrics(s.MetricsPort, metrics)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer metricsServer.Close()
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor),
+		grpc.ChainUnaryInterceptor(utils.UnaryRequestIDInterceptor(), auditLog.UnaryInterceptor(), metrics.UnaryInterceptor(), utils.UnaryAuthorizationInterceptor(s.Authorization)),
+		grpc.ChainStreamInterceptor(utils.StreamRequestIDInterceptor(), auditLog.StreamInterceptor(), metrics.StreamInterceptor(), utils.StreamAuthorizationInterceptor(s.Authorization)),
 	)
 
 	s.mutex.Lock()
@@ -101,6 +237,7 @@ func (s *Server) Start() error {
 	s.mutex.Unlock()
 
 	idl.RegisterHubServer(grpcServer, s)
+	healthpb.RegisterHealthServer(grpcServer, s.health)
 	reflection.Register(grpcServer)
 
 	wg := sync.WaitGroup{}
@@ -108,12 +245,15 @@ func (s *Server) Start() error {
 	go func() {
 		<-s.finish
 		gplog.Info("Received stop command, attempting graceful shutdown")
+		s.health.Shutdown()
 		s.grpcServer.GracefulStop()
 		gplog.Info("gRPC server has shut down")
 		cancel()
 		wg.Done()
 	}()
 
+	s.health.SetServingStatus(constants.HealthServing, healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -122,11 +262,59 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// newMetrics returns the hub metrics, which include the state of the
+// connections to the agents as of the last time they were used.
+func (s *Server) newMetrics() *utils.Metrics {
+	metrics := utils.NewMetrics("hub")
+	metrics.AddGauge("agents_configured", "Number of configured agent hosts.", func() float64 {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		return float64(len(s.Hostnames))
+	})
+	metrics.AddGauge("agents_connected", "Number of agents the hub is connected to.", func() float64 {
+		connected, _ := s.agentConnectionCounts()
+		return float64(connected)
+	})
+	metrics.AddGauge("agents_unreachable", "Number of agents the hub could not reach.", func() float64 {
+		_, unreachable := s.agentConnectionCounts()
+		return float64(unreachable)
+	})
+
+	return metrics
+}
+
+func (s *Server) agentConnectionCounts() (connected int, unreachable int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, conn := range s.Conns {
+		if conn.Healthy() {
+			connected++
+		} else {
+			unreachable++
+		}
+	}
+
+	return connected, unreachable
+}
+
 func (s *Server) Stop(ctx context.Context, in *idl.StopHubRequest) (*idl.StopHubReply, error) {
 	s.Shutdown()
 	return &idl.StopHubReply{}, nil
 }
 
+// Status reports the certificate the hub currently presents to its clients.
+func (s *Server) Status(ctx context.Context, in *idl.StatusHubRequest) (*idl.StatusHubReply, error) {
+	cert, err := s.Credentials.Certificate(utils.RoleHub)
+	if err != nil {
+		return &idl.StatusHubReply{}, grpcStatus.Errorf(codes.Internal, "could not get hub certificate: %v", err)
+	}
+
+	hostname, _ := os.Hostname()
+	return &idl.StatusHubReply{Certificate: utils.NewCertificateInfo(hostname, cert)}, nil
+}
+
 func (s *Server) Shutdown() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -137,206 +325,555 @@ func (s *Server) Shutdown() {
 }
 
 func (s *Server) StartAgents(ctx context.Context, in *idl.StartAgentsRequest) (*idl.StartAgentsReply, error) {
-	err := s.StartAllAgents()
+	hosts, err := s.selectHosts(in.Hosts)
 	if err != nil {
 		return &idl.StartAgentsReply{}, err
 	}
 
+	results := s.startAgents(hosts)
+	for _, result := range results {
+		if !result.Success {
+			return &idl.StartAgentsReply{Results: results}, nil
+		}
+	}
+
 	// Make sure service has started :
 	err = s.DialAllAgents()
+	var unready *UnreadyHostsError
+	if errors.As(err, &unready) {
+		for _, result := range results {
+			if unready.Contains(result.Host) {
+				*result = *NewHostResult(result.Host, grpcStatus.Errorf(codes.Unavailable, "agent on host %s did not become ready", result.Host))
+			}
+		}
+		return &idl.StartAgentsReply{Results: results}, nil
+	}
 	if err != nil {
 		return &idl.StartAgentsReply{}, err
 	}
-	return &idl.StartAgentsReply{}, nil
+	return &idl.StartAgentsReply{Results: results}, nil
 }
 
-func (s *Server) StartAllAgents() error {
-	remoteCmd := make([]string, 0)
-	for _, host := range s.Hostnames {
-		remoteCmd = append(remoteCmd, "-h", host)
+func (s *Server) startAgents(hosts []string) []*idl.HostResult {
+	startCmd := strings.Join(platform.GetStartAgentCommandString(s.ServiceName), " ")
+
+	results := make([]*idl.HostResult, 0, len(hosts))
+	for _, r := range remoteExecutor.Run(context.Background(), hosts, startCmd) {
+		err := r.Failure()
+		if err != nil {
+			err = fmt.Errorf("could not start agent: %w", err)
+		}
+
+		result := NewHostResult(r.Host, err)
+		if r.Err != nil { // the host could not be reached at all, as opposed to the command failing
+			result.Code = int32(codes.Unavailable)
+		}
+		results = append(results, result)
 	}
-	remoteCmd = append(remoteCmd, platform.GetStartAgentCommandString(s.ServiceName)...)
-	greenplumPathSh := filepath.Join(s.GpHome, "greenplum_path.sh")
-	cmd := execCommand(constants.ShellPath, "-c", fmt.Sprintf("source %s && gpssh %s", greenplumPathSh, strings.Join(remoteCmd, " ")))
-	output, err := cmd.CombinedOutput()
-	strOutput := string(output)
+
+	return results
+}
+
+// DialAllAgents makes sure there is a connection to the agent on every host,
+// and waits for up to DialTimeout for all of them to be ready. Connections
+// are established in the background and re-established by gRPC with
+// exponential backoff when they are lost, so an agent restart does not need
+// the connection to be re-created. A connection is only replaced once it has
+// been closed or has been unhealthy for longer than EvictTimeout.
+//
+// If some agents are not ready in time an UnreadyHostsError is returned; their
+// connections are kept so that later calls can pick them up once they are.
+func (s *Server) DialAllAgents() error {
+	conns, err := s.dialAgents()
 	if err != nil {
-		return fmt.Errorf("could not start agents: %s", output)
+		return err
 	}
-	// As command is run through gpssh, even if actual command returns error, gpssh still returns as success.
-	// to overcome this we have added check the command output.
-	if strings.Contains(strOutput, "ERROR") || strings.Contains(strOutput, "No such file or directory") {
-		return fmt.Errorf("could not start agents: %s", strOutput)
+
+	// Waiting for the agents must not hold up the RPCs and metrics which only
+	// need the current connections, so it is done without holding s.mutex.
+	err = ensureConnectionsAreReadyFunc(conns)
+	checkAgentVersions(conns)
+	if err != nil {
+		s.health.SetServingStatus(constants.HealthAgentConnectivity, healthpb.HealthCheckResponse_NOT_SERVING)
+	} else {
+		s.health.SetServingStatus(constants.HealthAgentConnectivity, healthpb.HealthCheckResponse_SERVING)
 	}
 
-	return nil
+	return err
 }
 
-func (s *Server) DialAllAgents() error {
+// dialAgents replaces the agent connections by one to the agent on every
+// configured host, keeping the existing connections which are still usable.
+// It returns the new connections.
+func (s *Server) dialAgents() ([]*Connection, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	if s.Conns != nil {
-		err := ensureConnectionsAreReadyFunc(s.Conns)
-		if err != nil {
-			return err
-		}
-
-		return nil
+	connsByHost := make(map[string]*Connection, len(s.Conns))
+	for _, conn := range s.Conns {
+		connsByHost[conn.Hostname] = conn
 	}
 
+	conns := make([]*Connection, 0, len(s.Hostnames))
 	for _, host := range s.Hostnames {
-		ctx, cancelFunc := context.WithTimeout(context.Background(), DialTimeout)
+		conn, ok := connsByHost[host]
+		delete(connsByHost, host)
 
-		credentials, err := s.Credentials.LoadClientCredentials()
-		if err != nil {
-			cancelFunc()
-			return err
+		if ok && conn.shouldEvict() {
+			gplog.Debug("Evicting connection to agent on host %s", host)
+			conn.close()
+			ok = false
 		}
 
-		address := fmt.Sprintf("%s:%d", host, s.AgentPort)
-		opts := []grpc.DialOption{
-			grpc.WithBlock(),
-			grpc.WithTransportCredentials(credentials),
-			grpc.WithReturnConnectionError(),
-		}
-		if s.grpcDialer != nil {
-			opts = append(opts, grpc.WithContextDialer(s.grpcDialer))
-		}
-		conn, err := grpc.DialContext(ctx, address, opts...)
-		if err != nil {
-			cancelFunc()
-			return fmt.Errorf("could not connect to agent on host %s: %w", host, err)
+		if !ok {
+			var err error
+			conn, err = s.dialAgent(host)
+			if err != nil {
+				return nil, err
+			}
 		}
-		s.Conns = append(s.Conns, &Connection{
-			Conn:          conn,
-			AgentClient:   idl.NewAgentClient(conn),
-			Hostname:      host,
-			CancelContext: cancelFunc,
-		})
+
+		conns = append(conns, conn)
 	}
 
-	err := ensureConnectionsAreReadyFunc(s.Conns)
-	if err != nil {
-		return err
+	// Close the connections to any hosts which are no longer configured
+	for _, conn := range connsByHost {
+		conn.close()
 	}
+	s.Conns = conns
 
-	return nil
+	return conns, nil
 }
 
-func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*idl.StopAgentsReply, error) {
-	request := func(conn *Connection) error {
-		_, err := conn.AgentClient.Stop(context.Background(), &idl.StopAgentRequest{})
-		if err == nil { // no error -> didn't stop
-			return fmt.Errorf("failed to stop agent on host %s", conn.Hostname)
-		}
+func (s *Server) dialAgent(host string) (*Connection, error) {
+	credentials, err := s.Credentials.LoadClientCredentials(utils.RoleHub)
+	if err != nil {
+		return nil, err
+	}
+
+	address := fmt.Sprintf("%s:%d", host, s.AgentPort)
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials),
+		grpc.WithChainUnaryInterceptor(utils.UnaryClientRequestIDInterceptor("")),
+		grpc.WithChainStreamInterceptor(utils.StreamClientRequestIDInterceptor("")),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                KeepaliveTime,
+			Timeout:             KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  time.Second,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   MaxReconnectDelay,
+			},
+			MinConnectTimeout: DialTimeout,
+		}),
+	}
+	if s.grpcDialer != nil {
+		opts = append(opts, grpc.WithContextDialer(s.grpcDialer))
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		cancelFunc()
+		return nil, fmt.Errorf("could not connect to agent on host %s: %w", host, err)
+	}
 
-		errStatus := grpcStatus.Convert(err)
-		if errStatus.Code() != codes.Unavailable {
-			return fmt.Errorf("failed to stop agent on host %s: %w", conn.Hostname, err)
-		}
+	return &Connection{
+		Conn:          conn,
+		AgentClient:   idl.NewAgentClient(conn),
+		HealthClient:  healthpb.NewHealthClient(conn),
+		Hostname:      host,
+		CancelContext: cancelFunc,
+	}, nil
+}
+
+// connectToAgents is DialAllAgents for the RPCs which fan out to the agents.
+// These report agents which could not be reached in their per-host results
+// rather than failing outright.
+func (s *Server) connectToAgents(ctx context.Context) error {
+	err := s.DialAllAgents()
 
+	var unready *UnreadyHostsError
+	if errors.As(err, &unready) {
+		utils.LogWarn(ctx, "%s", err)
 		return nil
 	}
 
-	err := s.DialAllAgents()
+	return err
+}
+
+// agentConns returns a copy of the agent connections, as DialAllAgents and
+// StopAgents may replace them while an RPC is iterating over them.
+func (s *Server) agentConns() []*Connection {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*Connection(nil), s.Conns...)
+}
+
+// agentHostnames returns a copy of the configured agent hosts, as ReloadConfig
+// may replace them at any time.
+func (s *Server) agentHostnames() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.Hostnames...)
+}
+
+func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*idl.StopAgentsReply, error) {
+	err := s.connectToAgents(ctx)
 	if err != nil {
 		return &idl.StopAgentsReply{}, err
 	}
 
-	err = ExecuteRPC(s.Conns, request)
+	conns := s.agentConns()
+	results := executeRPCOnAnyVersion(ctx, conns, func(conn *Connection) error {
+		return stopAgent(ctx, conn)
+	})
+
+	s.mutex.Lock()
+	for _, conn := range s.Conns {
+		conn.close()
+	}
 	s.Conns = nil
+	s.mutex.Unlock()
+
+	return &idl.StopAgentsReply{Results: results}, nil
+}
+
+// stopAgent asks the agent behind conn to stop. The agent drops the connection
+// as it shuts down, so only an Unavailable error means it has stopped.
+func stopAgent(ctx context.Context, conn *Connection) error {
+	_, err := conn.AgentClient.Stop(utils.DetachedContext(ctx), &idl.StopAgentRequest{})
+	if err == nil { // no error -> didn't stop
+		return fmt.Errorf("failed to stop agent on host %s", conn.Hostname)
+	}
+
+	errStatus := grpcStatus.Convert(err)
+	if errStatus.Code() != codes.Unavailable {
+		return fmt.Errorf("failed to stop agent on host %s: %w", conn.Hostname, err)
+	}
 
-	return &idl.StopAgentsReply{}, err
+	return nil
 }
 
 func (s *Server) StatusAgents(ctx context.Context, in *idl.StatusAgentsRequest) (*idl.StatusAgentsReply, error) {
-	statusChan := make(chan *idl.ServiceStatus, len(s.Conns))
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.StatusAgentsReply{}, err
+	}
+
+	conns := s.agentConns()
+	statuses := make([]*idl.ServiceStatus, len(conns))
+	connIndex := make(map[*Connection]int, len(conns))
+	for i, conn := range conns {
+		connIndex[conn] = i
+		if !conn.Healthy() {
+			statuses[i] = &idl.ServiceStatus{Host: conn.Hostname, Status: "unreachable"}
+		}
+	}
 
 	request := func(conn *Connection) error {
-		status, err := conn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+		ctx, cancel := context.WithTimeout(utils.DetachedContext(ctx), constants.HealthCheckTimeout)
+		defer cancel()
+
+		// A wedged agent may still accept connections without answering
+		// any requests, so check its health before asking for its status.
+		healthReply, err := conn.HealthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err == nil && healthReply.Status != healthpb.HealthCheckResponse_SERVING {
+			err = fmt.Errorf("agent reports %s", healthReply.Status)
+		}
+		if err != nil {
+			statuses[connIndex[conn]] = &idl.ServiceStatus{Host: conn.Hostname, Status: "unhealthy"}
+			return grpcStatus.Errorf(codes.Unavailable, "agent on host %s is unhealthy: %v", conn.Hostname, err)
+		}
+
+		status, err := conn.AgentClient.Status(ctx, &idl.StatusAgentRequest{})
 		if err != nil {
-			return fmt.Errorf("failed to get agent status on host %s", conn.Hostname)
+			return fmt.Errorf("failed to get agent status on host %s: %w", conn.Hostname, err)
 		}
-		s := idl.ServiceStatus{
-			Host:   conn.Hostname,
-			Status: status.Status,
-			Uptime: status.Uptime,
-			Pid:    status.Pid,
+		statuses[connIndex[conn]] = &idl.ServiceStatus{
+			Host:        conn.Hostname,
+			Status:      status.Status,
+			Uptime:      status.Uptime,
+			Pid:         status.Pid,
+			Certificate: status.Certificate,
 		}
-		statusChan <- &s
 
 		return nil
 	}
 
-	err := s.DialAllAgents()
+	results := executeRPCOnAnyVersion(ctx, conns, request)
+
+	reply := &idl.StatusAgentsReply{Statuses: []*idl.ServiceStatus{}, Results: results}
+	for _, status := range statuses {
+		if status != nil {
+			reply.Statuses = append(reply.Statuses, status)
+		}
+	}
+
+	return reply, nil
+}
+
+// HostInfo gathers the state of the requested agent hosts, in the order the
+// hosts were requested.
+func (s *Server) HostInfo(ctx context.Context, in *idl.HostInfoRequest) (*idl.HostInfoReply, error) {
+	err := s.connectToAgents(ctx)
 	if err != nil {
-		return &idl.StatusAgentsReply{}, err
+		return &idl.HostInfoReply{}, err
 	}
-	err = ExecuteRPC(s.Conns, request)
+
+	conns, err := s.selectConns(in.Hosts)
 	if err != nil {
-		return &idl.StatusAgentsReply{}, err
+		return &idl.HostInfoReply{}, err
 	}
-	close(statusChan)
 
-	statuses := make([]*idl.ServiceStatus, 0)
-	for status := range statusChan {
-		statuses = append(statuses, status)
+	infos := make([]*idl.HostInfo, len(conns))
+	connIndex := make(map[*Connection]int, len(conns))
+	for i, conn := range conns {
+		connIndex[conn] = i
 	}
 
-	return &idl.StatusAgentsReply{Statuses: statuses}, err
+	request := func(conn *Connection) error {
+		reply, err := conn.AgentClient.HostInfo(ctx, &idl.HostInfoAgentRequest{DataDirs: in.DataDirs})
+		if err != nil {
+			return fmt.Errorf("could not get host info of host %s: %w", conn.Hostname, err)
+		}
+		if reply.Info == nil {
+			return fmt.Errorf("could not get host info of host %s: agent sent no host info", conn.Hostname)
+		}
+
+		// Report the host under the name it is configured with
+		reply.Info.Host = conn.Hostname
+		infos[connIndex[conn]] = reply.Info
+
+		return nil
+	}
+
+	results := ExecuteRPC(ctx, conns, request)
+
+	reply := &idl.HostInfoReply{Hosts: []*idl.HostInfo{}, Results: results}
+	for _, info := range infos {
+		if info != nil {
+			reply.Hosts = append(reply.Hosts, info)
+		}
+	}
+
+	return reply, nil
 }
 
+// ensureConnectionsAreReady waits for up to DialTimeout for the connections to
+// become ready and records the health of each of them.
 func ensureConnectionsAreReady(conns []*Connection) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		conn := conn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn.SetHealthy(waitUntilReady(ctx, conn.Conn))
+		}()
+	}
+	wg.Wait()
+
 	hostnames := []string{}
 	for _, conn := range conns {
-		if conn.Conn.GetState() != connectivity.Ready {
+		if !conn.Healthy() {
 			hostnames = append(hostnames, conn.Hostname)
 		}
 	}
 
 	if len(hostnames) > 0 {
-		return fmt.Errorf("could not ensure connections were ready: unready hosts: %s", strings.Join(hostnames, ","))
+		return &UnreadyHostsError{Hostnames: hostnames}
 	}
 
 	return nil
 }
 
-func ExecuteRPC(agentConns []*Connection, executeRequest func(conn *Connection) error) error {
+func waitUntilReady(ctx context.Context, conn *grpc.ClientConn) bool {
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return true
+		case connectivity.Shutdown:
+			return false
+		case connectivity.Idle:
+			conn.Connect()
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// UnreadyHostsError is returned when the connections to the agents on some of
+// the hosts could not be made ready.
+type UnreadyHostsError struct {
+	Hostnames []string
+}
+
+func (e *UnreadyHostsError) Error() string {
+	return fmt.Sprintf("could not ensure connections were ready: unready hosts: %s", strings.Join(e.Hostnames, ","))
+}
+
+func (e *UnreadyHostsError) Contains(host string) bool {
+	for _, hostname := range e.Hostnames {
+		if hostname == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExecuteRPC runs executeRequest against every agent in parallel and returns
+// the outcome on each host, in the same order as agentConns. Agents which are
+// known to be unreachable are reported as such without being contacted.
+// Failures are logged under the request ID carried by ctx.
+func ExecuteRPC(ctx context.Context, agentConns []*Connection, executeRequest func(conn *Connection) error) []*idl.HostResult {
+	return executeRPC(ctx, agentConns, executeRequest, true)
+}
+
+// executeRPCOnAnyVersion is ExecuteRPC for the requests which every version of
+// the agent understands, such as those needed to stop or inspect the agents
+// left behind by a partial upgrade.
+func executeRPCOnAnyVersion(ctx context.Context, agentConns []*Connection, executeRequest func(conn *Connection) error) []*idl.HostResult {
+	return executeRPC(ctx, agentConns, executeRequest, false)
+}
+
+func executeRPC(ctx context.Context, agentConns []*Connection, executeRequest func(conn *Connection) error, requireCompatible bool) []*idl.HostResult {
 	var wg sync.WaitGroup
-	errs := make(chan error, len(agentConns))
+	results := make([]*idl.HostResult, len(agentConns))
 
-	for _, conn := range agentConns {
-		conn := conn
+	for i, conn := range agentConns {
+		if !conn.Healthy() {
+			results[i] = NewHostResult(conn.Hostname, grpcStatus.Errorf(codes.Unavailable, "agent on host %s is unreachable", conn.Hostname))
+			continue
+		}
+		if err := conn.Incompatible(); err != nil && requireCompatible {
+			results[i] = NewHostResult(conn.Hostname, grpcStatus.Errorf(codes.FailedPrecondition, "%v", err))
+			continue
+		}
+
+		i, conn := i, conn
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			err := executeRequest(conn)
-			errs <- err
+			if err != nil {
+				utils.LogDebug(ctx, "Request to agent on host %s failed: %v", conn.Hostname, err)
+			}
+			results[i] = NewHostResult(conn.Hostname, err)
 		}()
 	}
 
 	wg.Wait()
-	close(errs)
 
-	var err error
-	for e := range errs {
-		err = e
-		break
+	return results
+}
+
+// NewHostResult converts the outcome of an operation on a single host into its
+// reply representation, preserving the gRPC status code of err if it has one.
+func NewHostResult(host string, err error) *idl.HostResult {
+	if err == nil {
+		return &idl.HostResult{Host: host, Success: true, Code: int32(codes.OK)}
 	}
 
-	return err
+	status := grpcStatus.Convert(err)
+	return &idl.HostResult{Host: host, Code: int32(status.Code()), Message: status.Message()}
+}
+
+// ReloadConfig reads the configuration file of the hub again and picks up the
+// hosts added to or removed from it, connecting to the agents on the added
+// hosts and disconnecting from those on the removed ones. Any other changes
+// only take effect when the hub is restarted.
+func (s *Server) ReloadConfig(ctx context.Context, in *idl.ReloadConfigRequest) (*idl.ReloadConfigReply, error) {
+	if s.ConfigFilePath == "" {
+		return &idl.ReloadConfigReply{}, grpcStatus.Error(codes.FailedPrecondition, "the hub was not started from a configuration file")
+	}
+
+	conf := &Config{}
+	err := conf.Load(s.ConfigFilePath)
+	if err != nil {
+		return &idl.ReloadConfigReply{}, grpcStatus.Errorf(codes.FailedPrecondition, "could not reload configuration: %v", err)
+	}
+	if len(conf.Hostnames) == 0 {
+		return &idl.ReloadConfigReply{}, grpcStatus.Errorf(codes.FailedPrecondition, "could not reload configuration: no hosts in %s", s.ConfigFilePath)
+	}
+
+	s.mutex.Lock()
+	added, removed := diffHosts(s.Hostnames, conf.Hostnames)
+	s.Hostnames = conf.Hostnames
+	s.mutex.Unlock()
+
+	if len(added) > 0 || len(removed) > 0 {
+		utils.LogInfo(ctx, "Reloaded configuration: added hosts %v, removed hosts %v", added, removed)
+	}
+
+	err = s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.ReloadConfigReply{}, err
+	}
+
+	return &idl.ReloadConfigReply{AddedHosts: added, RemovedHosts: removed}, nil
+}
+
+// diffHosts returns the hosts in next but not in current, and those in current
+// but not in next.
+func diffHosts(current []string, next []string) ([]string, []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, host := range current {
+		inCurrent[host] = true
+	}
+	inNext := make(map[string]bool, len(next))
+	for _, host := range next {
+		inNext[host] = true
+	}
+
+	added, removed := []string{}, []string{}
+	for _, host := range next {
+		if !inCurrent[host] {
+			added = append(added, host)
+		}
+	}
+	for _, host := range current {
+		if !inNext[host] {
+			removed = append(removed, host)
+		}
+	}
+
+	return added, removed
 }
 
 func (conf *Config) Load(ConfigFilePath string) error {
 	//Loads config from the configFilePath
-	conf.Credentials = &utils.GpCredentials{}
 	contents, err := os.ReadFile(ConfigFilePath)
 	if err != nil {
 		return fmt.Errorf("could not open config file: %w", err)
 	}
 
-	err = json.Unmarshal(contents, &conf)
+	return conf.Parse(contents)
+}
+
+// Parse sets conf from the contents of a configuration file, migrating them
+// to the current schema first.
+func (conf *Config) Parse(contents []byte) error {
+	conf.Credentials = &utils.GpCredentials{}
+	contents, err := migrateConfig(contents)
+	if err != nil {
+		return fmt.Errorf("could not parse config file: %w", err)
+	}
+
+	err = decodeConfig(contents, conf)
 	if err != nil {
 		return fmt.Errorf("could not parse config file: %w", err)
 	}
@@ -344,49 +881,33 @@ func (conf *Config) Load(ConfigFilePath string) error {
 	return nil
 }
 
+// Write writes conf to the configuration file on this host, keeping the file
+// it replaces in the history. Copying the file to the agent hosts is left to
+// the caller, which knows whether the agents are running.
 func (conf *Config) Write(ConfigFilePath string) error {
-	// Updates config to the conf file
-	configHandle, err := os.OpenFile(ConfigFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		return fmt.Errorf("could not create configuration file %s: %w\n", ConfigFilePath, err)
-	}
-	defer configHandle.Close()
+	conf.ConfigVersion = ConfigVersion
 	configContents, err := json.MarshalIndent(conf, "", "\t")
 	if err != nil {
 		return fmt.Errorf("could not parse configuration file %s: %w\n", ConfigFilePath, err)
 	}
 
-	_, err = configHandle.Write(configContents)
+	_, err = os.Stat(filepath.Dir(ConfigFilePath))
 	if err != nil {
-		return fmt.Errorf("could not write to configuration file %s: %w\n", ConfigFilePath, err)
+		return fmt.Errorf("could not create configuration file %s: %w\n", ConfigFilePath, err)
 	}
-	gplog.Debug("Wrote configuration file to %s", ConfigFilePath)
 
-	err = copyConfigFileToAgents(conf, ConfigFilePath)
+	err = saveConfigHistory(ConfigFilePath, configContents)
 	if err != nil {
 		return err
 	}
 
-	return err
-}
-
-func copyConfigFileToAgents(conf *Config, ConfigFilePath string) error {
-	hostList := make([]string, 0)
-
-	for _, host := range conf.Hostnames {
-		hostList = append(hostList, "-h", host)
-	}
-	greenplumPathSh := filepath.Join(conf.GpHome, "greenplum_path.sh")
-	if len(hostList) < 1 {
-		return fmt.Errorf("hostlist should not be empty. No hosts to copy files.")
-	}
-
-	remoteCmd := append(hostList, ConfigFilePath, fmt.Sprintf("=:%s", ConfigFilePath))
-	cmd := execCommand(constants.ShellPath, "-c", fmt.Sprintf("source %s && gpsync %s", greenplumPathSh, strings.Join(remoteCmd, " ")))
-	output, err := cmd.CombinedOutput()
+	// The file is replaced rather than rewritten in place, so that an
+	// interrupted write cannot leave it empty or truncated
+	err = utils.WriteFileAtomically(ConfigFilePath, configContents, 0644)
 	if err != nil {
-		return fmt.Errorf("could not copy gp.conf file to segment hosts: %w, Command Output: %s", err, string(output))
+		return fmt.Errorf("could not write to configuration file %s: %w\n", ConfigFilePath, err)
 	}
+	gplog.Debug("Wrote configuration file to %s", ConfigFilePath)
 
 	return nil
 }
@@ -400,10 +921,10 @@ func ResetEnsureConnectionsAreReady() {
 	ensureConnectionsAreReadyFunc = ensureConnectionsAreReady
 }
 
-func SetExecCommand(command exectest.Command) {
-	execCommand = command
+func SetExecutor(e executor.Executor) {
+	remoteExecutor = e
 }
 
-func ResetExecCommand() {
-	execCommand = exec.Command
+func ResetExecutor() {
+	remoteExecutor = executor.NewSSHExecutor()
 }
@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,6 +24,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -28,19 +35,9 @@ import (
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
 	"github.com/greenplum-db/gpdb/gp/testutils"
-	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
 	"github.com/greenplum-db/gpdb/gp/utils"
 )
 
-func init() {
-	exectest.RegisterMains()
-}
-
-// Enable exectest.NewCommand mocking.
-func TestMain(m *testing.M) {
-	os.Exit(exectest.Run(m))
-}
-
 func TestStartServer(t *testing.T) {
 
 	testhelper.SetupTestLogger()
@@ -52,13 +49,13 @@ func TestStartServer(t *testing.T) {
 		credentials := &testutils.MockCredentials{}
 
 		hubConfig := &hub.Config{
-			1234,
-			8080,
-			[]string{host},
-			"/tmp/logDir",
-			"gp",
-			gpHome,
-			credentials,
+			Port:        1234,
+			AgentPort:   8080,
+			Hostnames:   []string{host},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      gpHome,
+			Credentials: credentials,
 		}
 
 		hubServer := hub.New(hubConfig, nil)
@@ -81,6 +78,52 @@ func TestStartServer(t *testing.T) {
 
 	})
 
+	t.Run("serves metrics when a metrics port is configured", func(t *testing.T) {
+		hubConfig := &hub.Config{
+			Port:        1236,
+			AgentPort:   8080,
+			MetricsPort: 1237,
+			Hostnames:   []string{host},
+			LogDir:      t.TempDir(),
+			ServiceName: "gp",
+			GpHome:      gpHome,
+			Credentials: &testutils.MockCredentials{},
+		}
+		hubServer := hub.New(hubConfig, nil)
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- hubServer.Start()
+		}()
+		defer hubServer.Shutdown()
+
+		var body []byte
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get("http://localhost:1237/metrics")
+			if err == nil {
+				body, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+				break
+			}
+
+			select {
+			case err := <-errChan:
+				t.Fatalf("unexpected error: %#v", err)
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+
+		expected := []string{"gp_hub_agents_configured 1", "gp_hub_agents_connected 0", "gp_hub_agents_unreachable 0"}
+		for _, line := range expected {
+			if !strings.Contains(string(body), line+"\n") {
+				t.Fatalf("got %s, want it to contain %q", body, line)
+			}
+		}
+	})
+
 	t.Run("failed to start if the load credential fail", func(t *testing.T) {
 		expected := errors.New("error")
 		credentials := &testutils.MockCredentials{
@@ -88,13 +131,13 @@ func TestStartServer(t *testing.T) {
 		}
 
 		hubConfig := &hub.Config{
-			1235,
-			8080,
-			[]string{host},
-			"/tmp/logDir",
-			"gp",
-			gpHome,
-			credentials,
+			Port:        1235,
+			AgentPort:   8080,
+			Hostnames:   []string{host},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      gpHome,
+			Credentials: credentials,
 		}
 		hubServer := hub.New(hubConfig, nil)
 
@@ -112,6 +155,13 @@ func TestStartServer(t *testing.T) {
 		case <-time.After(1 * time.Second):
 			t.Fatalf("Failed to raise error if load credential fail")
 		}
+
+		// The port is free again for the next attempt to start the hub
+		listener, err := net.Listen("tcp", "0.0.0.0:1235")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		listener.Close()
 	})
 }
 
@@ -132,13 +182,13 @@ func TestStartAgents(t *testing.T) {
 	}()
 
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gphome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
 	}
 
 	t.Run("successfully starts the agents from hub", func(t *testing.T) {
@@ -148,14 +198,90 @@ func TestStartAgents(t *testing.T) {
 
 		hubServer := hub.New(hubConfig, dialer)
 
-		hub.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer hub.ResetExecCommand()
+		hub.SetExecutor(&testutils.MockExecutor{})
+		defer hub.ResetExecutor()
 
 		_, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{})
 		if err != nil {
 			t.Fatalf("%v", err)
 		}
 	})
+
+	t.Run("errors out with the failing hosts when the agents could not be started", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, nil)
+
+		hub.SetExecutor(&testutils.MockExecutor{ExitCode: 5, Stderr: "Unit gp_agent.service not found."})
+		defer hub.ResetExecutor()
+
+		result, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.StartAgentsReply{
+			Results: []*idl.HostResult{
+				{Host: "sdw1", Code: int32(codes.Unknown), Message: "could not start agent: host sdw1: exit status 5: Unit gp_agent.service not found."},
+				{Host: "sdw2", Code: int32(codes.Unknown), Message: "could not start agent: host sdw2: exit status 5: Unit gp_agent.service not found."},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("starts the agents on the given hosts only", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, nil)
+
+		executor := &testutils.MockExecutor{ExitCode: 5}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		result, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{Hosts: []string{"sdw2"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !reflect.DeepEqual(executor.Hosts, [][]string{{"sdw2"}}) {
+			t.Fatalf("got %v, want %v", executor.Hosts, [][]string{{"sdw2"}})
+		}
+		if len(result.Results) != 1 || result.Results[0].Host != "sdw2" {
+			t.Fatalf("got %+v, want a result for host sdw2 only", result.Results)
+		}
+	})
+
+	t.Run("errors out when a host is not part of the cluster", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, nil)
+
+		executor := &testutils.MockExecutor{}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		_, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{Hosts: []string{"sdw3"}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", err, codes.InvalidArgument)
+		}
+		if len(executor.Hosts) != 0 {
+			t.Fatalf("got %v, want no agents to be started", executor.Hosts)
+		}
+	})
+
+	t.Run("reports unreachable hosts as unavailable", func(t *testing.T) {
+		hubServer := hub.New(hubConfig, nil)
+
+		hub.SetExecutor(&testutils.MockExecutor{Err: errors.New("connection refused")})
+		defer hub.ResetExecutor()
+
+		result, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for _, hostResult := range result.Results {
+			if hostResult.Success || codes.Code(hostResult.Code) != codes.Unavailable {
+				t.Fatalf("got %+v, want an unavailable failure", hostResult)
+			}
+		}
+	})
 }
 
 func TestDialAllAgents(t *testing.T) {
@@ -175,16 +301,16 @@ func TestDialAllAgents(t *testing.T) {
 	}()
 
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gphome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
 	}
 
-	t.Run("successfully establishes connections to agent hosts and errors out when some of the connections are not ready", func(t *testing.T) {
+	t.Run("successfully establishes connections to agent hosts and re-establishes them once closed", func(t *testing.T) {
 
 		dialer := func(ctx context.Context, address string) (net.Conn, error) {
 			return listener.Dial()
@@ -215,22 +341,38 @@ func TestDialAllAgents(t *testing.T) {
 		}
 
 		// close one of the connections
-		for _, conn := range hubServer.Conns {
-			if conn.Hostname == "sdw2" {
-				conn.Conn.Close()
-			}
-		}
+		closed := hubServer.Conns[1]
+		closed.Conn.Close()
 
 		err = hubServer.DialAllAgents()
-		expectedErr := "could not ensure connections were ready: unready hosts: sdw2"
-		if err.Error() != expectedErr {
-			t.Fatalf("got %v, want %v", err.Error(), expectedErr)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if hubServer.Conns[0].Hostname != "sdw1" || hubServer.Conns[1].Hostname != "sdw2" {
+			t.Fatalf("got hosts %s and %s, want sdw1 and sdw2", hubServer.Conns[0].Hostname, hubServer.Conns[1].Hostname)
+		}
+		if hubServer.Conns[1] == closed {
+			t.Fatalf("expected the closed connection to be replaced")
+		}
+		for _, conn := range hubServer.Conns {
+			if conn.Conn.GetState() != expectedState || !conn.Healthy() {
+				t.Fatalf("unexpected connection state: got %v, want %v", conn.Conn.GetState(), expectedState)
+			}
 		}
 	})
 
-	t.Run("errors out when connection to some of the agent hosts fail", func(t *testing.T) {
+	t.Run("reports the hosts whose agents could not be reached and reconnects once they are back", func(t *testing.T) {
+		hub.DialTimeout = 500 * time.Millisecond
+		defer func() { hub.DialTimeout = 3 * time.Second }()
+
+		var mutex sync.Mutex
+		sdw2Down := true
 		dialer := func(ctx context.Context, address string) (net.Conn, error) {
-			if strings.HasPrefix(address, "sdw2") {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if strings.HasPrefix(address, "sdw2") && sdw2Down {
 				return nil, errors.New("error")
 			}
 
@@ -239,9 +381,113 @@ func TestDialAllAgents(t *testing.T) {
 
 		hubServer := hub.New(hubConfig, dialer)
 		err := hubServer.DialAllAgents()
-		expectedErr := "could not connect to agent on host sdw2:"
-		if !strings.HasPrefix(err.Error(), expectedErr) {
-			t.Fatalf("got %s, want %s", err.Error(), expectedErr)
+		expectedErr := "could not ensure connections were ready: unready hosts: sdw2"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		var unready *hub.UnreadyHostsError
+		if !errors.As(err, &unready) || !unready.Contains("sdw2") || unready.Contains("sdw1") {
+			t.Fatalf("got %#v, want only sdw2 to be unready", err)
+		}
+
+		if !hubServer.Conns[0].Healthy() || hubServer.Conns[1].Healthy() {
+			t.Fatalf("expected only the connection to sdw2 to be unhealthy")
+		}
+
+		mutex.Lock()
+		sdw2Down = false
+		mutex.Unlock()
+
+		// The connection is retried with backoff, so give it a few attempts
+		hub.DialTimeout = 5 * time.Second
+		err = hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !hubServer.Conns[1].Healthy() {
+			t.Fatalf("expected the connection to sdw2 to have recovered")
+		}
+	})
+
+	t.Run("evicts connections which have been unhealthy for too long", func(t *testing.T) {
+		hub.EvictTimeout = 0
+		defer func() { hub.EvictTimeout = 5 * time.Minute }()
+
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}
+
+		hubServer := hub.New(hubConfig, dialer)
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		stale := hubServer.Conns[0]
+		stale.SetHealthy(false)
+		time.Sleep(time.Millisecond)
+
+		err = hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if hubServer.Conns[0] == stale {
+			t.Fatalf("expected the unhealthy connection to be replaced")
+		}
+		if stale.Conn.GetState() != connectivity.Shutdown {
+			t.Fatalf("got %v, want the evicted connection to be closed", stale.Conn.GetState())
+		}
+	})
+
+	t.Run("does not hold up other requests while waiting for the agents", func(t *testing.T) {
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}
+		hubServer := hub.New(hubConfig, dialer)
+		defer func() {
+			for _, conn := range hubServer.Conns {
+				conn.Conn.Close()
+			}
+		}()
+
+		// Only the first dial waits for the agents until it is released
+		waiting, release := make(chan struct{}), make(chan struct{})
+		var calls int32
+		hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(waiting)
+				<-release
+			}
+			return nil
+		})
+		defer hub.ResetEnsureConnectionsAreReady()
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- hubServer.DialAllAgents()
+		}()
+		<-waiting
+
+		done := make(chan error, 1)
+		go func() {
+			done <- hubServer.DialAllAgents()
+		}()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the agents to be dialed while another dial is waiting for them")
+		}
+
+		close(release)
+		err := <-errs
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
 		}
 	})
 }
@@ -251,13 +497,13 @@ func TestStatusAgents(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gphome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -266,19 +512,23 @@ func TestStatusAgents(t *testing.T) {
 	})
 	defer hub.ResetEnsureConnectionsAreReady()
 
+	healthy := &testutils.MockHealthClient{Status: healthpb.HealthCheckResponse_SERVING}
+
 	t.Run("gets the status from the agent hosts", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
+		certificate := &idl.CertificateInfo{Host: "sdw1", Subject: "sdw1", Issuer: "cluster CA", Sans: []string{"sdw1"}}
 		sdw1 := mock_idl.NewMockAgentClient(ctrl)
 		sdw1.EXPECT().Status(
 			gomock.Any(),
 			&idl.StatusAgentRequest{},
 			gomock.Any(),
 		).Return(&idl.StatusAgentReply{
-			Status: "running",
-			Uptime: "5H",
-			Pid:    123,
+			Status:      "running",
+			Uptime:      "5H",
+			Pid:         123,
+			Certificate: certificate,
 		}, nil)
 
 		sdw2 := mock_idl.NewMockAgentClient(ctrl)
@@ -293,8 +543,8 @@ func TestStatusAgents(t *testing.T) {
 		}, nil)
 
 		agentConns := []*hub.Connection{
-			{AgentClient: sdw1, Hostname: "sdw1"},
-			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw1, HealthClient: healthy, Hostname: "sdw1"},
+			{AgentClient: sdw2, HealthClient: healthy, Hostname: "sdw2"},
 		}
 		hubServer.Conns = agentConns
 
@@ -305,8 +555,92 @@ func TestStatusAgents(t *testing.T) {
 
 		expected := &idl.StatusAgentsReply{
 			Statuses: []*idl.ServiceStatus{
+				{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123, Certificate: certificate},
 				{Host: "sdw2", Status: "running", Uptime: "2H", Pid: 456},
+			},
+			Results: []*idl.HostResult{
+				{Host: "sdw1", Success: true},
+				{Host: "sdw2", Success: true},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("reports the hosts whose agents are unreachable without contacting them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().Status(
+			gomock.Any(),
+			&idl.StatusAgentRequest{},
+			gomock.Any(),
+		).Return(&idl.StatusAgentReply{
+			Status: "running",
+			Uptime: "5H",
+			Pid:    123,
+		}, nil)
+
+		unreachable := &hub.Connection{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw2"}
+		unreachable.SetHealthy(false)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, HealthClient: healthy, Hostname: "sdw1"},
+			unreachable,
+		}
+
+		result, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.StatusAgentsReply{
+			Statuses: []*idl.ServiceStatus{
 				{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+				{Host: "sdw2", Status: "unreachable"},
+			},
+			Results: []*idl.HostResult{
+				{Host: "sdw1", Success: true},
+				{Host: "sdw2", Code: int32(codes.Unavailable), Message: "agent on host sdw2 is unreachable"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("reports agents which do not report themselves as serving as unhealthy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer.Conns = []*hub.Connection{
+			{
+				AgentClient:  mock_idl.NewMockAgentClient(ctrl),
+				HealthClient: &testutils.MockHealthClient{Status: healthpb.HealthCheckResponse_NOT_SERVING},
+				Hostname:     "sdw1",
+			},
+			{
+				AgentClient:  mock_idl.NewMockAgentClient(ctrl),
+				HealthClient: &testutils.MockHealthClient{Err: status.Error(codes.DeadlineExceeded, "context deadline exceeded")},
+				Hostname:     "sdw2",
+			},
+		}
+
+		result, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.StatusAgentsReply{
+			Statuses: []*idl.ServiceStatus{
+				{Host: "sdw1", Status: "unhealthy"},
+				{Host: "sdw2", Status: "unhealthy"},
+			},
+			Results: []*idl.HostResult{
+				{Host: "sdw1", Code: int32(codes.Unavailable), Message: "agent on host sdw1 is unhealthy: agent reports NOT_SERVING"},
+				{Host: "sdw2", Code: int32(codes.Unavailable), Message: "agent on host sdw2 is unhealthy: rpc error: code = DeadlineExceeded desc = context deadline exceeded"},
 			},
 		}
 		if !reflect.DeepEqual(result, expected) {
@@ -314,7 +648,7 @@ func TestStatusAgents(t *testing.T) {
 		}
 	})
 
-	t.Run("errors out when not able to get the status from one of the hosts", func(t *testing.T) {
+	t.Run("reports the hosts it was not able to get the status from", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -338,18 +672,91 @@ func TestStatusAgents(t *testing.T) {
 			Status: "running",
 			Uptime: "2H",
 			Pid:    456,
-		}, errors.New("error"))
+		}, status.Error(codes.DeadlineExceeded, "timed out"))
 
 		agentConns := []*hub.Connection{
+			{AgentClient: sdw1, HealthClient: healthy, Hostname: "sdw1"},
+			{AgentClient: sdw2, HealthClient: healthy, Hostname: "sdw2"},
+		}
+		hubServer.Conns = agentConns
+
+		result, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.StatusAgentsReply{
+			Statuses: []*idl.ServiceStatus{
+				{Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+			},
+			Results: []*idl.HostResult{
+				{Host: "sdw1", Success: true},
+				{Host: "sdw2", Code: int32(codes.DeadlineExceeded), Message: "failed to get agent status on host sdw2: rpc error: code = DeadlineExceeded desc = timed out"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+}
+
+func TestHostInfo(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
+	hubConfig := &hub.Config{
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
+	}
+	hubServer := hub.New(hubConfig, nil)
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	t.Run("gets the state of the requested hosts under their configured names", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		request := &idl.HostInfoAgentRequest{DataDirs: []string{"/data/*"}}
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().HostInfo(gomock.Any(), request, gomock.Any()).Return(&idl.HostInfoAgentReply{
+			Info: &idl.HostInfo{Host: "sdw1.example.com", Cpus: 8, GpVersion: "7.1.0"},
+		}, nil)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().HostInfo(gomock.Any(), request, gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
 			{AgentClient: sdw1, Hostname: "sdw1"},
 			{AgentClient: sdw2, Hostname: "sdw2"},
 		}
-		hubServer.Conns = agentConns
 
-		_, err := hubServer.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
-		expectedErr := "failed to get agent status on host sdw2"
-		if err.Error() != expectedErr {
-			t.Fatalf("got %v, want %v", err, expectedErr)
+		reply, err := hubServer.HostInfo(context.Background(), &idl.HostInfoRequest{DataDirs: []string{"/data/*"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.HostInfo{{Host: "sdw1", Cpus: 8, GpVersion: "7.1.0"}}
+		if !reflect.DeepEqual(reply.Hosts, expected) {
+			t.Fatalf("got %+v, want %+v", reply.Hosts, expected)
+		}
+		if len(reply.Results) != 2 || !reply.Results[0].Success || reply.Results[1].Success {
+			t.Fatalf("got %+v, want sdw1 to succeed and sdw2 to fail", reply.Results)
+		}
+	})
+
+	t.Run("errors out when a host is not part of the cluster", func(t *testing.T) {
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}}
+
+		_, err := hubServer.HostInfo(context.Background(), &idl.HostInfoRequest{Hosts: []string{"sdw3"}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", status.Code(err), codes.InvalidArgument)
 		}
 	})
 }
@@ -359,13 +766,13 @@ func TestStopAgents(t *testing.T) {
 
 	credentials := &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()}
 	hubConfig := &hub.Config{
-		constants.DefaultHubPort,
-		constants.DefaultAgentPort,
-		[]string{"sdw1", "sdw2"},
-		"/tmp/logDir",
-		"gp",
-		"gphome",
-		credentials,
+		Port:        constants.DefaultHubPort,
+		AgentPort:   constants.DefaultAgentPort,
+		Hostnames:   []string{"sdw1", "sdw2"},
+		LogDir:      "/tmp/logDir",
+		ServiceName: "gp",
+		GpHome:      "gphome",
+		Credentials: credentials,
 	}
 	hubServer := hub.New(hubConfig, nil)
 
@@ -398,13 +805,48 @@ func TestStopAgents(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		_, err := hubServer.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+		result, err := hubServer.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.HostResult{
+			{Host: "sdw1", Success: true},
+			{Host: "sdw2", Success: true},
+		}
+		if !reflect.DeepEqual(result.Results, expected) {
+			t.Fatalf("got %+v, want %+v", result.Results, expected)
+		}
+	})
+
+	t.Run("passes the request ID on to the agents", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var requestID string
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().Stop(
+			gomock.Any(),
+			&idl.StopAgentRequest{},
+			gomock.Any(),
+		).DoAndReturn(func(ctx context.Context, in *idl.StopAgentRequest, opts ...grpc.CallOption) (*idl.StopAgentReply, error) {
+			requestID = utils.RequestIDFromContext(ctx)
+			return &idl.StopAgentReply{}, status.Errorf(codes.Unavailable, "")
+		})
+		hubServer.Conns = []*hub.Connection{{AgentClient: sdw1, Hostname: "sdw1"}}
+
+		ctx := utils.ContextWithRequestID(context.Background(), "0123456789abcdef")
+		_, err := hubServer.StopAgents(ctx, &idl.StopAgentsRequest{})
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		if requestID != "0123456789abcdef" {
+			t.Fatalf("got %q, want %q", requestID, "0123456789abcdef")
+		}
 	})
 
-	t.Run("errors out when not able to stop the agents", func(t *testing.T) {
+	t.Run("reports the hosts on which the agents could not be stopped", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
@@ -428,10 +870,115 @@ func TestStopAgents(t *testing.T) {
 		}
 		hubServer.Conns = agentConns
 
-		_, err := hubServer.StopAgents(context.Background(), &idl.StopAgentsRequest{})
-		expectedErr := "failed to stop agent on host sdw2: error"
-		if err.Error() != expectedErr {
-			t.Fatalf("got %v, want %v", err, expectedErr)
+		result, err := hubServer.StopAgents(context.Background(), &idl.StopAgentsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []*idl.HostResult{
+			{Host: "sdw1", Success: true},
+			{Host: "sdw2", Code: int32(codes.Unknown), Message: "failed to stop agent on host sdw2: error"},
+		}
+		if !reflect.DeepEqual(result.Results, expected) {
+			t.Fatalf("got %+v, want %+v", result.Results, expected)
+		}
+	})
+}
+
+func TestReloadConfig(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	// The agents on added hosts are not running yet
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	newServer := func(t *testing.T, hostnames ...string) *hub.Server {
+		t.Helper()
+
+		hubConfig := &hub.Config{
+			Port:        constants.DefaultHubPort,
+			AgentPort:   constants.DefaultAgentPort,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      "gphome",
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}
+		hubServer := hub.New(hubConfig, dialer)
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}, {Hostname: "sdw2"}}
+
+		contents, err := json.Marshal(&hub.Config{Port: hubConfig.Port, Hostnames: hostnames})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		hubServer.ConfigFilePath = filepath.Join(t.TempDir(), "gp.conf")
+		err = os.WriteFile(hubServer.ConfigFilePath, contents, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return hubServer
+	}
+	connectedHosts := func(hubServer *hub.Server) []string {
+		hosts := []string{}
+		for _, conn := range hubServer.Conns {
+			hosts = append(hosts, conn.Hostname)
+		}
+		return hosts
+	}
+
+	t.Run("connects to added hosts and disconnects from removed ones", func(t *testing.T) {
+		hubServer := newServer(t, "sdw2", "sdw3")
+		defer func() {
+			for _, conn := range hubServer.Conns {
+				if conn.Conn != nil {
+					conn.Conn.Close()
+				}
+			}
+		}()
+
+		reply, err := hubServer.ReloadConfig(context.Background(), &idl.ReloadConfigRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := &idl.ReloadConfigReply{AddedHosts: []string{"sdw3"}, RemovedHosts: []string{"sdw1"}}
+		if !reflect.DeepEqual(reply, expected) {
+			t.Fatalf("got %+v, want %+v", reply, expected)
+		}
+		if !reflect.DeepEqual(hubServer.Hostnames, []string{"sdw2", "sdw3"}) {
+			t.Fatalf("got %v, want %v", hubServer.Hostnames, []string{"sdw2", "sdw3"})
+		}
+		if !reflect.DeepEqual(connectedHosts(hubServer), []string{"sdw2", "sdw3"}) {
+			t.Fatalf("got %v, want %v", connectedHosts(hubServer), []string{"sdw2", "sdw3"})
+		}
+	})
+
+	t.Run("keeps the hosts when the configuration file lists none", func(t *testing.T) {
+		hubServer := newServer(t)
+
+		_, err := hubServer.ReloadConfig(context.Background(), &idl.ReloadConfigRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", err, codes.FailedPrecondition)
+		}
+		if !reflect.DeepEqual(connectedHosts(hubServer), []string{"sdw1", "sdw2"}) {
+			t.Fatalf("got %v, want the connections to be kept", connectedHosts(hubServer))
+		}
+	})
+
+	t.Run("errors out when the hub was not started from a configuration file", func(t *testing.T) {
+		hubServer := newServer(t, "sdw1")
+		hubServer.ConfigFilePath = ""
+
+		_, err := hubServer.ReloadConfig(context.Background(), &idl.ReloadConfigRequest{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", err, codes.FailedPrecondition)
 		}
 	})
 }
@@ -446,9 +993,6 @@ func TestConfig(t *testing.T) {
 		}
 		defer os.Remove(file.Name())
 
-		hub.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer hub.ResetExecCommand()
-
 		expectedConfig := hub.Config{
 			Port:        123,
 			AgentPort:   456,
@@ -479,6 +1023,74 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps the previous versions of the config file in a numbered history", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp.conf")
+		written := make([][]byte, 0, constants.ConfigHistorySize+2)
+		for i := 0; i < constants.ConfigHistorySize+2; i++ {
+			config := hub.Config{Port: 1000 + i, Hostnames: []string{"sdw1"}}
+			err := config.Write(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			written = append(written, contents)
+
+			// Writing the same contents again is not a new version
+			err = config.Write(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		entries, err := hub.ConfigHistory(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(entries) != constants.ConfigHistorySize {
+			t.Fatalf("got %d entries, want %d", len(entries), constants.ConfigHistorySize)
+		}
+
+		// The file written i-th was kept as entry i once it was replaced, and
+		// the oldest entries were dropped
+		for i, entry := range entries {
+			number := constants.ConfigHistorySize + 1 - i
+			if entry.Number != number {
+				t.Fatalf("got entry %d, want %d", entry.Number, number)
+			}
+
+			contents, err := os.ReadFile(entry.Path)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if !reflect.DeepEqual(contents, written[number-1]) {
+				t.Fatalf("got %s for entry %d, want %s", contents, number, written[number-1])
+			}
+		}
+
+		// No temporary files are left next to the config file
+		files, err := os.ReadDir(filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(files) != 2 {
+			t.Fatalf("got %d files, want only the config file and its history", len(files))
+		}
+	})
+
+	t.Run("returns no history for a config file which was never overwritten", func(t *testing.T) {
+		entries, err := hub.ConfigHistory(filepath.Join(t.TempDir(), "gp.conf"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("got %+v, want no entries", entries)
+		}
+	})
+
 	t.Run("returns appropriate error when fails to write config", func(t *testing.T) {
 		file, err := os.CreateTemp("", "test")
 		if err != nil {
@@ -486,18 +1098,9 @@ func TestConfig(t *testing.T) {
 		}
 		defer os.Remove(file.Name())
 
-		hub.SetExecCommand(exectest.NewCommand(exectest.Failure))
-		defer hub.ResetExecCommand()
-
 		config := hub.Config{
 			Hostnames: []string{"sdw1", "sdw2"},
 		}
-		err = config.Write(file.Name())
-		expectedErrPrefix := "could not copy gp.conf file to segment hosts: exit status 1"
-		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
-			t.Fatalf("got %v, want %v", err, expectedErrPrefix)
-		}
-
 		err = os.Chmod(file.Name(), 0000)
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
@@ -509,6 +1112,88 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	writeConfigFile := func(t *testing.T, contents string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "gp.conf")
+		err := os.WriteFile(path, []byte(contents), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return path
+	}
+	const unversionedConfig = `{
+		"hubPort": 4242,
+		"agentPort": 8000,
+		"hostnames": ["sdw1"],
+		"hubLogDir": "/tmp",
+		"serviceName": "gp",
+		"gphome": "/usr/local/gp",
+		"Credentials": {"caCert": "/path/to/ca.pem", "caKey": "", "serverCert": "", "serverKey": ""}
+	}`
+
+	t.Run("migrates a config file written before the schema was versioned", func(t *testing.T) {
+		config := hub.Config{}
+		err := config.Load(writeConfigFile(t, unversionedConfig))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := hub.Config{
+			ConfigVersion: hub.ConfigVersion,
+			Port:          4242,
+			AgentPort:     8000,
+			Hostnames:     []string{"sdw1"},
+			LogDir:        "/tmp",
+			ServiceName:   "gp",
+			GpHome:        "/usr/local/gp",
+			Credentials:   &utils.GpCredentials{CACertPath: "/path/to/ca.pem"},
+		}
+		if !reflect.DeepEqual(config, expected) {
+			t.Fatalf("got %+v, want %+v", config, expected)
+		}
+	})
+
+	t.Run("rejects config files which do not match the schema", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			contents string
+			expected string
+		}{
+			{
+				name:     "unknown key",
+				contents: strings.Replace(unversionedConfig, `"hubPort"`, `"port"`, 1),
+				expected: `could not parse config file: json: unknown field "port"`,
+			},
+			{
+				name:     "unknown nested key",
+				contents: strings.Replace(unversionedConfig, `"caCert"`, `"caCertificate"`, 1),
+				expected: `could not parse config file: json: unknown field "caCertificate"`,
+			},
+			{
+				name:     "missing key",
+				contents: strings.Replace(unversionedConfig, `"agentPort": 8000,`, "", 1),
+				expected: `could not parse config file: missing required key "agentPort"`,
+			},
+			{
+				name:     "newer version",
+				contents: strings.Replace(unversionedConfig, "{", fmt.Sprintf(`{"configVersion": %d,`, hub.ConfigVersion+1), 1),
+				expected: fmt.Sprintf("could not parse config file: unsupported configVersion %d: this version of gp supports up to %d; upgrade gp to use this file", hub.ConfigVersion+1, hub.ConfigVersion),
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				config := hub.Config{}
+				err := config.Load(writeConfigFile(t, tc.contents))
+				if err == nil || err.Error() != tc.expected {
+					t.Fatalf("got %v, want %v", err, tc.expected)
+				}
+			})
+		}
+	})
+
 	t.Run("returns appropriate error when fails to load config", func(t *testing.T) {
 		file, err := os.CreateTemp("", "test")
 		if err != nil {
@@ -0,0 +1,396 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/executor"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// UpgradeRetryInterval is how long to wait between checks of whether the
+// restarted agents are up and running the new version.
+var UpgradeRetryInterval = time.Second
+
+// The new binary is staged next to the installed one, which is kept as
+// previousBinaryName for rolling back.
+const (
+	binaryName         = "gp"
+	stagedBinaryName   = ".gp.upgrade"
+	previousBinaryName = "gp.previous"
+)
+
+// UpgradeTools installs a new gp binary in GPHOME on every host. The binary is
+// first copied next to the installed one on all hosts, with its checksum
+// verified by the agents, so that nothing is restarted unless every host has
+// it. The agents are then restarted on the new binary a batch at a time, and
+// each batch must come back running the new version before the next one is
+// started. If one does not, every agent upgraded so far is rolled back to the
+// previous binary and must come back running the version of the hub. Finally the binary is installed on the hub host; restarting
+// the hub is left to the caller, as the hub cannot outlive its own restart.
+func (s *Server) UpgradeTools(in *idl.UpgradeToolsRequest, stream idl.Hub_UpgradeToolsServer) error {
+	ctx := stream.Context()
+
+	if !filepath.IsAbs(in.Binary) {
+		return grpcStatus.Errorf(codes.InvalidArgument, "binary path %s is not absolute", in.Binary)
+	}
+	if in.BatchSize < 0 {
+		return grpcStatus.Errorf(codes.InvalidArgument, "invalid batch size %d: must be positive", in.BatchSize)
+	}
+	batchSize := int(in.BatchSize)
+	if batchSize == 0 {
+		batchSize = constants.DefaultUpgradeBatchSize
+	}
+
+	target, err := utils.BinaryVersion(in.Binary)
+	if err != nil {
+		return grpcStatus.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	info, err := utils.NewFileInfo(in.Binary)
+	if err != nil {
+		return grpcStatus.Errorf(codes.InvalidArgument, "could not read %s: %v", in.Binary, err)
+	}
+	binDir := filepath.Join(s.GpHome, "bin")
+	info.Path = filepath.Join(binDir, stagedBinaryName)
+	info.Owner = "" // owned by the user running the agents, like the installed binary
+
+	progress := func(format string, args ...interface{}) error {
+		message := fmt.Sprintf(format, args...)
+		utils.LogInfo(ctx, "%s", message)
+		return stream.Send(&idl.UpgradeToolsReply{Update: &idl.UpgradeToolsReply_Progress{Progress: message}})
+	}
+	sendResults := func(results []*idl.HostResult) error {
+		for _, result := range results {
+			err := stream.Send(&idl.UpgradeToolsReply{Update: &idl.UpgradeToolsReply_Result{Result: result}})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = s.connectToAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Every agent is needed to tell whether it came back after the upgrade
+	conns := s.agentConns()
+	hosts := make([]string, 0, len(conns))
+	var unreachable []string
+	for _, conn := range conns {
+		hosts = append(hosts, conn.Hostname)
+		if !conn.Healthy() {
+			unreachable = append(unreachable, conn.Hostname)
+		}
+	}
+	if len(unreachable) > 0 {
+		return grpcStatus.Errorf(codes.FailedPrecondition, "agents on hosts %s are unreachable; start them before upgrading gp", strings.Join(unreachable, ", "))
+	}
+
+	err = progress("Copying gp %s (commit %s) to %d hosts", target.Version, target.Commit, len(hosts))
+	if err != nil {
+		return err
+	}
+
+	results := executeRPCOnAnyVersion(ctx, conns, func(conn *Connection) error {
+		err := PutFile(ctx, conn.AgentClient, in.Binary, info)
+		if err != nil {
+			return fmt.Errorf("could not copy %s to host %s: %w", in.Binary, conn.Hostname, err)
+		}
+
+		return nil
+	})
+	for _, result := range results {
+		if !result.Success {
+			err = sendResults(results)
+			if err != nil {
+				return err
+			}
+			return grpcStatus.Error(codes.Aborted, "could not copy the new binary to every host; no agent was restarted")
+		}
+	}
+
+	binDirArg := executor.Quote(binDir)
+	install := fmt.Sprintf("cd %s && rm -f %s && ln %s %s && mv -f %s %s",
+		binDirArg, previousBinaryName, binaryName, previousBinaryName, stagedBinaryName, binaryName)
+	rollback := fmt.Sprintf("cd %s && if [ -e %s ]; then mv -f %s %s; fi",
+		binDirArg, previousBinaryName, previousBinaryName, binaryName)
+
+	var upgraded []string
+	for start := 0; start < len(hosts); start += batchSize {
+		end := start + batchSize
+		if end > len(hosts) {
+			end = len(hosts)
+		}
+		batch := hosts[start:end]
+		err = progress("Restarting the agents on hosts %s", strings.Join(batch, ", "))
+		if err != nil {
+			return err
+		}
+
+		upgraded = append(upgraded, batch...)
+		failures := s.restartAgents(ctx, batch, install)
+		if len(failures) == 0 {
+			failures = s.waitForAgents(ctx, batch, target)
+		}
+		if len(failures) == 0 {
+			continue
+		}
+
+		err = progress("Rolling back the agents on hosts %s", strings.Join(upgraded, ", "))
+		if err != nil {
+			return err
+		}
+		rollbackFailures := s.restartAgents(ctx, upgraded, rollback)
+
+		// The agents roll back to the version the hub itself still runs
+		restarted := make([]string, 0, len(upgraded))
+		for _, host := range upgraded {
+			if rollbackFailures[host] == nil {
+				restarted = append(restarted, host)
+			}
+		}
+		if len(restarted) > 0 {
+			for host, err := range s.waitForAgents(ctx, restarted, utils.GetVersionInfo()) {
+				rollbackFailures[host] = err
+			}
+		}
+
+		err = sendResults(rollbackResults(hosts, upgraded, failures, rollbackFailures))
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("agents on hosts %s did not come back running gp %s; rolled back the upgrade", strings.Join(sortedKeys(failures), ", "), target.Version)
+		if len(rollbackFailures) > 0 {
+			message += fmt.Sprintf(", but could not roll back hosts %s", strings.Join(sortedKeys(rollbackFailures), ", "))
+		}
+		return grpcStatus.Error(codes.Aborted, message)
+	}
+
+	results = make([]*idl.HostResult, 0, len(hosts))
+	for _, host := range hosts {
+		results = append(results, NewHostResult(host, nil))
+	}
+	err = sendResults(results)
+	if err != nil {
+		return err
+	}
+
+	err = installBinary(in.Binary, binDir)
+	if err != nil {
+		return fmt.Errorf("upgraded the agents, but could not install %s on the hub host: %w", in.Binary, err)
+	}
+
+	return progress("Installed gp %s (commit %s) on all hosts", target.Version, target.Commit)
+}
+
+// restartAgents stops the agents on hosts, runs command on those hosts and
+// starts the agents again. It returns why it failed on each host it failed on.
+func (s *Server) restartAgents(ctx context.Context, hosts []string, command string) map[string]error {
+	failures := make(map[string]error)
+
+	conns, err := s.selectConns(hosts)
+	if err != nil {
+		for _, host := range hosts {
+			failures[host] = err
+		}
+		return failures
+	}
+
+	// Agents which are unreachable are most likely stopped already
+	stopped := make([]string, 0, len(hosts))
+	for _, result := range executeRPCOnAnyVersion(ctx, conns, func(conn *Connection) error { return stopAgent(ctx, conn) }) {
+		if !result.Success && result.Code != int32(codes.Unavailable) {
+			failures[result.Host] = grpcStatus.Error(codes.Code(result.Code), result.Message)
+			continue
+		}
+		stopped = append(stopped, result.Host)
+	}
+
+	startCmd := strings.Join(platform.GetStartAgentCommandString(s.ServiceName), " ")
+	for _, r := range remoteExecutor.Run(ctx, stopped, command+" && "+startCmd) {
+		if err := r.Failure(); err != nil {
+			failures[r.Host] = fmt.Errorf("could not restart agent: %w", err)
+		}
+	}
+
+	return failures
+}
+
+// waitForAgents waits for the agents on hosts to be up and running the target
+// version of gp. It returns why it gave up on each host it gave up on.
+func (s *Server) waitForAgents(ctx context.Context, hosts []string, target *idl.VersionInfo) map[string]error {
+	var failures map[string]error
+	for try := 0; try < constants.MaxRetries; try++ {
+		if try > 0 {
+			select {
+			case <-ctx.Done():
+				return failures
+			case <-time.After(UpgradeRetryInterval):
+			}
+		}
+
+		failures = make(map[string]error)
+
+		// The connections reconnect to the restarted agents by themselves
+		err := s.DialAllAgents()
+		var unready *UnreadyHostsError
+		if err != nil && !errors.As(err, &unready) {
+			for _, host := range hosts {
+				failures[host] = err
+			}
+			continue
+		}
+
+		conns, err := s.selectConns(hosts)
+		if err != nil {
+			for _, host := range hosts {
+				failures[host] = err
+			}
+			continue
+		}
+
+		results := executeRPCOnAnyVersion(ctx, conns, func(conn *Connection) error {
+			reply, err := conn.AgentClient.Version(ctx, &idl.VersionAgentRequest{Hub: utils.GetVersionInfo()})
+			if err != nil {
+				return fmt.Errorf("could not get the version of the agent on host %s: %w", conn.Hostname, err)
+			}
+			if reply.Info == nil || !utils.SameBuild(reply.Info, target) {
+				return grpcStatus.Errorf(codes.FailedPrecondition, "agent on host %s does not run gp %s (commit %s)", conn.Hostname, target.Version, target.Commit)
+			}
+
+			return nil
+		})
+		for _, result := range results {
+			if !result.Success {
+				failures[result.Host] = grpcStatus.Error(codes.Code(result.Code), result.Message)
+			}
+		}
+
+		if len(failures) == 0 {
+			return nil
+		}
+	}
+
+	return failures
+}
+
+// rollbackResults reports the outcome of a rolled back upgrade on every host.
+func rollbackResults(hosts []string, upgraded []string, failures map[string]error, rollbackFailures map[string]error) []*idl.HostResult {
+	wasUpgraded := make(map[string]bool, len(upgraded))
+	for _, host := range upgraded {
+		wasUpgraded[host] = true
+	}
+
+	results := make([]*idl.HostResult, 0, len(hosts))
+	for _, host := range hosts {
+		err, failed := failures[host]
+		switch {
+		case rollbackFailures[host] != nil:
+			err = fmt.Errorf("could not roll back to the previous gp binary: %w", rollbackFailures[host])
+		case failed:
+		case wasUpgraded[host]:
+			err = grpcStatus.Error(codes.Aborted, "rolled back to the previous gp binary")
+		default:
+			err = grpcStatus.Error(codes.Aborted, "not upgraded, as the upgrade was rolled back")
+		}
+		results = append(results, NewHostResult(host, err))
+	}
+
+	return results
+}
+
+// installBinary installs the gp binary at src in binDir on the hub host the
+// same way it is installed on the agent hosts, unless the hub host is one of
+// them and has it installed already.
+func installBinary(src string, binDir string) error {
+	dst := filepath.Join(binDir, binaryName)
+
+	checksum, err := utils.FileChecksum(src)
+	if err != nil {
+		return err
+	}
+	installed, err := utils.FileChecksum(dst)
+	if err == nil && installed == checksum {
+		return nil
+	}
+
+	staged := filepath.Join(binDir, stagedBinaryName)
+	err = copyExecutable(src, staged)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(staged) // no-op once the file has been renamed
+
+	previous := filepath.Join(binDir, previousBinaryName)
+	err = os.Remove(previous)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove %s: %w", previous, err)
+	}
+	err = os.Link(dst, previous)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not keep %s as %s: %w", dst, previous, err)
+	}
+
+	err = os.Rename(staged, dst)
+	if err != nil {
+		return fmt.Errorf("could not move %s into place: %w", dst, err)
+	}
+
+	return nil
+}
+
+func copyExecutable(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		return fmt.Errorf("could not write to %s: %w", dst, err)
+	}
+
+	err = out.Chmod(0755)
+	if err != nil {
+		return fmt.Errorf("could not set mode of %s: %w", dst, err)
+	}
+
+	err = out.Sync()
+	if err != nil {
+		return fmt.Errorf("could not write to %s: %w", dst, err)
+	}
+
+	return out.Close()
+}
+
+func sortedKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,323 @@
+package hub_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+type upgradeToolsServerStream struct {
+	grpc.ServerStream
+	replies []*idl.UpgradeToolsReply
+}
+
+func (s *upgradeToolsServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *upgradeToolsServerStream) Send(reply *idl.UpgradeToolsReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+func (s *upgradeToolsServerStream) results() []*idl.HostResult {
+	var results []*idl.HostResult
+	for _, reply := range s.replies {
+		if result := reply.GetResult(); result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+func TestUpgradeTools(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	hub.UpgradeRetryInterval = 0
+	defer func() { hub.UpgradeRetryInterval = time.Second }()
+
+	hub.SetEnsureConnectionsAreReady(func(conns []*hub.Connection) error {
+		return nil
+	})
+	defer hub.ResetEnsureConnectionsAreReady()
+
+	// The new binary only needs to be able to report its version
+	binary := filepath.Join(t.TempDir(), "gp")
+	err := os.WriteFile(binary, []byte("#!/bin/sh\necho 'gp version 2.0.0 (commit abc123, protocol version 1)'\n"), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	target := &idl.VersionInfo{Version: "2.0.0", Commit: "abc123", ProtocolVersion: 1}
+	// The agents run the same version as the hub before the upgrade
+	previous := utils.GetVersionInfo()
+
+	setup := func(t *testing.T) (*hub.Server, string) {
+		t.Helper()
+
+		gphome := t.TempDir()
+		err := os.Mkdir(filepath.Join(gphome, "bin"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = os.WriteFile(filepath.Join(gphome, "bin", "gp"), []byte("old binary"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		hubServer := hub.New(&hub.Config{
+			Port:        constants.DefaultHubPort,
+			AgentPort:   constants.DefaultAgentPort,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      gphome,
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}, nil)
+
+		return hubServer, gphome
+	}
+
+	// agent returns an agent which receives the binary, and then stops and
+	// reports the given versions whenever asked to.
+	agent := func(ctrl *gomock.Controller, putErr error, versions ...*idl.VersionInfo) *mock_idl.MockAgentClient {
+		stream := mock_idl.NewMockAgent_PutFileClient(ctrl)
+		stream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+		stream.EXPECT().CloseAndRecv().Return(&idl.PutFileReply{}, putErr)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().PutFile(gomock.Any(), gomock.Any()).Return(stream, nil)
+		client.EXPECT().Stop(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "transport is closing")).AnyTimes()
+		for i, version := range versions {
+			call := client.EXPECT().Version(gomock.Any(), gomock.Any(), gomock.Any()).Return(&idl.VersionAgentReply{Info: version}, nil)
+			if i == len(versions)-1 {
+				call.AnyTimes()
+			}
+		}
+
+		return client
+	}
+
+	t.Run("restarts the agents in batches and installs the binary on the hub host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer, gphome := setup(t)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: agent(ctrl, nil, previous, target), Hostname: "sdw1"},
+			{AgentClient: agent(ctrl, nil, target), Hostname: "sdw2"},
+		}
+
+		executor := &testutils.MockExecutor{}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		stream := &upgradeToolsServerStream{}
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: binary, BatchSize: 1}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectedHosts := [][]string{{"sdw1"}, {"sdw2"}}
+		if !reflect.DeepEqual(executor.Hosts, expectedHosts) {
+			t.Fatalf("got %v, want %v", executor.Hosts, expectedHosts)
+		}
+		binDir := filepath.Join(gphome, "bin")
+		expectedCommand := "cd '" + binDir + "' && rm -f gp.previous && ln gp gp.previous && mv -f .gp.upgrade gp && " +
+			strings.Join(utils.GetPlatform().GetStartAgentCommandString("gp"), " ")
+		if executor.Commands[0] != expectedCommand {
+			t.Fatalf("got %q, want %q", executor.Commands[0], expectedCommand)
+		}
+
+		results := stream.results()
+		if len(results) != 2 || !results[0].Success || !results[1].Success {
+			t.Fatalf("got %+v, want both hosts to succeed", results)
+		}
+
+		installed, err := os.ReadFile(filepath.Join(binDir, "gp"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !strings.Contains(string(installed), "gp version 2.0.0") {
+			t.Fatalf("got %q, want the new binary to be installed", installed)
+		}
+		kept, err := os.ReadFile(filepath.Join(binDir, "gp.previous"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(kept) != "old binary" {
+			t.Fatalf("got %q, want the previous binary to be kept", kept)
+		}
+	})
+
+	t.Run("rolls back every upgraded agent when one does not come back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer, gphome := setup(t)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: agent(ctrl, nil, target, previous), Hostname: "sdw1"},
+			{AgentClient: agent(ctrl, nil, previous), Hostname: "sdw2"},
+		}
+
+		executor := &testutils.MockExecutor{}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		stream := &upgradeToolsServerStream{}
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: binary, BatchSize: 1}, stream)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("got %v, want %v", err, codes.Aborted)
+		}
+
+		expectedHosts := [][]string{{"sdw1"}, {"sdw2"}, {"sdw1", "sdw2"}}
+		if !reflect.DeepEqual(executor.Hosts, expectedHosts) {
+			t.Fatalf("got %v, want %v", executor.Hosts, expectedHosts)
+		}
+		if !strings.Contains(executor.Commands[2], "mv -f gp.previous gp") {
+			t.Fatalf("got %q, want the previous binary to be restored", executor.Commands[2])
+		}
+
+		expected := []*idl.HostResult{
+			{Host: "sdw1", Code: int32(codes.Aborted), Message: "rolled back to the previous gp binary"},
+			{Host: "sdw2", Code: int32(codes.FailedPrecondition), Message: "agent on host sdw2 does not run gp 2.0.0 (commit abc123)"},
+		}
+		if !reflect.DeepEqual(stream.results(), expected) {
+			t.Fatalf("got %+v, want %+v", stream.results(), expected)
+		}
+
+		// The hub host is left alone
+		installed, err := os.ReadFile(filepath.Join(gphome, "bin", "gp"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(installed) != "old binary" {
+			t.Fatalf("got %q, want the hub host to keep its binary", installed)
+		}
+	})
+
+	t.Run("reports the agents which could not be rolled back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// sdw2 does not come back, and then cannot be stopped for the rollback
+		stream := mock_idl.NewMockAgent_PutFileClient(ctrl)
+		stream.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+		stream.EXPECT().CloseAndRecv().Return(&idl.PutFileReply{}, nil)
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().PutFile(gomock.Any(), gomock.Any()).Return(stream, nil)
+		gomock.InOrder(
+			sdw2.EXPECT().Stop(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "transport is closing")),
+			sdw2.EXPECT().Stop(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Internal, "could not stop")),
+		)
+		sdw2.EXPECT().Version(gomock.Any(), gomock.Any(), gomock.Any()).Return(&idl.VersionAgentReply{Info: previous}, nil).AnyTimes()
+
+		// sdw1 keeps running the new version after the rollback
+		hubServer, _ := setup(t)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: agent(ctrl, nil, target), Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		executor := &testutils.MockExecutor{}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		upgradeStream := &upgradeToolsServerStream{}
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: binary, BatchSize: 2}, upgradeStream)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("got %v, want %v", err, codes.Aborted)
+		}
+		if !strings.Contains(err.Error(), "could not roll back hosts sdw1, sdw2") {
+			t.Fatalf("got %v, want it to name the hosts which could not be rolled back", err)
+		}
+
+		expectedHosts := [][]string{{"sdw1", "sdw2"}, {"sdw1"}}
+		if !reflect.DeepEqual(executor.Hosts, expectedHosts) {
+			t.Fatalf("got %v, want %v", executor.Hosts, expectedHosts)
+		}
+
+		results := upgradeStream.results()
+		expected := []string{
+			fmt.Sprintf("could not roll back to the previous gp binary: rpc error: code = FailedPrecondition desc = agent on host sdw1 does not run gp %s (commit %s)", previous.Version, previous.Commit),
+			"could not roll back to the previous gp binary: rpc error: code = Internal desc = failed to stop agent on host sdw2: rpc error: code = Internal desc = could not stop",
+		}
+		if len(results) != 2 || results[0].Message != expected[0] || results[1].Message != expected[1] {
+			t.Fatalf("got %+v, want messages %q", results, expected)
+		}
+	})
+
+	t.Run("restarts no agent unless every host received the binary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer, _ := setup(t)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: agent(ctrl, nil), Hostname: "sdw1"},
+			{AgentClient: agent(ctrl, status.Error(codes.DataLoss, "checksum mismatch")), Hostname: "sdw2"},
+		}
+
+		executor := &testutils.MockExecutor{}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		stream := &upgradeToolsServerStream{}
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: binary}, stream)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("got %v, want %v", err, codes.Aborted)
+		}
+		if len(executor.Commands) != 0 {
+			t.Fatalf("got %q, want no commands to be run", executor.Commands)
+		}
+
+		results := stream.results()
+		if len(results) != 2 || !results[0].Success || results[1].Code != int32(codes.DataLoss) {
+			t.Fatalf("got %+v, want sdw2 to fail with %v", results, codes.DataLoss)
+		}
+	})
+
+	t.Run("refuses to upgrade while an agent is unreachable", func(t *testing.T) {
+		hubServer, _ := setup(t)
+		unreachable := &hub.Connection{Hostname: "sdw2"}
+		unreachable.SetHealthy(false)
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}, unreachable}
+
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: binary}, &upgradeToolsServerStream{})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", err, codes.FailedPrecondition)
+		}
+	})
+
+	t.Run("rejects a binary which is not gp", func(t *testing.T) {
+		hubServer, _ := setup(t)
+
+		err := hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: "/bin/true"}, &upgradeToolsServerStream{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", err, codes.InvalidArgument)
+		}
+
+		err = hubServer.UpgradeTools(&idl.UpgradeToolsRequest{Binary: "gp"}, &upgradeToolsServerStream{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got %v, want %v", err, codes.InvalidArgument)
+		}
+	})
+}
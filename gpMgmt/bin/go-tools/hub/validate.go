@@ -0,0 +1,171 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gp/executor"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+var lookupHost = net.LookupHost
+
+// ConfigCheck is the outcome of checking one aspect of the configuration
+// against the environment of a host. Error is empty if the check passed.
+type ConfigCheck struct {
+	Name  string `json:"check"`
+	Error string `json:"error,omitempty"`
+}
+
+// HostConfigChecks are the checks run on a single host. Err is set if they
+// could not be run on the host at all.
+type HostConfigChecks struct {
+	Host   string
+	Checks []ConfigCheck
+	Err    error
+}
+
+func newConfigCheck(name string, err error) ConfigCheck {
+	check := ConfigCheck{Name: name}
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+// ValidateConfig checks conf against the environment of this host, including
+// the certificates of the given roles, which are those used on this host.
+func ValidateConfig(conf *Config, roles ...utils.Role) []ConfigCheck {
+	checks := []ConfigCheck{newConfigCheck("ports", validatePorts(conf))}
+
+	credentials, ok := conf.Credentials.(*utils.GpCredentials)
+	if ok {
+		checks = append(checks, newConfigCheck("CA certificate", credentials.VerifyCA()))
+		for _, role := range roles {
+			checks = append(checks, newConfigCheck(fmt.Sprintf("%s certificate", role), credentials.VerifyCertificate(role)))
+		}
+	} else {
+		checks = append(checks, newConfigCheck("certificates", errors.New("only file based credentials can be validated")))
+	}
+
+	return append(checks,
+		newConfigCheck("gphome", validateGpHome(conf.GpHome)),
+		newConfigCheck("hostnames", validateHostnames(conf.Hostnames)),
+		newConfigCheck("log directory", validateLogDir(conf.LogDir)),
+	)
+}
+
+// ValidateConfigOnHosts runs the checks on every host of the cluster over SSH,
+// with the certificate of the agents, so that the configuration can be
+// validated while the agents are not running. configFilePath is the path of
+// gp.conf on the hosts.
+func ValidateConfigOnHosts(conf *Config, configFilePath string) []*HostConfigChecks {
+	command := fmt.Sprintf("%s config validate --local --config-file %s",
+		executor.Quote(filepath.Join(conf.GpHome, "bin", "gp")), executor.Quote(configFilePath))
+	results := remoteExecutor.Run(context.Background(), conf.Hostnames, command)
+
+	hostChecks := make([]*HostConfigChecks, 0, len(results))
+	for _, result := range results {
+		checks := &HostConfigChecks{Host: result.Host}
+		hostChecks = append(hostChecks, checks)
+
+		// The checks are printed even if some of them failed
+		if result.Err == nil {
+			err := json.Unmarshal([]byte(result.Stdout), &checks.Checks)
+			if err == nil {
+				continue
+			}
+		}
+
+		checks.Err = result.Failure()
+		if checks.Err == nil {
+			checks.Err = fmt.Errorf("host %s: unexpected output of gp config validate: %q", result.Host, result.Stdout)
+		}
+	}
+
+	return hostChecks
+}
+
+func validatePorts(conf *Config) error {
+	ports := []struct {
+		key      string
+		port     int
+		optional bool
+	}{
+		{"hubPort", conf.Port, false},
+		{"agentPort", conf.AgentPort, false},
+		{"hubMetricsPort", conf.MetricsPort, true},
+		{"agentMetricsPort", conf.AgentMetricsPort, true},
+	}
+
+	used := make(map[int]string, len(ports))
+	for _, p := range ports {
+		if p.optional && p.port == 0 {
+			continue
+		}
+		if p.port < 1 || p.port > 65535 {
+			return fmt.Errorf("%s %d is not between 1 and 65535", p.key, p.port)
+		}
+		if key, ok := used[p.port]; ok {
+			return fmt.Errorf("%s and %s are both %d", key, p.key, p.port)
+		}
+		used[p.port] = p.key
+	}
+
+	return nil
+}
+
+func validateGpHome(gphome string) error {
+	for _, name := range []string{filepath.Join("bin", "gp"), "greenplum_path.sh"} {
+		_, err := os.Stat(filepath.Join(gphome, name))
+		if err != nil {
+			return fmt.Errorf("could not find %s in gphome %s: %w", name, gphome, err)
+		}
+	}
+
+	return nil
+}
+
+func validateHostnames(hostnames []string) error {
+	var unresolved []string
+	var lastErr error
+	for _, host := range hostnames {
+		_, err := lookupHost(host)
+		if err != nil {
+			unresolved = append(unresolved, host)
+			lastErr = err
+		}
+	}
+
+	if len(unresolved) > 0 {
+		return fmt.Errorf("could not resolve hosts %s: %w", strings.Join(unresolved, ", "), lastErr)
+	}
+
+	return nil
+}
+
+func validateLogDir(logDir string) error {
+	file, err := os.CreateTemp(logDir, ".gp_validate_*")
+	if err != nil {
+		return fmt.Errorf("log directory %s is not writable: %w", logDir, err)
+	}
+	file.Close()
+
+	return os.Remove(file.Name())
+}
+
+// used only for testing
+func SetLookupHost(customFunc func(host string) ([]string, error)) {
+	lookupHost = customFunc
+}
+
+func ResetLookupHost() {
+	lookupHost = net.LookupHost
+}
@@ -0,0 +1,219 @@
+package hub_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestValidateConfig(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	hub.SetLookupHost(func(host string) ([]string, error) {
+		if strings.HasPrefix(host, "sdw") {
+			return []string{"10.0.0.1"}, nil
+		}
+		return nil, errors.New("no such host")
+	})
+	defer hub.ResetLookupHost()
+
+	// setup returns a configuration which passes every check
+	setup := func(t *testing.T) *hub.Config {
+		t.Helper()
+
+		dir := t.TempDir()
+		ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		credentials := &utils.GpCredentials{
+			CACertPath:     filepath.Join(dir, utils.CACertFileName),
+			CAKeyPath:      filepath.Join(dir, utils.CAKeyFileName),
+			ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+			ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+		}
+		err = ca.Write(credentials.CACertPath, credentials.CAKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = ca.IssueLocalCertificate(utils.RoleHub, "server", []string{"sdw1"}, time.Hour, credentials.ServerCertPath, credentials.ServerKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		gphome := t.TempDir()
+		err = os.Mkdir(filepath.Join(gphome, "bin"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		for _, name := range []string{filepath.Join("bin", "gp"), "greenplum_path.sh"} {
+			err = os.WriteFile(filepath.Join(gphome, name), nil, 0755)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		return &hub.Config{
+			Port:        4242,
+			AgentPort:   8000,
+			Hostnames:   []string{"sdw1", "sdw2"},
+			LogDir:      t.TempDir(),
+			ServiceName: "gp",
+			GpHome:      gphome,
+			Credentials: credentials,
+		}
+	}
+	failures := func(checks []hub.ConfigCheck) map[string]string {
+		failed := make(map[string]string)
+		for _, check := range checks {
+			if check.Error != "" {
+				failed[check.Name] = check.Error
+			}
+		}
+		return failed
+	}
+
+	t.Run("passes every check for a valid configuration", func(t *testing.T) {
+		conf := setup(t)
+
+		checks := hub.ValidateConfig(conf, utils.RoleHub, utils.RoleCLI)
+
+		names := make([]string, 0, len(checks))
+		for _, check := range checks {
+			names = append(names, check.Name)
+		}
+		expected := []string{"ports", "CA certificate", "hub certificate", "cli certificate", "gphome", "hostnames", "log directory"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("got %v, want %v", names, expected)
+		}
+		if len(failures(checks)) != 0 {
+			t.Fatalf("got failures %v, want none", failures(checks))
+		}
+	})
+
+	t.Run("reports each problem with the environment", func(t *testing.T) {
+		conf := setup(t)
+		conf.AgentMetricsPort = conf.AgentPort
+		conf.Hostnames = append(conf.Hostnames, "cdw.invalid")
+		conf.LogDir = filepath.Join(conf.LogDir, "missing")
+		err := os.Remove(filepath.Join(conf.GpHome, "greenplum_path.sh"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		// The key of another certificate does not belong to the configured one
+		credentials := conf.Credentials.(*utils.GpCredentials)
+		credentials.AgentCertPath = credentials.ServerCertPath
+		credentials.AgentKeyPath = credentials.CAKeyPath
+
+		failed := failures(hub.ValidateConfig(conf, utils.RoleAgent))
+
+		expected := map[string]string{
+			"ports":             "agentPort and agentMetricsPort are both 8000",
+			"agent certificate": "private key does not match public key",
+			"gphome":            "could not find greenplum_path.sh in gphome",
+			"hostnames":         "could not resolve hosts cdw.invalid: no such host",
+			"log directory":     "is not writable",
+		}
+		if len(failed) != len(expected) {
+			t.Fatalf("got failures %v, want %v", failed, expected)
+		}
+		for name, message := range expected {
+			if !strings.Contains(failed[name], message) {
+				t.Fatalf("got %q for %s, want %q", failed[name], name, message)
+			}
+		}
+	})
+
+	t.Run("reports certificates which were not issued by the CA", func(t *testing.T) {
+		conf := setup(t)
+		credentials := conf.Credentials.(*utils.GpCredentials)
+
+		other, err := utils.NewCertificateAuthority("other CA", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		dir := t.TempDir()
+		credentials.HubCertPath = filepath.Join(dir, "hub-cert.pem")
+		credentials.HubKeyPath = filepath.Join(dir, "hub-key.pem")
+		err = other.IssueLocalCertificate(utils.RoleHub, "hub", []string{"cdw"}, time.Hour, credentials.HubCertPath, credentials.HubKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		failed := failures(hub.ValidateConfig(conf, utils.RoleHub))
+		if !strings.Contains(failed["hub certificate"], "is not valid for CA") || len(failed) != 1 {
+			t.Fatalf("got failures %v, want the hub certificate to be reported", failed)
+		}
+
+		credentials.CACertPath = credentials.ServerCertPath
+		failed = failures(hub.ValidateConfig(conf))
+		if !strings.Contains(failed["CA certificate"], "is not a certificate authority") {
+			t.Fatalf("got failures %v, want the CA certificate to be reported", failed)
+		}
+	})
+
+	t.Run("rejects ports out of range", func(t *testing.T) {
+		conf := setup(t)
+		conf.Port = 0
+
+		failed := failures(hub.ValidateConfig(conf))
+		expected := "hubPort 0 is not between 1 and 65535"
+		if failed["ports"] != expected {
+			t.Fatalf("got %q, want %q", failed["ports"], expected)
+		}
+	})
+}
+
+func TestValidateConfigOnHosts(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	conf := &hub.Config{Hostnames: []string{"sdw1"}, GpHome: "/usr/local/gp"}
+
+	t.Run("reads back the checks run on each host", func(t *testing.T) {
+		executor := &testutils.MockExecutor{
+			ExitCode: 1,
+			Stdout:   `[{"check":"ports"},{"check":"log directory","error":"log directory /tmp is not writable"}]`,
+		}
+		hub.SetExecutor(executor)
+		defer hub.ResetExecutor()
+
+		hostChecks := hub.ValidateConfigOnHosts(conf, "/usr/local/gp/gp.conf")
+
+		expectedCommand := "'/usr/local/gp/bin/gp' config validate --local --config-file '/usr/local/gp/gp.conf'"
+		if len(executor.Commands) != 1 || executor.Commands[0] != expectedCommand {
+			t.Fatalf("got %q, want %q", executor.Commands, expectedCommand)
+		}
+		expected := []*hub.HostConfigChecks{{
+			Host: "sdw1",
+			Checks: []hub.ConfigCheck{
+				{Name: "ports"},
+				{Name: "log directory", Error: "log directory /tmp is not writable"},
+			},
+		}}
+		if !reflect.DeepEqual(hostChecks, expected) {
+			t.Fatalf("got %+v, want %+v", hostChecks, expected)
+		}
+	})
+
+	t.Run("reports hosts on which the checks could not be run", func(t *testing.T) {
+		hub.SetExecutor(&testutils.MockExecutor{ExitCode: 127, Stderr: "gp: command not found"})
+		defer hub.ResetExecutor()
+
+		hostChecks := hub.ValidateConfigOnHosts(conf, "/usr/local/gp/gp.conf")
+
+		expected := "host sdw1: exit status 127: gp: command not found"
+		if len(hostChecks) != 1 || hostChecks[0].Err == nil || hostChecks[0].Err.Error() != expected {
+			t.Fatalf("got %+v, want %v", hostChecks, expected)
+		}
+	})
+}
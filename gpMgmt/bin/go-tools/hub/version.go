@@ -0,0 +1,125 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// checkAgentVersions performs the version handshake with every reachable
+// agent. It is repeated whenever the agents are dialed, as an agent may have
+// been restarted with another build behind an existing connection. Agents
+// speaking another protocol version are marked as incompatible, so that
+// requests to them are refused rather than failing in obscure ways; agents
+// running a different build of the same protocol version are only warned
+// about.
+func checkAgentVersions(conns []*Connection) {
+	hub := utils.GetVersionInfo()
+
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		// Connections without a gRPC connection are only used by tests
+		if conn.Conn == nil || !conn.Healthy() {
+			continue
+		}
+
+		conn := conn
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), constants.HealthCheckTimeout)
+			defer cancel()
+
+			// Only warn when the agent is first found to differ, not every
+			// time the agents are dialed.
+			previous := conn.AgentVersion()
+			reply, err := conn.AgentClient.Version(ctx, &idl.VersionAgentRequest{Hub: hub})
+			switch {
+			case grpcStatus.Code(err) == codes.Unimplemented:
+				info := &idl.VersionInfo{Host: conn.Hostname}
+				err = fmt.Errorf("agent on host %s predates version %d of the protocol; upgrade gp on host %s", conn.Hostname, utils.ProtocolVersion, conn.Hostname)
+				if !proto.Equal(previous, info) {
+					gplog.Warn("%v", err)
+				}
+				conn.SetAgentVersion(info, err)
+			case err != nil:
+				// Try again the next time the agents are dialed
+				gplog.Debug("Could not get the version of the agent on host %s: %v", conn.Hostname, err)
+			case reply.Info == nil:
+				gplog.Debug("Agent on host %s did not report its version", conn.Hostname)
+			default:
+				reply.Info.Host = conn.Hostname
+				err = utils.CheckVersionCompatibility(reply.Info)
+				switch {
+				case proto.Equal(previous, reply.Info):
+				case err != nil:
+					gplog.Warn("Refusing to work with the agent on host %s: %v", conn.Hostname, err)
+				case utils.VersionDiffers(reply.Info):
+					gplog.Warn("Agent on host %s runs gp %s (commit %s), but the hub runs gp %s (commit %s)",
+						conn.Hostname, reply.Info.Version, reply.Info.Commit, utils.Version, utils.Commit)
+				}
+				conn.SetAgentVersion(reply.Info, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Version reports the version of the hub and of every agent. It reaches the
+// agents regardless of their version, to find those which need upgrading.
+func (s *Server) Version(ctx context.Context, in *idl.VersionRequest) (*idl.VersionReply, error) {
+	if in.Client != nil && utils.VersionDiffers(in.Client) {
+		utils.LogWarn(ctx, "The CLI on host %s runs gp %s (commit %s), but the hub runs gp %s (commit %s)",
+			in.Client.Host, in.Client.Version, in.Client.Commit, utils.Version, utils.Commit)
+	}
+
+	err := s.connectToAgents(ctx)
+	if err != nil {
+		return &idl.VersionReply{}, err
+	}
+
+	conns := s.agentConns()
+	infos := make([]*idl.VersionInfo, len(conns))
+	connIndex := make(map[*Connection]int, len(conns))
+	for i, conn := range conns {
+		connIndex[conn] = i
+	}
+
+	request := func(conn *Connection) error {
+		reply, err := conn.AgentClient.Version(ctx, &idl.VersionAgentRequest{Hub: utils.GetVersionInfo()})
+		if grpcStatus.Code(err) == codes.Unimplemented {
+			return grpcStatus.Errorf(codes.FailedPrecondition, "agent on host %s predates version reporting; upgrade gp on host %s", conn.Hostname, conn.Hostname)
+		}
+		if err != nil {
+			return fmt.Errorf("could not get the version of the agent on host %s: %w", conn.Hostname, err)
+		}
+		if reply.Info == nil {
+			return fmt.Errorf("could not get the version of the agent on host %s: agent sent no version", conn.Hostname)
+		}
+
+		reply.Info.Host = conn.Hostname
+		infos[connIndex[conn]] = reply.Info
+
+		return nil
+	}
+
+	results := executeRPCOnAnyVersion(ctx, conns, request)
+
+	reply := &idl.VersionReply{Hub: utils.GetVersionInfo(), Agents: []*idl.VersionInfo{}, Results: results}
+	for _, info := range infos {
+		if info != nil {
+			reply.Agents = append(reply.Agents, info)
+		}
+	}
+
+	return reply, nil
+}
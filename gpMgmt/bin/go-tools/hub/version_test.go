@@ -0,0 +1,139 @@
+package hub_test
+
+import (
+	"context"
+	"log"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/agent"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/hub"
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// versionAgent is an agent of another build, which reports the given version
+// or, if it is nil, predates the version handshake.
+type versionAgent struct {
+	idl.UnimplementedAgentServer
+	info *idl.VersionInfo
+}
+
+func (a *versionAgent) Version(ctx context.Context, in *idl.VersionAgentRequest) (*idl.VersionAgentReply, error) {
+	if a.info == nil {
+		return a.UnimplementedAgentServer.Version(ctx, in)
+	}
+
+	return &idl.VersionAgentReply{Info: a.info}, nil
+}
+
+func TestVersionHandshake(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	startHub := func(t *testing.T, agentServer idl.AgentServer) *hub.Server {
+		t.Helper()
+
+		listener := bufconn.Listen(1024 * 1024)
+		server := grpc.NewServer()
+		t.Cleanup(server.Stop)
+
+		idl.RegisterAgentServer(server, agentServer)
+		go func() {
+			if err := server.Serve(listener); err != nil {
+				log.Fatalf("server exited with error: %v", err)
+			}
+		}()
+
+		hubConfig := &hub.Config{
+			Port:        constants.DefaultHubPort,
+			AgentPort:   constants.DefaultAgentPort,
+			Hostnames:   []string{"sdw1"},
+			LogDir:      "/tmp/logDir",
+			ServiceName: "gp",
+			GpHome:      "gphome",
+			Credentials: &testutils.MockCredentials{TlsConnection: insecure.NewCredentials()},
+		}
+		dialer := func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}
+
+		hubServer := hub.New(hubConfig, dialer)
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return hubServer
+	}
+
+	t.Run("works with agents of the same protocol version", func(t *testing.T) {
+		hubServer := startHub(t, &agent.Server{})
+
+		conn := hubServer.Conns[0]
+		if conn.Incompatible() != nil {
+			t.Fatalf("unexpected error: %#v", conn.Incompatible())
+		}
+		version := conn.AgentVersion()
+		if version == nil || version.Host != "sdw1" || version.Version != utils.Version || version.ProtocolVersion != utils.ProtocolVersion {
+			t.Fatalf("got %+v, want the version of this build on sdw1", version)
+		}
+	})
+
+	t.Run("still works with agents of another build of the same protocol version", func(t *testing.T) {
+		hubServer := startHub(t, &versionAgent{info: &idl.VersionInfo{Version: "0.1.0", Commit: "abc", ProtocolVersion: utils.ProtocolVersion}})
+
+		if err := hubServer.Conns[0].Incompatible(); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("refuses requests to agents of another protocol version", func(t *testing.T) {
+		hubServer := startHub(t, &versionAgent{info: &idl.VersionInfo{Version: "9.0.0", Commit: "def", ProtocolVersion: utils.ProtocolVersion + 1}})
+
+		reply, err := hubServer.HostInfo(context.Background(), &idl.HostInfoRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(reply.Results) != 1 || codes.Code(reply.Results[0].Code) != codes.FailedPrecondition {
+			t.Fatalf("got %+v, want a FailedPrecondition failure", reply.Results)
+		}
+
+		// The version of incompatible agents can still be asked for
+		versionReply, err := hubServer.Version(context.Background(), &idl.VersionRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(versionReply.Agents) != 1 || versionReply.Agents[0].Version != "9.0.0" || versionReply.Agents[0].Host != "sdw1" {
+			t.Fatalf("got %+v, want version 9.0.0 on sdw1", versionReply.Agents)
+		}
+		if versionReply.Hub.Version != utils.Version {
+			t.Fatalf("got %s, want %s", versionReply.Hub.Version, utils.Version)
+		}
+	})
+
+	t.Run("refuses requests to agents which predate the handshake", func(t *testing.T) {
+		hubServer := startHub(t, &versionAgent{})
+
+		err := hubServer.Conns[0].Incompatible()
+		expected := "agent on host sdw1 predates version 1 of the protocol; upgrade gp on host sdw1"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		reply, err := hubServer.Version(context.Background(), &idl.VersionRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(reply.Results) != 1 || codes.Code(reply.Results[0].Code) != codes.FailedPrecondition {
+			t.Fatalf("got %+v, want a FailedPrecondition failure", reply.Results)
+		}
+	})
+}
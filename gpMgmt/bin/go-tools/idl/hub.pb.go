@@ -100,16 +100,178 @@ func (*StopHubReply) Descriptor() ([]byte, []int) {
 	return file_hub_proto_rawDescGZIP(), []int{1}
 }
 
+type StatusHubRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StatusHubRequest) Reset() {
+	*x = StatusHubRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusHubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusHubRequest) ProtoMessage() {}
+
+func (x *StatusHubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusHubRequest.ProtoReflect.Descriptor instead.
+func (*StatusHubRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{2}
+}
+
+type StatusHubReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Certificate *CertificateInfo `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+}
+
+func (x *StatusHubReply) Reset() {
+	*x = StatusHubReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusHubReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusHubReply) ProtoMessage() {}
+
+func (x *StatusHubReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusHubReply.ProtoReflect.Descriptor instead.
+func (*StatusHubReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StatusHubReply) GetCertificate() *CertificateInfo {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+// HostResult is the outcome of an operation on a single agent host. code is
+// the gRPC status code of the failure, and is OK when success is true.
+type HostResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host    string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Code    int32  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *HostResult) Reset() {
+	*x = HostResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostResult) ProtoMessage() {}
+
+func (x *HostResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostResult.ProtoReflect.Descriptor instead.
+func (*HostResult) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HostResult) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *HostResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HostResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *HostResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// StartAgentsRequest starts the agents on the given hosts, or on all hosts if
+// none are given.
 type StartAgentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
 }
 
 func (x *StartAgentsRequest) Reset() {
 	*x = StartAgentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[2]
+		mi := &file_hub_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -122,7 +284,7 @@ func (x *StartAgentsRequest) String() string {
 func (*StartAgentsRequest) ProtoMessage() {}
 
 func (x *StartAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[2]
+	mi := &file_hub_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -135,19 +297,28 @@ func (x *StartAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAgentsRequest.ProtoReflect.Descriptor instead.
 func (*StartAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{2}
+	return file_hub_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StartAgentsRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
 }
 
 type StartAgentsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Results []*HostResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
 func (x *StartAgentsReply) Reset() {
 	*x = StartAgentsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[3]
+		mi := &file_hub_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -160,7 +331,7 @@ func (x *StartAgentsReply) String() string {
 func (*StartAgentsReply) ProtoMessage() {}
 
 func (x *StartAgentsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[3]
+	mi := &file_hub_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -173,7 +344,14 @@ func (x *StartAgentsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartAgentsReply.ProtoReflect.Descriptor instead.
 func (*StartAgentsReply) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{3}
+	return file_hub_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StartAgentsReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
 }
 
 type StatusAgentsRequest struct {
@@ -185,7 +363,7 @@ type StatusAgentsRequest struct {
 func (x *StatusAgentsRequest) Reset() {
 	*x = StatusAgentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[4]
+		mi := &file_hub_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -198,7 +376,7 @@ func (x *StatusAgentsRequest) String() string {
 func (*StatusAgentsRequest) ProtoMessage() {}
 
 func (x *StatusAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[4]
+	mi := &file_hub_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +389,7 @@ func (x *StatusAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusAgentsRequest.ProtoReflect.Descriptor instead.
 func (*StatusAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{4}
+	return file_hub_proto_rawDescGZIP(), []int{7}
 }
 
 type ServiceStatus struct {
@@ -219,16 +397,18 @@ type ServiceStatus struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Host   string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Uptime string `protobuf:"bytes,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
-	Pid    uint32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Host        string           `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Status      string           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Uptime      string           `protobuf:"bytes,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Pid         uint32           `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Certificate *CertificateInfo `protobuf:"bytes,5,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Warning     string           `protobuf:"bytes,6,opt,name=warning,proto3" json:"warning,omitempty"`
 }
 
 func (x *ServiceStatus) Reset() {
 	*x = ServiceStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[5]
+		mi := &file_hub_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -241,7 +421,7 @@ func (x *ServiceStatus) String() string {
 func (*ServiceStatus) ProtoMessage() {}
 
 func (x *ServiceStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[5]
+	mi := &file_hub_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +434,7 @@ func (x *ServiceStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceStatus.ProtoReflect.Descriptor instead.
 func (*ServiceStatus) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{5}
+	return file_hub_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ServiceStatus) GetHost() string {
@@ -285,18 +465,33 @@ func (x *ServiceStatus) GetPid() uint32 {
 	return 0
 }
 
+func (x *ServiceStatus) GetCertificate() *CertificateInfo {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *ServiceStatus) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
 type StatusAgentsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Statuses []*ServiceStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	Results  []*HostResult    `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 }
 
 func (x *StatusAgentsReply) Reset() {
 	*x = StatusAgentsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[6]
+		mi := &file_hub_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -309,7 +504,7 @@ func (x *StatusAgentsReply) String() string {
 func (*StatusAgentsReply) ProtoMessage() {}
 
 func (x *StatusAgentsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[6]
+	mi := &file_hub_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,7 +517,7 @@ func (x *StatusAgentsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusAgentsReply.ProtoReflect.Descriptor instead.
 func (*StatusAgentsReply) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{6}
+	return file_hub_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *StatusAgentsReply) GetStatuses() []*ServiceStatus {
@@ -332,6 +527,13 @@ func (x *StatusAgentsReply) GetStatuses() []*ServiceStatus {
 	return nil
 }
 
+func (x *StatusAgentsReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 type StopAgentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -341,7 +543,7 @@ type StopAgentsRequest struct {
 func (x *StopAgentsRequest) Reset() {
 	*x = StopAgentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[7]
+		mi := &file_hub_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -354,7 +556,7 @@ func (x *StopAgentsRequest) String() string {
 func (*StopAgentsRequest) ProtoMessage() {}
 
 func (x *StopAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[7]
+	mi := &file_hub_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,19 +569,21 @@ func (x *StopAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAgentsRequest.ProtoReflect.Descriptor instead.
 func (*StopAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{7}
+	return file_hub_proto_rawDescGZIP(), []int{10}
 }
 
 type StopAgentsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Results []*HostResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
 func (x *StopAgentsReply) Reset() {
 	*x = StopAgentsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hub_proto_msgTypes[8]
+		mi := &file_hub_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -392,7 +596,7 @@ func (x *StopAgentsReply) String() string {
 func (*StopAgentsReply) ProtoMessage() {}
 
 func (x *StopAgentsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_proto_msgTypes[8]
+	mi := &file_hub_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,137 +609,2097 @@ func (x *StopAgentsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopAgentsReply.ProtoReflect.Descriptor instead.
 func (*StopAgentsReply) Descriptor() ([]byte, []int) {
-	return file_hub_proto_rawDescGZIP(), []int{8}
+	return file_hub_proto_rawDescGZIP(), []int{11}
 }
 
-var File_hub_proto protoreflect.FileDescriptor
+func (x *StopAgentsReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
 
-var file_hub_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x68, 0x75, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x69, 0x64, 0x6c,
-	0x22, 0x10, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x15, 0x0a, 0x13,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x43, 0x0a, 0x11, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x2e, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22,
-	0x13, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xfa, 0x01, 0x0a, 0x03, 0x48, 0x75, 0x62, 0x12,
-	0x30, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74,
-	0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73,
-	0x12, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x42, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e,
-	0x74, 0x73, 0x12, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67,
-	0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41,
-	0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69,
-	0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x69, 0x64, 0x6c, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+// ExecRequest runs command on the given hosts, or on all hosts if none are
+// given.
+type ExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts   []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Command string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
 }
 
-var (
-	file_hub_proto_rawDescOnce sync.Once
-	file_hub_proto_rawDescData = file_hub_proto_rawDesc
-)
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_hub_proto_rawDescGZIP() []byte {
-	file_hub_proto_rawDescOnce.Do(func() {
-		file_hub_proto_rawDescData = protoimpl.X.CompressGZIP(file_hub_proto_rawDescData)
-	})
-	return file_hub_proto_rawDescData
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_hub_proto_goTypes = []interface{}{
-	(*StopHubRequest)(nil),      // 0: idl.StopHubRequest
-	(*StopHubReply)(nil),        // 1: idl.StopHubReply
-	(*StartAgentsRequest)(nil),  // 2: idl.StartAgentsRequest
-	(*StartAgentsReply)(nil),    // 3: idl.StartAgentsReply
-	(*StatusAgentsRequest)(nil), // 4: idl.StatusAgentsRequest
-	(*ServiceStatus)(nil),       // 5: idl.ServiceStatus
-	(*StatusAgentsReply)(nil),   // 6: idl.StatusAgentsReply
-	(*StopAgentsRequest)(nil),   // 7: idl.StopAgentsRequest
-	(*StopAgentsReply)(nil),     // 8: idl.StopAgentsReply
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_hub_proto_depIdxs = []int32{
-	5, // 0: idl.StatusAgentsReply.statuses:type_name -> idl.ServiceStatus
-	0, // 1: idl.Hub.Stop:input_type -> idl.StopHubRequest
-	2, // 2: idl.Hub.StartAgents:input_type -> idl.StartAgentsRequest
-	4, // 3: idl.Hub.StatusAgents:input_type -> idl.StatusAgentsRequest
-	7, // 4: idl.Hub.StopAgents:input_type -> idl.StopAgentsRequest
-	1, // 5: idl.Hub.Stop:output_type -> idl.StopHubReply
-	3, // 6: idl.Hub.StartAgents:output_type -> idl.StartAgentsReply
-	6, // 7: idl.Hub.StatusAgents:output_type -> idl.StatusAgentsReply
-	8, // 8: idl.Hub.StopAgents:output_type -> idl.StopAgentsReply
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{12}
 }
 
-func init() { file_hub_proto_init() }
-func file_hub_proto_init() {
-	if File_hub_proto != nil {
-		return
+func (x *ExecRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_hub_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopHubRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_hub_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopHubReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_hub_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartAgentsRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
+	return nil
+}
+
+func (x *ExecRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+// ExecReply carries a chunk of output from a single host. Once the command has
+// finished on every host, one reply per host is sent carrying its result.
+type ExecReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// Types that are assignable to Output:
+	//	*ExecReply_Stdout
+	//	*ExecReply_Stderr
+	//	*ExecReply_Result
+	Output isExecReply_Output `protobuf_oneof:"output"`
+}
+
+func (x *ExecReply) Reset() {
+	*x = ExecReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecReply) ProtoMessage() {}
+
+func (x *ExecReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecReply.ProtoReflect.Descriptor instead.
+func (*ExecReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecReply) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (m *ExecReply) GetOutput() isExecReply_Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (x *ExecReply) GetStdout() []byte {
+	if x, ok := x.GetOutput().(*ExecReply_Stdout); ok {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ExecReply) GetStderr() []byte {
+	if x, ok := x.GetOutput().(*ExecReply_Stderr); ok {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ExecReply) GetResult() *HostResult {
+	if x, ok := x.GetOutput().(*ExecReply_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+type isExecReply_Output interface {
+	isExecReply_Output()
+}
+
+type ExecReply_Stdout struct {
+	Stdout []byte `protobuf:"bytes,2,opt,name=stdout,proto3,oneof"`
+}
+
+type ExecReply_Stderr struct {
+	Stderr []byte `protobuf:"bytes,3,opt,name=stderr,proto3,oneof"`
+}
+
+type ExecReply_Result struct {
+	Result *HostResult `protobuf:"bytes,4,opt,name=result,proto3,oneof"`
+}
+
+func (*ExecReply_Stdout) isExecReply_Output() {}
+
+func (*ExecReply_Stderr) isExecReply_Output() {}
+
+func (*ExecReply_Result) isExecReply_Output() {}
+
+// CopyFileRequest copies the local file source, or the file source on
+// source_host if it is set, to destination on the given hosts, or on all hosts
+// if none are given.
+type CopyFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts       []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Source      string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string   `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	SourceHost  string   `protobuf:"bytes,4,opt,name=source_host,json=sourceHost,proto3" json:"source_host,omitempty"`
+}
+
+func (x *CopyFileRequest) Reset() {
+	*x = CopyFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileRequest) ProtoMessage() {}
+
+func (x *CopyFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileRequest.ProtoReflect.Descriptor instead.
+func (*CopyFileRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CopyFileRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *CopyFileRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *CopyFileRequest) GetSourceHost() string {
+	if x != nil {
+		return x.SourceHost
+	}
+	return ""
+}
+
+type CopyFileReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*HostResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CopyFileReply) Reset() {
+	*x = CopyFileReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyFileReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFileReply) ProtoMessage() {}
+
+func (x *CopyFileReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFileReply.ProtoReflect.Descriptor instead.
+func (*CopyFileReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CopyFileReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// AgentCertificatesRequest makes a new TLS connection to the given hosts, or
+// to all hosts if none are given, and reports the certificate each of their
+// agents presents.
+type AgentCertificatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *AgentCertificatesRequest) Reset() {
+	*x = AgentCertificatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentCertificatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCertificatesRequest) ProtoMessage() {}
+
+func (x *AgentCertificatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCertificatesRequest.ProtoReflect.Descriptor instead.
+func (*AgentCertificatesRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AgentCertificatesRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type AgentCertificatesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Certificates []*CertificateInfo `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	Results      []*HostResult      `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *AgentCertificatesReply) Reset() {
+	*x = AgentCertificatesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentCertificatesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCertificatesReply) ProtoMessage() {}
+
+func (x *AgentCertificatesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCertificatesReply.ProtoReflect.Descriptor instead.
+func (*AgentCertificatesReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AgentCertificatesReply) GetCertificates() []*CertificateInfo {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+func (x *AgentCertificatesReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// CollectLogsRequest asks the hub to collect the logs of the requested agent
+// hosts, or of all hosts if none are given, along with its own into a tar.gz
+// archive at destination on the hub host. See CollectLogsAgentRequest for the
+// remaining fields.
+type CollectLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts          []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Since          int64    `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until          int64    `protobuf:"varint,3,opt,name=until,proto3" json:"until,omitempty"`
+	SegmentLogDirs []string `protobuf:"bytes,4,rep,name=segment_log_dirs,json=segmentLogDirs,proto3" json:"segment_log_dirs,omitempty"`
+	Destination    string   `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+}
+
+func (x *CollectLogsRequest) Reset() {
+	*x = CollectLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectLogsRequest) ProtoMessage() {}
+
+func (x *CollectLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectLogsRequest.ProtoReflect.Descriptor instead.
+func (*CollectLogsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CollectLogsRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *CollectLogsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *CollectLogsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *CollectLogsRequest) GetSegmentLogDirs() []string {
+	if x != nil {
+		return x.SegmentLogDirs
+	}
+	return nil
+}
+
+func (x *CollectLogsRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type CollectLogsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*HostResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CollectLogsReply) Reset() {
+	*x = CollectLogsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectLogsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectLogsReply) ProtoMessage() {}
+
+func (x *CollectLogsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectLogsReply.ProtoReflect.Descriptor instead.
+func (*CollectLogsReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CollectLogsReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// TailLogsRequest tails the logs on the given hosts, or on all hosts if none
+// are given. See TailLogsAgentRequest for the remaining fields.
+type TailLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts    []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	DataDirs []string `protobuf:"bytes,2,rep,name=data_dirs,json=dataDirs,proto3" json:"data_dirs,omitempty"`
+	Lines    int32    `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	Follow   bool     `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	Severity string   `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	Pattern  string   `protobuf:"bytes,6,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (x *TailLogsRequest) Reset() {
+	*x = TailLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogsRequest) ProtoMessage() {}
+
+func (x *TailLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogsRequest.ProtoReflect.Descriptor instead.
+func (*TailLogsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TailLogsRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *TailLogsRequest) GetDataDirs() []string {
+	if x != nil {
+		return x.DataDirs
+	}
+	return nil
+}
+
+func (x *TailLogsRequest) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *TailLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *TailLogsRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *TailLogsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// TailLogsReply carries a log entry from a single host. Once the logs of a
+// host are no longer tailed, its result is sent instead.
+type TailLogsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// Types that are assignable to Output:
+	//	*TailLogsReply_Entry
+	//	*TailLogsReply_Result
+	Output isTailLogsReply_Output `protobuf_oneof:"output"`
+}
+
+func (x *TailLogsReply) Reset() {
+	*x = TailLogsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailLogsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogsReply) ProtoMessage() {}
+
+func (x *TailLogsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogsReply.ProtoReflect.Descriptor instead.
+func (*TailLogsReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TailLogsReply) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (m *TailLogsReply) GetOutput() isTailLogsReply_Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (x *TailLogsReply) GetEntry() *TailLogsAgentReply {
+	if x, ok := x.GetOutput().(*TailLogsReply_Entry); ok {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *TailLogsReply) GetResult() *HostResult {
+	if x, ok := x.GetOutput().(*TailLogsReply_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+type isTailLogsReply_Output interface {
+	isTailLogsReply_Output()
+}
+
+type TailLogsReply_Entry struct {
+	Entry *TailLogsAgentReply `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
+}
+
+type TailLogsReply_Result struct {
+	Result *HostResult `protobuf:"bytes,3,opt,name=result,proto3,oneof"`
+}
+
+func (*TailLogsReply_Entry) isTailLogsReply_Output() {}
+
+func (*TailLogsReply_Result) isTailLogsReply_Output() {}
+
+// HostInfoRequest asks for the state of the given hosts, or of all hosts if
+// none are given. See HostInfoAgentRequest for data_dirs.
+type HostInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts    []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	DataDirs []string `protobuf:"bytes,2,rep,name=data_dirs,json=dataDirs,proto3" json:"data_dirs,omitempty"`
+}
+
+func (x *HostInfoRequest) Reset() {
+	*x = HostInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostInfoRequest) ProtoMessage() {}
+
+func (x *HostInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostInfoRequest.ProtoReflect.Descriptor instead.
+func (*HostInfoRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HostInfoRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *HostInfoRequest) GetDataDirs() []string {
+	if x != nil {
+		return x.DataDirs
+	}
+	return nil
+}
+
+type HostInfoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts   []*HostInfo   `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Results []*HostResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *HostInfoReply) Reset() {
+	*x = HostInfoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostInfoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostInfoReply) ProtoMessage() {}
+
+func (x *HostInfoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostInfoReply.ProtoReflect.Descriptor instead.
+func (*HostInfoReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HostInfoReply) GetHosts() []*HostInfo {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *HostInfoReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// VersionRequest asks for the versions of gp running on the hub and on all
+// agent hosts. client is the version of the caller.
+type VersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Client *VersionInfo `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+}
+
+func (x *VersionRequest) Reset() {
+	*x = VersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionRequest) ProtoMessage() {}
+
+func (x *VersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VersionRequest) GetClient() *VersionInfo {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+type VersionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hub     *VersionInfo   `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	Agents  []*VersionInfo `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
+	Results []*HostResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *VersionReply) Reset() {
+	*x = VersionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionReply) ProtoMessage() {}
+
+func (x *VersionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionReply.ProtoReflect.Descriptor instead.
+func (*VersionReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VersionReply) GetHub() *VersionInfo {
+	if x != nil {
+		return x.Hub
+	}
+	return nil
+}
+
+func (x *VersionReply) GetAgents() []*VersionInfo {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *VersionReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// UpgradeToolsRequest installs the gp binary at the absolute path binary on the
+// hub host in GPHOME on every host, restarting batch_size agents at a time.
+type UpgradeToolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Binary    string `protobuf:"bytes,1,opt,name=binary,proto3" json:"binary,omitempty"`
+	BatchSize int32  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+}
+
+func (x *UpgradeToolsRequest) Reset() {
+	*x = UpgradeToolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeToolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeToolsRequest) ProtoMessage() {}
+
+func (x *UpgradeToolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeToolsRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeToolsRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpgradeToolsRequest) GetBinary() string {
+	if x != nil {
+		return x.Binary
+	}
+	return ""
+}
+
+func (x *UpgradeToolsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// UpgradeToolsReply reports the progress of an upgrade, and once it is over
+// its outcome on every host.
+type UpgradeToolsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*UpgradeToolsReply_Progress
+	//	*UpgradeToolsReply_Result
+	Update isUpgradeToolsReply_Update `protobuf_oneof:"update"`
+}
+
+func (x *UpgradeToolsReply) Reset() {
+	*x = UpgradeToolsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeToolsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeToolsReply) ProtoMessage() {}
+
+func (x *UpgradeToolsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeToolsReply.ProtoReflect.Descriptor instead.
+func (*UpgradeToolsReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{27}
+}
+
+func (m *UpgradeToolsReply) GetUpdate() isUpgradeToolsReply_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *UpgradeToolsReply) GetProgress() string {
+	if x, ok := x.GetUpdate().(*UpgradeToolsReply_Progress); ok {
+		return x.Progress
+	}
+	return ""
+}
+
+func (x *UpgradeToolsReply) GetResult() *HostResult {
+	if x, ok := x.GetUpdate().(*UpgradeToolsReply_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+type isUpgradeToolsReply_Update interface {
+	isUpgradeToolsReply_Update()
+}
+
+type UpgradeToolsReply_Progress struct {
+	Progress string `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type UpgradeToolsReply_Result struct {
+	Result *HostResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*UpgradeToolsReply_Progress) isUpgradeToolsReply_Update() {}
+
+func (*UpgradeToolsReply_Result) isUpgradeToolsReply_Update() {}
+
+// ReloadConfigRequest makes the hub pick up changes to the hosts in its
+// configuration file.
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{28}
+}
+
+type ReloadConfigReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AddedHosts   []string `protobuf:"bytes,1,rep,name=added_hosts,json=addedHosts,proto3" json:"added_hosts,omitempty"`
+	RemovedHosts []string `protobuf:"bytes,2,rep,name=removed_hosts,json=removedHosts,proto3" json:"removed_hosts,omitempty"`
+}
+
+func (x *ReloadConfigReply) Reset() {
+	*x = ReloadConfigReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigReply) ProtoMessage() {}
+
+func (x *ReloadConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigReply.ProtoReflect.Descriptor instead.
+func (*ReloadConfigReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReloadConfigReply) GetAddedHosts() []string {
+	if x != nil {
+		return x.AddedHosts
+	}
+	return nil
+}
+
+func (x *ReloadConfigReply) GetRemovedHosts() []string {
+	if x != nil {
+		return x.RemovedHosts
+	}
+	return nil
+}
+
+// CheckConfigRequest compares the configuration file on the given hosts, or on
+// all hosts if none are given, with that of the hub. The reply carries the
+// checksum of the file of the hub, and a drift for every host whose file
+// differs from it.
+type CheckConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *CheckConfigRequest) Reset() {
+	*x = CheckConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConfigRequest) ProtoMessage() {}
+
+func (x *CheckConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConfigRequest.ProtoReflect.Descriptor instead.
+func (*CheckConfigRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CheckConfigRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type CheckConfigReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sha256  string         `protobuf:"bytes,1,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Drifts  []*ConfigDrift `protobuf:"bytes,2,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	Results []*HostResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CheckConfigReply) Reset() {
+	*x = CheckConfigReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckConfigReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConfigReply) ProtoMessage() {}
+
+func (x *CheckConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConfigReply.ProtoReflect.Descriptor instead.
+func (*CheckConfigReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CheckConfigReply) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *CheckConfigReply) GetDrifts() []*ConfigDrift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+func (x *CheckConfigReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// ConfigDrift describes how the configuration file on a host differs from that
+// of the hub. fields is empty if the files only differ in formatting, and error
+// is set if the file on the host is missing or could not be parsed.
+type ConfigDrift struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host   string             `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Sha256 string             `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Fields []*ConfigFieldDiff `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	Error  string             `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ConfigDrift) Reset() {
+	*x = ConfigDrift{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDrift) ProtoMessage() {}
+
+func (x *ConfigDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDrift.ProtoReflect.Descriptor instead.
+func (*ConfigDrift) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ConfigDrift) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ConfigDrift) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *ConfigDrift) GetFields() []*ConfigFieldDiff {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ConfigDrift) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ConfigFieldDiff is a key whose value differs, named by its path such as
+// Credentials.caCert. The values are encoded as JSON, and are empty where the
+// key is not set.
+type ConfigFieldDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	HubValue  string `protobuf:"bytes,2,opt,name=hub_value,json=hubValue,proto3" json:"hub_value,omitempty"`
+	HostValue string `protobuf:"bytes,3,opt,name=host_value,json=hostValue,proto3" json:"host_value,omitempty"`
+}
+
+func (x *ConfigFieldDiff) Reset() {
+	*x = ConfigFieldDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigFieldDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigFieldDiff) ProtoMessage() {}
+
+func (x *ConfigFieldDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigFieldDiff.ProtoReflect.Descriptor instead.
+func (*ConfigFieldDiff) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ConfigFieldDiff) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigFieldDiff) GetHubValue() string {
+	if x != nil {
+		return x.HubValue
+	}
+	return ""
+}
+
+func (x *ConfigFieldDiff) GetHostValue() string {
+	if x != nil {
+		return x.HostValue
+	}
+	return ""
+}
+
+// SyncConfigRequest copies the configuration file of the hub to those of the
+// given hosts, or of all hosts if none are given, whose file differs from it.
+type SyncConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *SyncConfigRequest) Reset() {
+	*x = SyncConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncConfigRequest) ProtoMessage() {}
+
+func (x *SyncConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncConfigRequest.ProtoReflect.Descriptor instead.
+func (*SyncConfigRequest) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SyncConfigRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type SyncConfigReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SyncedHosts []string      `protobuf:"bytes,1,rep,name=synced_hosts,json=syncedHosts,proto3" json:"synced_hosts,omitempty"`
+	Results     []*HostResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SyncConfigReply) Reset() {
+	*x = SyncConfigReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hub_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncConfigReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncConfigReply) ProtoMessage() {}
+
+func (x *SyncConfigReply) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncConfigReply.ProtoReflect.Descriptor instead.
+func (*SyncConfigReply) Descriptor() ([]byte, []int) {
+	return file_hub_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SyncConfigReply) GetSyncedHosts() []string {
+	if x != nil {
+		return x.SyncedHosts
+	}
+	return nil
+}
+
+func (x *SyncConfigReply) GetResults() []*HostResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_hub_proto protoreflect.FileDescriptor
+
+var file_hub_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x68, 0x75, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x69, 0x64, 0x6c,
+	0x1a, 0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x10, 0x0a,
+	0x0e, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x12, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x75, 0x62,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x68, 0x0a,
+	0x0a, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2a, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x3d, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x0d, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x22, 0x6e, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e,
+	0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3c, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x3d, 0x0a, 0x0b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0x88, 0x01, 0x0a, 0x09, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75,
+	0x74, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x29, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x22, 0x82, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x68,
+	0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x0d, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x30, 0x0a, 0x18, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x7d, 0x0a, 0x16, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a,
+	0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x4c, 0x6f,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x6f,
+	0x67, 0x44, 0x69, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x0f, 0x54, 0x61, 0x69, 0x6c, 0x4c,
+	0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x64, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x22, 0x89, 0x01, 0x0a, 0x0d, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x54, 0x61, 0x69,
+	0x6c, 0x4c, 0x6f, 0x67, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48,
+	0x00, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x44, 0x0a,
+	0x0f, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x64,
+	0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x44,
+	0x69, 0x72, 0x73, 0x22, 0x5f, 0x0a, 0x0d, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x22, 0x3a, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x22, 0x87, 0x01, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x22, 0x0a, 0x03, 0x68, 0x75, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x03, 0x68, 0x75, 0x62, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x4c, 0x0a, 0x13, 0x55, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x66, 0x0a, 0x11, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1f, 0x0a, 0x0b,
+	0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x48, 0x6f, 0x73,
+	0x74, 0x73, 0x22, 0x2a, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x7f,
+	0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x28, 0x0a, 0x06, 0x64, 0x72,
+	0x69, 0x66, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x52, 0x06, 0x64, 0x72,
+	0x69, 0x66, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22,
+	0x7d, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x69, 0x66, 0x66,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x5f,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x69, 0x66,
+	0x66, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x75, 0x62, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x75, 0x62, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x29, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x5f, 0x0a, 0x0f, 0x53, 0x79,
+	0x6e, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x48, 0x6f, 0x73, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x32, 0xdc, 0x07, 0x0a, 0x03,
+	0x48, 0x75, 0x62, 0x12, 0x30, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x13, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x48, 0x75, 0x62, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x75, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x48, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a,
+	0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x42,
+	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18,
+	0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53,
+	0x74, 0x6f, 0x70, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x2c, 0x0a, 0x04, 0x45, 0x78, 0x65, 0x63, 0x12, 0x10, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x45,
+	0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x36,
+	0x0a, 0x08, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x11, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x4c,
+	0x6f, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x08, 0x54, 0x61,
+	0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x54, 0x61, 0x69,
+	0x6c, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x48, 0x6f, 0x73,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x07,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69,
+	0x64, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x0c, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x6f, 0x6f, 0x6c,
+	0x73, 0x12, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54,
+	0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x64,
+	0x6c, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65,
+	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x17, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0a,
+	0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x2e, 0x69, 0x64, 0x6c,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x64, 0x6c, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e,
+	0x2f, 0x69, 0x64, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hub_proto_rawDescOnce sync.Once
+	file_hub_proto_rawDescData = file_hub_proto_rawDesc
+)
+
+func file_hub_proto_rawDescGZIP() []byte {
+	file_hub_proto_rawDescOnce.Do(func() {
+		file_hub_proto_rawDescData = protoimpl.X.CompressGZIP(file_hub_proto_rawDescData)
+	})
+	return file_hub_proto_rawDescData
+}
+
+var file_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_hub_proto_goTypes = []interface{}{
+	(*StopHubRequest)(nil),           // 0: idl.StopHubRequest
+	(*StopHubReply)(nil),             // 1: idl.StopHubReply
+	(*StatusHubRequest)(nil),         // 2: idl.StatusHubRequest
+	(*StatusHubReply)(nil),           // 3: idl.StatusHubReply
+	(*HostResult)(nil),               // 4: idl.HostResult
+	(*StartAgentsRequest)(nil),       // 5: idl.StartAgentsRequest
+	(*StartAgentsReply)(nil),         // 6: idl.StartAgentsReply
+	(*StatusAgentsRequest)(nil),      // 7: idl.StatusAgentsRequest
+	(*ServiceStatus)(nil),            // 8: idl.ServiceStatus
+	(*StatusAgentsReply)(nil),        // 9: idl.StatusAgentsReply
+	(*StopAgentsRequest)(nil),        // 10: idl.StopAgentsRequest
+	(*StopAgentsReply)(nil),          // 11: idl.StopAgentsReply
+	(*ExecRequest)(nil),              // 12: idl.ExecRequest
+	(*ExecReply)(nil),                // 13: idl.ExecReply
+	(*CopyFileRequest)(nil),          // 14: idl.CopyFileRequest
+	(*CopyFileReply)(nil),            // 15: idl.CopyFileReply
+	(*AgentCertificatesRequest)(nil), // 16: idl.AgentCertificatesRequest
+	(*AgentCertificatesReply)(nil),   // 17: idl.AgentCertificatesReply
+	(*CollectLogsRequest)(nil),       // 18: idl.CollectLogsRequest
+	(*CollectLogsReply)(nil),         // 19: idl.CollectLogsReply
+	(*TailLogsRequest)(nil),          // 20: idl.TailLogsRequest
+	(*TailLogsReply)(nil),            // 21: idl.TailLogsReply
+	(*HostInfoRequest)(nil),          // 22: idl.HostInfoRequest
+	(*HostInfoReply)(nil),            // 23: idl.HostInfoReply
+	(*VersionRequest)(nil),           // 24: idl.VersionRequest
+	(*VersionReply)(nil),             // 25: idl.VersionReply
+	(*UpgradeToolsRequest)(nil),      // 26: idl.UpgradeToolsRequest
+	(*UpgradeToolsReply)(nil),        // 27: idl.UpgradeToolsReply
+	(*ReloadConfigRequest)(nil),      // 28: idl.ReloadConfigRequest
+	(*ReloadConfigReply)(nil),        // 29: idl.ReloadConfigReply
+	(*CheckConfigRequest)(nil),       // 30: idl.CheckConfigRequest
+	(*CheckConfigReply)(nil),         // 31: idl.CheckConfigReply
+	(*ConfigDrift)(nil),              // 32: idl.ConfigDrift
+	(*ConfigFieldDiff)(nil),          // 33: idl.ConfigFieldDiff
+	(*SyncConfigRequest)(nil),        // 34: idl.SyncConfigRequest
+	(*SyncConfigReply)(nil),          // 35: idl.SyncConfigReply
+	(*CertificateInfo)(nil),          // 36: idl.CertificateInfo
+	(*TailLogsAgentReply)(nil),       // 37: idl.TailLogsAgentReply
+	(*HostInfo)(nil),                 // 38: idl.HostInfo
+	(*VersionInfo)(nil),              // 39: idl.VersionInfo
+}
+var file_hub_proto_depIdxs = []int32{
+	36, // 0: idl.StatusHubReply.certificate:type_name -> idl.CertificateInfo
+	4,  // 1: idl.StartAgentsReply.results:type_name -> idl.HostResult
+	36, // 2: idl.ServiceStatus.certificate:type_name -> idl.CertificateInfo
+	8,  // 3: idl.StatusAgentsReply.statuses:type_name -> idl.ServiceStatus
+	4,  // 4: idl.StatusAgentsReply.results:type_name -> idl.HostResult
+	4,  // 5: idl.StopAgentsReply.results:type_name -> idl.HostResult
+	4,  // 6: idl.ExecReply.result:type_name -> idl.HostResult
+	4,  // 7: idl.CopyFileReply.results:type_name -> idl.HostResult
+	36, // 8: idl.AgentCertificatesReply.certificates:type_name -> idl.CertificateInfo
+	4,  // 9: idl.AgentCertificatesReply.results:type_name -> idl.HostResult
+	4,  // 10: idl.CollectLogsReply.results:type_name -> idl.HostResult
+	37, // 11: idl.TailLogsReply.entry:type_name -> idl.TailLogsAgentReply
+	4,  // 12: idl.TailLogsReply.result:type_name -> idl.HostResult
+	38, // 13: idl.HostInfoReply.hosts:type_name -> idl.HostInfo
+	4,  // 14: idl.HostInfoReply.results:type_name -> idl.HostResult
+	39, // 15: idl.VersionRequest.client:type_name -> idl.VersionInfo
+	39, // 16: idl.VersionReply.hub:type_name -> idl.VersionInfo
+	39, // 17: idl.VersionReply.agents:type_name -> idl.VersionInfo
+	4,  // 18: idl.VersionReply.results:type_name -> idl.HostResult
+	4,  // 19: idl.UpgradeToolsReply.result:type_name -> idl.HostResult
+	32, // 20: idl.CheckConfigReply.drifts:type_name -> idl.ConfigDrift
+	4,  // 21: idl.CheckConfigReply.results:type_name -> idl.HostResult
+	33, // 22: idl.ConfigDrift.fields:type_name -> idl.ConfigFieldDiff
+	4,  // 23: idl.SyncConfigReply.results:type_name -> idl.HostResult
+	0,  // 24: idl.Hub.Stop:input_type -> idl.StopHubRequest
+	2,  // 25: idl.Hub.Status:input_type -> idl.StatusHubRequest
+	5,  // 26: idl.Hub.StartAgents:input_type -> idl.StartAgentsRequest
+	7,  // 27: idl.Hub.StatusAgents:input_type -> idl.StatusAgentsRequest
+	10, // 28: idl.Hub.StopAgents:input_type -> idl.StopAgentsRequest
+	12, // 29: idl.Hub.Exec:input_type -> idl.ExecRequest
+	14, // 30: idl.Hub.CopyFile:input_type -> idl.CopyFileRequest
+	16, // 31: idl.Hub.AgentCertificates:input_type -> idl.AgentCertificatesRequest
+	18, // 32: idl.Hub.CollectLogs:input_type -> idl.CollectLogsRequest
+	20, // 33: idl.Hub.TailLogs:input_type -> idl.TailLogsRequest
+	22, // 34: idl.Hub.HostInfo:input_type -> idl.HostInfoRequest
+	24, // 35: idl.Hub.Version:input_type -> idl.VersionRequest
+	26, // 36: idl.Hub.UpgradeTools:input_type -> idl.UpgradeToolsRequest
+	28, // 37: idl.Hub.ReloadConfig:input_type -> idl.ReloadConfigRequest
+	30, // 38: idl.Hub.CheckConfig:input_type -> idl.CheckConfigRequest
+	34, // 39: idl.Hub.SyncConfig:input_type -> idl.SyncConfigRequest
+	1,  // 40: idl.Hub.Stop:output_type -> idl.StopHubReply
+	3,  // 41: idl.Hub.Status:output_type -> idl.StatusHubReply
+	6,  // 42: idl.Hub.StartAgents:output_type -> idl.StartAgentsReply
+	9,  // 43: idl.Hub.StatusAgents:output_type -> idl.StatusAgentsReply
+	11, // 44: idl.Hub.StopAgents:output_type -> idl.StopAgentsReply
+	13, // 45: idl.Hub.Exec:output_type -> idl.ExecReply
+	15, // 46: idl.Hub.CopyFile:output_type -> idl.CopyFileReply
+	17, // 47: idl.Hub.AgentCertificates:output_type -> idl.AgentCertificatesReply
+	19, // 48: idl.Hub.CollectLogs:output_type -> idl.CollectLogsReply
+	21, // 49: idl.Hub.TailLogs:output_type -> idl.TailLogsReply
+	23, // 50: idl.Hub.HostInfo:output_type -> idl.HostInfoReply
+	25, // 51: idl.Hub.Version:output_type -> idl.VersionReply
+	27, // 52: idl.Hub.UpgradeTools:output_type -> idl.UpgradeToolsReply
+	29, // 53: idl.Hub.ReloadConfig:output_type -> idl.ReloadConfigReply
+	31, // 54: idl.Hub.CheckConfig:output_type -> idl.CheckConfigReply
+	35, // 55: idl.Hub.SyncConfig:output_type -> idl.SyncConfigReply
+	40, // [40:56] is the sub-list for method output_type
+	24, // [24:40] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_hub_proto_init() }
+func file_hub_proto_init() {
+	if File_hub_proto != nil {
+		return
+	}
+	file_agent_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_hub_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopHubRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopHubReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusHubRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusHubReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartAgentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartAgentsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusAgentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusAgentsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopAgentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopAgentsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
-		file_hub_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartAgentsReply); i {
+		file_hub_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyFileReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -546,8 +2710,8 @@ func file_hub_proto_init() {
 				return nil
 			}
 		}
-		file_hub_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusAgentsRequest); i {
+		file_hub_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentCertificatesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -558,8 +2722,8 @@ func file_hub_proto_init() {
 				return nil
 			}
 		}
-		file_hub_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServiceStatus); i {
+		file_hub_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentCertificatesReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -570,8 +2734,8 @@ func file_hub_proto_init() {
 				return nil
 			}
 		}
-		file_hub_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusAgentsReply); i {
+		file_hub_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectLogsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -582,8 +2746,8 @@ func file_hub_proto_init() {
 				return nil
 			}
 		}
-		file_hub_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopAgentsRequest); i {
+		file_hub_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectLogsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -594,8 +2758,188 @@ func file_hub_proto_init() {
 				return nil
 			}
 		}
-		file_hub_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopAgentsReply); i {
+		file_hub_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TailLogsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostInfoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeToolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpgradeToolsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckConfigReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigFieldDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hub_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncConfigReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -607,13 +2951,26 @@ func file_hub_proto_init() {
 			}
 		}
 	}
+	file_hub_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*ExecReply_Stdout)(nil),
+		(*ExecReply_Stderr)(nil),
+		(*ExecReply_Result)(nil),
+	}
+	file_hub_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*TailLogsReply_Entry)(nil),
+		(*TailLogsReply_Result)(nil),
+	}
+	file_hub_proto_msgTypes[27].OneofWrappers = []interface{}{
+		(*UpgradeToolsReply_Progress)(nil),
+		(*UpgradeToolsReply_Result)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_hub_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -627,62 +2984,251 @@ func file_hub_proto_init() {
 	file_hub_proto_depIdxs = nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// HubClient is the client API for Hub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HubClient interface {
+	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
+	Status(ctx context.Context, in *StatusHubRequest, opts ...grpc.CallOption) (*StatusHubReply, error)
+	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
+	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
+	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (Hub_ExecClient, error)
+	CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*CopyFileReply, error)
+	AgentCertificates(ctx context.Context, in *AgentCertificatesRequest, opts ...grpc.CallOption) (*AgentCertificatesReply, error)
+	CollectLogs(ctx context.Context, in *CollectLogsRequest, opts ...grpc.CallOption) (*CollectLogsReply, error)
+	TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (Hub_TailLogsClient, error)
+	HostInfo(ctx context.Context, in *HostInfoRequest, opts ...grpc.CallOption) (*HostInfoReply, error)
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionReply, error)
+	UpgradeTools(ctx context.Context, in *UpgradeToolsRequest, opts ...grpc.CallOption) (Hub_UpgradeToolsClient, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigReply, error)
+	CheckConfig(ctx context.Context, in *CheckConfigRequest, opts ...grpc.CallOption) (*CheckConfigReply, error)
+	SyncConfig(ctx context.Context, in *SyncConfigRequest, opts ...grpc.CallOption) (*SyncConfigReply, error)
+}
+
+type hubClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHubClient(cc grpc.ClientConnInterface) HubClient {
+	return &hubClient{cc}
+}
+
+func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
+	out := new(StopHubReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) Status(ctx context.Context, in *StatusHubRequest, opts ...grpc.CallOption) (*StatusHubReply, error) {
+	out := new(StatusHubReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
+	out := new(StartAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
+	out := new(StatusAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
+	out := new(StopAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (Hub_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[0], "/idl.Hub/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_ExecClient interface {
+	Recv() (*ExecReply, error)
+	grpc.ClientStream
+}
+
+type hubExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubExecClient) Recv() (*ExecReply, error) {
+	m := new(ExecReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) CopyFile(ctx context.Context, in *CopyFileRequest, opts ...grpc.CallOption) (*CopyFileReply, error) {
+	out := new(CopyFileReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CopyFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) AgentCertificates(ctx context.Context, in *AgentCertificatesRequest, opts ...grpc.CallOption) (*AgentCertificatesReply, error) {
+	out := new(AgentCertificatesReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/AgentCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (c *hubClient) CollectLogs(ctx context.Context, in *CollectLogsRequest, opts ...grpc.CallOption) (*CollectLogsReply, error) {
+	out := new(CollectLogsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CollectLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// HubClient is the client API for Hub service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HubClient interface {
-	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
-	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
-	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
-	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
+func (c *hubClient) TailLogs(ctx context.Context, in *TailLogsRequest, opts ...grpc.CallOption) (Hub_TailLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[1], "/idl.Hub/TailLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubTailLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type hubClient struct {
-	cc grpc.ClientConnInterface
+type Hub_TailLogsClient interface {
+	Recv() (*TailLogsReply, error)
+	grpc.ClientStream
 }
 
-func NewHubClient(cc grpc.ClientConnInterface) HubClient {
-	return &hubClient{cc}
+type hubTailLogsClient struct {
+	grpc.ClientStream
 }
 
-func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
-	out := new(StopHubReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
+func (x *hubTailLogsClient) Recv() (*TailLogsReply, error) {
+	m := new(TailLogsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) HostInfo(ctx context.Context, in *HostInfoRequest, opts ...grpc.CallOption) (*HostInfoReply, error) {
+	out := new(HostInfoReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/HostInfo", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
-	out := new(StartAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
+func (c *hubClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionReply, error) {
+	out := new(VersionReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/Version", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
-	out := new(StatusAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
+func (c *hubClient) UpgradeTools(ctx context.Context, in *UpgradeToolsRequest, opts ...grpc.CallOption) (Hub_UpgradeToolsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[2], "/idl.Hub/UpgradeTools", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubUpgradeToolsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_UpgradeToolsClient interface {
+	Recv() (*UpgradeToolsReply, error)
+	grpc.ClientStream
+}
+
+type hubUpgradeToolsClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubUpgradeToolsClient) Recv() (*UpgradeToolsReply, error) {
+	m := new(UpgradeToolsReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigReply, error) {
+	out := new(ReloadConfigReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ReloadConfig", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
-	out := new(StopAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
+func (c *hubClient) CheckConfig(ctx context.Context, in *CheckConfigRequest, opts ...grpc.CallOption) (*CheckConfigReply, error) {
+	out := new(CheckConfigReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CheckConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) SyncConfig(ctx context.Context, in *SyncConfigRequest, opts ...grpc.CallOption) (*SyncConfigReply, error) {
+	out := new(SyncConfigReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/SyncConfig", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -692,9 +3238,21 @@ func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts
 // HubServer is the server API for Hub service.
 type HubServer interface {
 	Stop(context.Context, *StopHubRequest) (*StopHubReply, error)
+	Status(context.Context, *StatusHubRequest) (*StatusHubReply, error)
 	StartAgents(context.Context, *StartAgentsRequest) (*StartAgentsReply, error)
 	StatusAgents(context.Context, *StatusAgentsRequest) (*StatusAgentsReply, error)
 	StopAgents(context.Context, *StopAgentsRequest) (*StopAgentsReply, error)
+	Exec(*ExecRequest, Hub_ExecServer) error
+	CopyFile(context.Context, *CopyFileRequest) (*CopyFileReply, error)
+	AgentCertificates(context.Context, *AgentCertificatesRequest) (*AgentCertificatesReply, error)
+	CollectLogs(context.Context, *CollectLogsRequest) (*CollectLogsReply, error)
+	TailLogs(*TailLogsRequest, Hub_TailLogsServer) error
+	HostInfo(context.Context, *HostInfoRequest) (*HostInfoReply, error)
+	Version(context.Context, *VersionRequest) (*VersionReply, error)
+	UpgradeTools(*UpgradeToolsRequest, Hub_UpgradeToolsServer) error
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigReply, error)
+	CheckConfig(context.Context, *CheckConfigRequest) (*CheckConfigReply, error)
+	SyncConfig(context.Context, *SyncConfigRequest) (*SyncConfigReply, error)
 }
 
 // UnimplementedHubServer can be embedded to have forward compatible implementations.
@@ -704,6 +3262,9 @@ type UnimplementedHubServer struct {
 func (*UnimplementedHubServer) Stop(context.Context, *StopHubRequest) (*StopHubReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (*UnimplementedHubServer) Status(context.Context, *StatusHubRequest) (*StatusHubReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
 func (*UnimplementedHubServer) StartAgents(context.Context, *StartAgentsRequest) (*StartAgentsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartAgents not implemented")
 }
@@ -713,6 +3274,39 @@ func (*UnimplementedHubServer) StatusAgents(context.Context, *StatusAgentsReques
 func (*UnimplementedHubServer) StopAgents(context.Context, *StopAgentsRequest) (*StopAgentsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopAgents not implemented")
 }
+func (*UnimplementedHubServer) Exec(*ExecRequest, Hub_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (*UnimplementedHubServer) CopyFile(context.Context, *CopyFileRequest) (*CopyFileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFile not implemented")
+}
+func (*UnimplementedHubServer) AgentCertificates(context.Context, *AgentCertificatesRequest) (*AgentCertificatesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AgentCertificates not implemented")
+}
+func (*UnimplementedHubServer) CollectLogs(context.Context, *CollectLogsRequest) (*CollectLogsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectLogs not implemented")
+}
+func (*UnimplementedHubServer) TailLogs(*TailLogsRequest, Hub_TailLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TailLogs not implemented")
+}
+func (*UnimplementedHubServer) HostInfo(context.Context, *HostInfoRequest) (*HostInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HostInfo not implemented")
+}
+func (*UnimplementedHubServer) Version(context.Context, *VersionRequest) (*VersionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (*UnimplementedHubServer) UpgradeTools(*UpgradeToolsRequest, Hub_UpgradeToolsServer) error {
+	return status.Errorf(codes.Unimplemented, "method UpgradeTools not implemented")
+}
+func (*UnimplementedHubServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (*UnimplementedHubServer) CheckConfig(context.Context, *CheckConfigRequest) (*CheckConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConfig not implemented")
+}
+func (*UnimplementedHubServer) SyncConfig(context.Context, *SyncConfigRequest) (*SyncConfigReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncConfig not implemented")
+}
 
 func RegisterHubServer(s *grpc.Server, srv HubServer) {
 	s.RegisterService(&_Hub_serviceDesc, srv)
@@ -736,6 +3330,24 @@ func _Hub_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).Status(ctx, req.(*StatusHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Hub_StartAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartAgentsRequest)
 	if err := dec(in); err != nil {
@@ -790,6 +3402,213 @@ func _Hub_StopAgents_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).Exec(m, &hubExecServer{stream})
+}
+
+type Hub_ExecServer interface {
+	Send(*ExecReply) error
+	grpc.ServerStream
+}
+
+type hubExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubExecServer) Send(m *ExecReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_CopyFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CopyFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CopyFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CopyFile(ctx, req.(*CopyFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_AgentCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).AgentCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/AgentCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).AgentCertificates(ctx, req.(*AgentCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CollectLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CollectLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CollectLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CollectLogs(ctx, req.(*CollectLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_TailLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).TailLogs(m, &hubTailLogsServer{stream})
+}
+
+type Hub_TailLogsServer interface {
+	Send(*TailLogsReply) error
+	grpc.ServerStream
+}
+
+type hubTailLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubTailLogsServer) Send(m *TailLogsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_HostInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).HostInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/HostInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).HostInfo(ctx, req.(*HostInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_UpgradeTools_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpgradeToolsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).UpgradeTools(m, &hubUpgradeToolsServer{stream})
+}
+
+type Hub_UpgradeToolsServer interface {
+	Send(*UpgradeToolsReply) error
+	grpc.ServerStream
+}
+
+type hubUpgradeToolsServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubUpgradeToolsServer) Send(m *UpgradeToolsReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CheckConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CheckConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CheckConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CheckConfig(ctx, req.(*CheckConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_SyncConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).SyncConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/SyncConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).SyncConfig(ctx, req.(*SyncConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Hub",
 	HandlerType: (*HubServer)(nil),
@@ -798,6 +3617,10 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _Hub_Stop_Handler,
 		},
+		{
+			MethodName: "Status",
+			Handler:    _Hub_Status_Handler,
+		},
 		{
 			MethodName: "StartAgents",
 			Handler:    _Hub_StartAgents_Handler,
@@ -810,7 +3633,55 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopAgents",
 			Handler:    _Hub_StopAgents_Handler,
 		},
+		{
+			MethodName: "CopyFile",
+			Handler:    _Hub_CopyFile_Handler,
+		},
+		{
+			MethodName: "AgentCertificates",
+			Handler:    _Hub_AgentCertificates_Handler,
+		},
+		{
+			MethodName: "CollectLogs",
+			Handler:    _Hub_CollectLogs_Handler,
+		},
+		{
+			MethodName: "HostInfo",
+			Handler:    _Hub_HostInfo_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _Hub_Version_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Hub_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "CheckConfig",
+			Handler:    _Hub_CheckConfig_Handler,
+		},
+		{
+			MethodName: "SyncConfig",
+			Handler:    _Hub_SyncConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Exec",
+			Handler:       _Hub_Exec_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailLogs",
+			Handler:       _Hub_TailLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UpgradeTools",
+			Handler:       _Hub_UpgradeTools_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "hub.proto",
 }
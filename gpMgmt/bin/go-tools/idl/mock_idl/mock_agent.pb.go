@@ -11,8 +11,114 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpdb/gp/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
+// MockisExecAgentReply_Output is a mock of isExecAgentReply_Output interface.
+type MockisExecAgentReply_Output struct {
+	ctrl     *gomock.Controller
+	recorder *MockisExecAgentReply_OutputMockRecorder
+}
+
+// MockisExecAgentReply_OutputMockRecorder is the mock recorder for MockisExecAgentReply_Output.
+type MockisExecAgentReply_OutputMockRecorder struct {
+	mock *MockisExecAgentReply_Output
+}
+
+// NewMockisExecAgentReply_Output creates a new mock instance.
+func NewMockisExecAgentReply_Output(ctrl *gomock.Controller) *MockisExecAgentReply_Output {
+	mock := &MockisExecAgentReply_Output{ctrl: ctrl}
+	mock.recorder = &MockisExecAgentReply_OutputMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisExecAgentReply_Output) EXPECT() *MockisExecAgentReply_OutputMockRecorder {
+	return m.recorder
+}
+
+// isExecAgentReply_Output mocks base method.
+func (m *MockisExecAgentReply_Output) isExecAgentReply_Output() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isExecAgentReply_Output")
+}
+
+// isExecAgentReply_Output indicates an expected call of isExecAgentReply_Output.
+func (mr *MockisExecAgentReply_OutputMockRecorder) isExecAgentReply_Output() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isExecAgentReply_Output", reflect.TypeOf((*MockisExecAgentReply_Output)(nil).isExecAgentReply_Output))
+}
+
+// MockisPutFileRequest_Data is a mock of isPutFileRequest_Data interface.
+type MockisPutFileRequest_Data struct {
+	ctrl     *gomock.Controller
+	recorder *MockisPutFileRequest_DataMockRecorder
+}
+
+// MockisPutFileRequest_DataMockRecorder is the mock recorder for MockisPutFileRequest_Data.
+type MockisPutFileRequest_DataMockRecorder struct {
+	mock *MockisPutFileRequest_Data
+}
+
+// NewMockisPutFileRequest_Data creates a new mock instance.
+func NewMockisPutFileRequest_Data(ctrl *gomock.Controller) *MockisPutFileRequest_Data {
+	mock := &MockisPutFileRequest_Data{ctrl: ctrl}
+	mock.recorder = &MockisPutFileRequest_DataMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisPutFileRequest_Data) EXPECT() *MockisPutFileRequest_DataMockRecorder {
+	return m.recorder
+}
+
+// isPutFileRequest_Data mocks base method.
+func (m *MockisPutFileRequest_Data) isPutFileRequest_Data() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isPutFileRequest_Data")
+}
+
+// isPutFileRequest_Data indicates an expected call of isPutFileRequest_Data.
+func (mr *MockisPutFileRequest_DataMockRecorder) isPutFileRequest_Data() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isPutFileRequest_Data", reflect.TypeOf((*MockisPutFileRequest_Data)(nil).isPutFileRequest_Data))
+}
+
+// MockisGetFileReply_Data is a mock of isGetFileReply_Data interface.
+type MockisGetFileReply_Data struct {
+	ctrl     *gomock.Controller
+	recorder *MockisGetFileReply_DataMockRecorder
+}
+
+// MockisGetFileReply_DataMockRecorder is the mock recorder for MockisGetFileReply_Data.
+type MockisGetFileReply_DataMockRecorder struct {
+	mock *MockisGetFileReply_Data
+}
+
+// NewMockisGetFileReply_Data creates a new mock instance.
+func NewMockisGetFileReply_Data(ctrl *gomock.Controller) *MockisGetFileReply_Data {
+	mock := &MockisGetFileReply_Data{ctrl: ctrl}
+	mock.recorder = &MockisGetFileReply_DataMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisGetFileReply_Data) EXPECT() *MockisGetFileReply_DataMockRecorder {
+	return m.recorder
+}
+
+// isGetFileReply_Data mocks base method.
+func (m *MockisGetFileReply_Data) isGetFileReply_Data() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isGetFileReply_Data")
+}
+
+// isGetFileReply_Data indicates an expected call of isGetFileReply_Data.
+func (mr *MockisGetFileReply_DataMockRecorder) isGetFileReply_Data() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isGetFileReply_Data", reflect.TypeOf((*MockisGetFileReply_Data)(nil).isGetFileReply_Data))
+}
+
 // MockAgentClient is a mock of AgentClient interface.
 type MockAgentClient struct {
 	ctrl     *gomock.Controller
@@ -36,6 +142,126 @@ func (m *MockAgentClient) EXPECT() *MockAgentClientMockRecorder {
 	return m.recorder
 }
 
+// CollectLogs mocks base method.
+func (m *MockAgentClient) CollectLogs(ctx context.Context, in *idl.CollectLogsAgentRequest, opts ...grpc.CallOption) (idl.Agent_CollectLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CollectLogs", varargs...)
+	ret0, _ := ret[0].(idl.Agent_CollectLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockAgentClientMockRecorder) CollectLogs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockAgentClient)(nil).CollectLogs), varargs...)
+}
+
+// ConfigFile mocks base method.
+func (m *MockAgentClient) ConfigFile(ctx context.Context, in *idl.ConfigFileAgentRequest, opts ...grpc.CallOption) (*idl.ConfigFileAgentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfigFile", varargs...)
+	ret0, _ := ret[0].(*idl.ConfigFileAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigFile indicates an expected call of ConfigFile.
+func (mr *MockAgentClientMockRecorder) ConfigFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigFile", reflect.TypeOf((*MockAgentClient)(nil).ConfigFile), varargs...)
+}
+
+// Exec mocks base method.
+func (m *MockAgentClient) Exec(ctx context.Context, in *idl.ExecAgentRequest, opts ...grpc.CallOption) (idl.Agent_ExecClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(idl.Agent_ExecClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockAgentClientMockRecorder) Exec(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockAgentClient)(nil).Exec), varargs...)
+}
+
+// GetFile mocks base method.
+func (m *MockAgentClient) GetFile(ctx context.Context, in *idl.GetFileRequest, opts ...grpc.CallOption) (idl.Agent_GetFileClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFile", varargs...)
+	ret0, _ := ret[0].(idl.Agent_GetFileClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockAgentClientMockRecorder) GetFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockAgentClient)(nil).GetFile), varargs...)
+}
+
+// HostInfo mocks base method.
+func (m *MockAgentClient) HostInfo(ctx context.Context, in *idl.HostInfoAgentRequest, opts ...grpc.CallOption) (*idl.HostInfoAgentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HostInfo", varargs...)
+	ret0, _ := ret[0].(*idl.HostInfoAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostInfo indicates an expected call of HostInfo.
+func (mr *MockAgentClientMockRecorder) HostInfo(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostInfo", reflect.TypeOf((*MockAgentClient)(nil).HostInfo), varargs...)
+}
+
+// PutFile mocks base method.
+func (m *MockAgentClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (idl.Agent_PutFileClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFile", varargs...)
+	ret0, _ := ret[0].(idl.Agent_PutFileClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFile indicates an expected call of PutFile.
+func (mr *MockAgentClientMockRecorder) PutFile(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFile", reflect.TypeOf((*MockAgentClient)(nil).PutFile), varargs...)
+}
+
 // Status mocks base method.
 func (m *MockAgentClient) Status(ctx context.Context, in *idl.StatusAgentRequest, opts ...grpc.CallOption) (*idl.StatusAgentReply, error) {
 	m.ctrl.T.Helper()
@@ -76,55 +302,1449 @@ func (mr *MockAgentClientMockRecorder) Stop(ctx, in interface{}, opts ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentClient)(nil).Stop), varargs...)
 }
 
-// MockAgentServer is a mock of AgentServer interface.
-type MockAgentServer struct {
+// TailLogs mocks base method.
+func (m *MockAgentClient) TailLogs(ctx context.Context, in *idl.TailLogsAgentRequest, opts ...grpc.CallOption) (idl.Agent_TailLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TailLogs", varargs...)
+	ret0, _ := ret[0].(idl.Agent_TailLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TailLogs indicates an expected call of TailLogs.
+func (mr *MockAgentClientMockRecorder) TailLogs(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailLogs", reflect.TypeOf((*MockAgentClient)(nil).TailLogs), varargs...)
+}
+
+// Version mocks base method.
+func (m *MockAgentClient) Version(ctx context.Context, in *idl.VersionAgentRequest, opts ...grpc.CallOption) (*idl.VersionAgentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Version", varargs...)
+	ret0, _ := ret[0].(*idl.VersionAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockAgentClientMockRecorder) Version(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockAgentClient)(nil).Version), varargs...)
+}
+
+// MockAgent_ExecClient is a mock of Agent_ExecClient interface.
+type MockAgent_ExecClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockAgentServerMockRecorder
+	recorder *MockAgent_ExecClientMockRecorder
 }
 
-// MockAgentServerMockRecorder is the mock recorder for MockAgentServer.
-type MockAgentServerMockRecorder struct {
-	mock *MockAgentServer
+// MockAgent_ExecClientMockRecorder is the mock recorder for MockAgent_ExecClient.
+type MockAgent_ExecClientMockRecorder struct {
+	mock *MockAgent_ExecClient
 }
 
-// NewMockAgentServer creates a new mock instance.
-func NewMockAgentServer(ctrl *gomock.Controller) *MockAgentServer {
-	mock := &MockAgentServer{ctrl: ctrl}
-	mock.recorder = &MockAgentServerMockRecorder{mock}
+// NewMockAgent_ExecClient creates a new mock instance.
+func NewMockAgent_ExecClient(ctrl *gomock.Controller) *MockAgent_ExecClient {
+	mock := &MockAgent_ExecClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_ExecClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAgentServer) EXPECT() *MockAgentServerMockRecorder {
+func (m *MockAgent_ExecClient) EXPECT() *MockAgent_ExecClientMockRecorder {
 	return m.recorder
 }
 
-// Status mocks base method.
-func (m *MockAgentServer) Status(arg0 context.Context, arg1 *idl.StatusAgentRequest) (*idl.StatusAgentReply, error) {
+// CloseSend mocks base method.
+func (m *MockAgent_ExecClient) CloseSend() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Status", arg0, arg1)
-	ret0, _ := ret[0].(*idl.StatusAgentReply)
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_ExecClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_ExecClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_ExecClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_ExecClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_ExecClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_ExecClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Status indicates an expected call of Status.
-func (mr *MockAgentServerMockRecorder) Status(arg0, arg1 interface{}) *gomock.Call {
+// Header indicates an expected call of Header.
+func (mr *MockAgent_ExecClientMockRecorder) Header() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockAgentServer)(nil).Status), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_ExecClient)(nil).Header))
 }
 
-// Stop mocks base method.
-func (m *MockAgentServer) Stop(arg0 context.Context, arg1 *idl.StopAgentRequest) (*idl.StopAgentReply, error) {
+// Recv mocks base method.
+func (m *MockAgent_ExecClient) Recv() (*idl.ExecAgentReply, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop", arg0, arg1)
-	ret0, _ := ret[0].(*idl.StopAgentReply)
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.ExecAgentReply)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Stop indicates an expected call of Stop.
-func (mr *MockAgentServerMockRecorder) Stop(arg0, arg1 interface{}) *gomock.Call {
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_ExecClientMockRecorder) Recv() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentServer)(nil).Stop), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_ExecClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_ExecClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_ExecClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_ExecClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_ExecClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_ExecClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_ExecClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_ExecClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_ExecClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_ExecClient)(nil).Trailer))
+}
+
+// MockAgent_PutFileClient is a mock of Agent_PutFileClient interface.
+type MockAgent_PutFileClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_PutFileClientMockRecorder
+}
+
+// MockAgent_PutFileClientMockRecorder is the mock recorder for MockAgent_PutFileClient.
+type MockAgent_PutFileClientMockRecorder struct {
+	mock *MockAgent_PutFileClient
+}
+
+// NewMockAgent_PutFileClient creates a new mock instance.
+func NewMockAgent_PutFileClient(ctrl *gomock.Controller) *MockAgent_PutFileClient {
+	mock := &MockAgent_PutFileClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_PutFileClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_PutFileClient) EXPECT() *MockAgent_PutFileClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockAgent_PutFileClient) CloseAndRecv() (*idl.PutFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*idl.PutFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockAgent_PutFileClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockAgent_PutFileClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_PutFileClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_PutFileClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_PutFileClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_PutFileClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_PutFileClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_PutFileClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_PutFileClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_PutFileClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_PutFileClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_PutFileClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_PutFileClient) Send(arg0 *idl.PutFileRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_PutFileClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_PutFileClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_PutFileClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_PutFileClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_PutFileClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_PutFileClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Trailer))
+}
+
+// MockAgent_GetFileClient is a mock of Agent_GetFileClient interface.
+type MockAgent_GetFileClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetFileClientMockRecorder
+}
+
+// MockAgent_GetFileClientMockRecorder is the mock recorder for MockAgent_GetFileClient.
+type MockAgent_GetFileClientMockRecorder struct {
+	mock *MockAgent_GetFileClient
+}
+
+// NewMockAgent_GetFileClient creates a new mock instance.
+func NewMockAgent_GetFileClient(ctrl *gomock.Controller) *MockAgent_GetFileClient {
+	mock := &MockAgent_GetFileClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetFileClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_GetFileClient) EXPECT() *MockAgent_GetFileClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_GetFileClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_GetFileClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_GetFileClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_GetFileClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_GetFileClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_GetFileClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_GetFileClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_GetFileClient) Recv() (*idl.GetFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.GetFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_GetFileClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_GetFileClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_GetFileClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetFileClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_GetFileClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_GetFileClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetFileClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_GetFileClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_GetFileClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Trailer))
+}
+
+// MockAgent_CollectLogsClient is a mock of Agent_CollectLogsClient interface.
+type MockAgent_CollectLogsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_CollectLogsClientMockRecorder
+}
+
+// MockAgent_CollectLogsClientMockRecorder is the mock recorder for MockAgent_CollectLogsClient.
+type MockAgent_CollectLogsClientMockRecorder struct {
+	mock *MockAgent_CollectLogsClient
+}
+
+// NewMockAgent_CollectLogsClient creates a new mock instance.
+func NewMockAgent_CollectLogsClient(ctrl *gomock.Controller) *MockAgent_CollectLogsClient {
+	mock := &MockAgent_CollectLogsClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_CollectLogsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_CollectLogsClient) EXPECT() *MockAgent_CollectLogsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_CollectLogsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_CollectLogsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_CollectLogsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_CollectLogsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_CollectLogsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_CollectLogsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_CollectLogsClient) Recv() (*idl.CollectLogsAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.CollectLogsAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_CollectLogsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_CollectLogsClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_CollectLogsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_CollectLogsClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_CollectLogsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_CollectLogsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_CollectLogsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_CollectLogsClient)(nil).Trailer))
+}
+
+// MockAgent_TailLogsClient is a mock of Agent_TailLogsClient interface.
+type MockAgent_TailLogsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_TailLogsClientMockRecorder
+}
+
+// MockAgent_TailLogsClientMockRecorder is the mock recorder for MockAgent_TailLogsClient.
+type MockAgent_TailLogsClientMockRecorder struct {
+	mock *MockAgent_TailLogsClient
+}
+
+// NewMockAgent_TailLogsClient creates a new mock instance.
+func NewMockAgent_TailLogsClient(ctrl *gomock.Controller) *MockAgent_TailLogsClient {
+	mock := &MockAgent_TailLogsClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_TailLogsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_TailLogsClient) EXPECT() *MockAgent_TailLogsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_TailLogsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_TailLogsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_TailLogsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_TailLogsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_TailLogsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_TailLogsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_TailLogsClient) Recv() (*idl.TailLogsAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.TailLogsAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_TailLogsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_TailLogsClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_TailLogsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_TailLogsClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_TailLogsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_TailLogsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_TailLogsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_TailLogsClient)(nil).Trailer))
+}
+
+// MockAgentServer is a mock of AgentServer interface.
+type MockAgentServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentServerMockRecorder
+}
+
+// MockAgentServerMockRecorder is the mock recorder for MockAgentServer.
+type MockAgentServerMockRecorder struct {
+	mock *MockAgentServer
+}
+
+// NewMockAgentServer creates a new mock instance.
+func NewMockAgentServer(ctrl *gomock.Controller) *MockAgentServer {
+	mock := &MockAgentServer{ctrl: ctrl}
+	mock.recorder = &MockAgentServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentServer) EXPECT() *MockAgentServerMockRecorder {
+	return m.recorder
+}
+
+// CollectLogs mocks base method.
+func (m *MockAgentServer) CollectLogs(arg0 *idl.CollectLogsAgentRequest, arg1 idl.Agent_CollectLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockAgentServerMockRecorder) CollectLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockAgentServer)(nil).CollectLogs), arg0, arg1)
+}
+
+// ConfigFile mocks base method.
+func (m *MockAgentServer) ConfigFile(arg0 context.Context, arg1 *idl.ConfigFileAgentRequest) (*idl.ConfigFileAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigFile", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ConfigFileAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigFile indicates an expected call of ConfigFile.
+func (mr *MockAgentServerMockRecorder) ConfigFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigFile", reflect.TypeOf((*MockAgentServer)(nil).ConfigFile), arg0, arg1)
+}
+
+// Exec mocks base method.
+func (m *MockAgentServer) Exec(arg0 *idl.ExecAgentRequest, arg1 idl.Agent_ExecServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockAgentServerMockRecorder) Exec(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockAgentServer)(nil).Exec), arg0, arg1)
+}
+
+// GetFile mocks base method.
+func (m *MockAgentServer) GetFile(arg0 *idl.GetFileRequest, arg1 idl.Agent_GetFileServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockAgentServerMockRecorder) GetFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockAgentServer)(nil).GetFile), arg0, arg1)
+}
+
+// HostInfo mocks base method.
+func (m *MockAgentServer) HostInfo(arg0 context.Context, arg1 *idl.HostInfoAgentRequest) (*idl.HostInfoAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HostInfo", arg0, arg1)
+	ret0, _ := ret[0].(*idl.HostInfoAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostInfo indicates an expected call of HostInfo.
+func (mr *MockAgentServerMockRecorder) HostInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostInfo", reflect.TypeOf((*MockAgentServer)(nil).HostInfo), arg0, arg1)
+}
+
+// PutFile mocks base method.
+func (m *MockAgentServer) PutFile(arg0 idl.Agent_PutFileServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutFile indicates an expected call of PutFile.
+func (mr *MockAgentServerMockRecorder) PutFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFile", reflect.TypeOf((*MockAgentServer)(nil).PutFile), arg0)
+}
+
+// Status mocks base method.
+func (m *MockAgentServer) Status(arg0 context.Context, arg1 *idl.StatusAgentRequest) (*idl.StatusAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StatusAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockAgentServerMockRecorder) Status(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockAgentServer)(nil).Status), arg0, arg1)
+}
+
+// Stop mocks base method.
+func (m *MockAgentServer) Stop(arg0 context.Context, arg1 *idl.StopAgentRequest) (*idl.StopAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StopAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockAgentServerMockRecorder) Stop(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentServer)(nil).Stop), arg0, arg1)
+}
+
+// TailLogs mocks base method.
+func (m *MockAgentServer) TailLogs(arg0 *idl.TailLogsAgentRequest, arg1 idl.Agent_TailLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TailLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TailLogs indicates an expected call of TailLogs.
+func (mr *MockAgentServerMockRecorder) TailLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailLogs", reflect.TypeOf((*MockAgentServer)(nil).TailLogs), arg0, arg1)
+}
+
+// Version mocks base method.
+func (m *MockAgentServer) Version(arg0 context.Context, arg1 *idl.VersionAgentRequest) (*idl.VersionAgentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", arg0, arg1)
+	ret0, _ := ret[0].(*idl.VersionAgentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockAgentServerMockRecorder) Version(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockAgentServer)(nil).Version), arg0, arg1)
+}
+
+// MockAgent_ExecServer is a mock of Agent_ExecServer interface.
+type MockAgent_ExecServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_ExecServerMockRecorder
+}
+
+// MockAgent_ExecServerMockRecorder is the mock recorder for MockAgent_ExecServer.
+type MockAgent_ExecServerMockRecorder struct {
+	mock *MockAgent_ExecServer
+}
+
+// NewMockAgent_ExecServer creates a new mock instance.
+func NewMockAgent_ExecServer(ctrl *gomock.Controller) *MockAgent_ExecServer {
+	mock := &MockAgent_ExecServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_ExecServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_ExecServer) EXPECT() *MockAgent_ExecServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_ExecServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_ExecServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_ExecServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_ExecServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_ExecServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_ExecServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_ExecServer) Send(arg0 *idl.ExecAgentReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_ExecServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_ExecServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_ExecServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_ExecServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_ExecServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_ExecServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_ExecServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_ExecServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_ExecServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_ExecServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_ExecServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_ExecServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_ExecServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_ExecServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_PutFileServer is a mock of Agent_PutFileServer interface.
+type MockAgent_PutFileServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_PutFileServerMockRecorder
+}
+
+// MockAgent_PutFileServerMockRecorder is the mock recorder for MockAgent_PutFileServer.
+type MockAgent_PutFileServerMockRecorder struct {
+	mock *MockAgent_PutFileServer
+}
+
+// NewMockAgent_PutFileServer creates a new mock instance.
+func NewMockAgent_PutFileServer(ctrl *gomock.Controller) *MockAgent_PutFileServer {
+	mock := &MockAgent_PutFileServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_PutFileServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_PutFileServer) EXPECT() *MockAgent_PutFileServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_PutFileServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_PutFileServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_PutFileServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_PutFileServer) Recv() (*idl.PutFileRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.PutFileRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_PutFileServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_PutFileServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_PutFileServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_PutFileServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_PutFileServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockAgent_PutFileServer) SendAndClose(arg0 *idl.PutFileReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockAgent_PutFileServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_PutFileServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_PutFileServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_PutFileServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_PutFileServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_PutFileServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_PutFileServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_PutFileServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_PutFileServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_GetFileServer is a mock of Agent_GetFileServer interface.
+type MockAgent_GetFileServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetFileServerMockRecorder
+}
+
+// MockAgent_GetFileServerMockRecorder is the mock recorder for MockAgent_GetFileServer.
+type MockAgent_GetFileServerMockRecorder struct {
+	mock *MockAgent_GetFileServer
+}
+
+// NewMockAgent_GetFileServer creates a new mock instance.
+func NewMockAgent_GetFileServer(ctrl *gomock.Controller) *MockAgent_GetFileServer {
+	mock := &MockAgent_GetFileServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetFileServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_GetFileServer) EXPECT() *MockAgent_GetFileServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_GetFileServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_GetFileServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetFileServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_GetFileServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_GetFileServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetFileServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_GetFileServer) Send(arg0 *idl.GetFileReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_GetFileServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_GetFileServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_GetFileServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_GetFileServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_GetFileServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_GetFileServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_GetFileServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_GetFileServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_GetFileServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_GetFileServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_CollectLogsServer is a mock of Agent_CollectLogsServer interface.
+type MockAgent_CollectLogsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_CollectLogsServerMockRecorder
+}
+
+// MockAgent_CollectLogsServerMockRecorder is the mock recorder for MockAgent_CollectLogsServer.
+type MockAgent_CollectLogsServerMockRecorder struct {
+	mock *MockAgent_CollectLogsServer
+}
+
+// NewMockAgent_CollectLogsServer creates a new mock instance.
+func NewMockAgent_CollectLogsServer(ctrl *gomock.Controller) *MockAgent_CollectLogsServer {
+	mock := &MockAgent_CollectLogsServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_CollectLogsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_CollectLogsServer) EXPECT() *MockAgent_CollectLogsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_CollectLogsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_CollectLogsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_CollectLogsServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_CollectLogsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_CollectLogsServer) Send(arg0 *idl.CollectLogsAgentReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_CollectLogsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_CollectLogsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_CollectLogsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_CollectLogsServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_CollectLogsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_CollectLogsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_CollectLogsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_CollectLogsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_CollectLogsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_CollectLogsServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_TailLogsServer is a mock of Agent_TailLogsServer interface.
+type MockAgent_TailLogsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_TailLogsServerMockRecorder
+}
+
+// MockAgent_TailLogsServerMockRecorder is the mock recorder for MockAgent_TailLogsServer.
+type MockAgent_TailLogsServerMockRecorder struct {
+	mock *MockAgent_TailLogsServer
+}
+
+// NewMockAgent_TailLogsServer creates a new mock instance.
+func NewMockAgent_TailLogsServer(ctrl *gomock.Controller) *MockAgent_TailLogsServer {
+	mock := &MockAgent_TailLogsServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_TailLogsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_TailLogsServer) EXPECT() *MockAgent_TailLogsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_TailLogsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_TailLogsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_TailLogsServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_TailLogsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_TailLogsServer) Send(arg0 *idl.TailLogsAgentReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_TailLogsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_TailLogsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_TailLogsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_TailLogsServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_TailLogsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_TailLogsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_TailLogsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_TailLogsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_TailLogsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_TailLogsServer)(nil).SetTrailer), arg0)
 }
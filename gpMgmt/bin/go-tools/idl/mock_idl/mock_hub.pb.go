@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/greenplum-db/gpdb/gp/idl (interfaces: HubClient,HubServer)
+// Source: github.com/greenplum-db/gpdb/gp/idl (interfaces: HubClient,HubServer,Hub_ExecClient,Hub_TailLogsClient,Hub_UpgradeToolsClient)
 
 // Package mock_idl is a generated GoMock package.
 package mock_idl
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpdb/gp/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockHubClient is a mock of HubClient interface.
@@ -36,6 +37,146 @@ func (m *MockHubClient) EXPECT() *MockHubClientMockRecorder {
 	return m.recorder
 }
 
+// AgentCertificates mocks base method.
+func (m *MockHubClient) AgentCertificates(arg0 context.Context, arg1 *idl.AgentCertificatesRequest, arg2 ...grpc.CallOption) (*idl.AgentCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AgentCertificates", varargs...)
+	ret0, _ := ret[0].(*idl.AgentCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AgentCertificates indicates an expected call of AgentCertificates.
+func (mr *MockHubClientMockRecorder) AgentCertificates(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentCertificates", reflect.TypeOf((*MockHubClient)(nil).AgentCertificates), varargs...)
+}
+
+// CheckConfig mocks base method.
+func (m *MockHubClient) CheckConfig(arg0 context.Context, arg1 *idl.CheckConfigRequest, arg2 ...grpc.CallOption) (*idl.CheckConfigReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckConfig", varargs...)
+	ret0, _ := ret[0].(*idl.CheckConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckConfig indicates an expected call of CheckConfig.
+func (mr *MockHubClientMockRecorder) CheckConfig(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConfig", reflect.TypeOf((*MockHubClient)(nil).CheckConfig), varargs...)
+}
+
+// CollectLogs mocks base method.
+func (m *MockHubClient) CollectLogs(arg0 context.Context, arg1 *idl.CollectLogsRequest, arg2 ...grpc.CallOption) (*idl.CollectLogsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CollectLogs", varargs...)
+	ret0, _ := ret[0].(*idl.CollectLogsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockHubClientMockRecorder) CollectLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockHubClient)(nil).CollectLogs), varargs...)
+}
+
+// CopyFile mocks base method.
+func (m *MockHubClient) CopyFile(arg0 context.Context, arg1 *idl.CopyFileRequest, arg2 ...grpc.CallOption) (*idl.CopyFileReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CopyFile", varargs...)
+	ret0, _ := ret[0].(*idl.CopyFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFile indicates an expected call of CopyFile.
+func (mr *MockHubClientMockRecorder) CopyFile(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFile", reflect.TypeOf((*MockHubClient)(nil).CopyFile), varargs...)
+}
+
+// Exec mocks base method.
+func (m *MockHubClient) Exec(arg0 context.Context, arg1 *idl.ExecRequest, arg2 ...grpc.CallOption) (idl.Hub_ExecClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(idl.Hub_ExecClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockHubClientMockRecorder) Exec(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockHubClient)(nil).Exec), varargs...)
+}
+
+// HostInfo mocks base method.
+func (m *MockHubClient) HostInfo(arg0 context.Context, arg1 *idl.HostInfoRequest, arg2 ...grpc.CallOption) (*idl.HostInfoReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HostInfo", varargs...)
+	ret0, _ := ret[0].(*idl.HostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostInfo indicates an expected call of HostInfo.
+func (mr *MockHubClientMockRecorder) HostInfo(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostInfo", reflect.TypeOf((*MockHubClient)(nil).HostInfo), varargs...)
+}
+
+// ReloadConfig mocks base method.
+func (m *MockHubClient) ReloadConfig(arg0 context.Context, arg1 *idl.ReloadConfigRequest, arg2 ...grpc.CallOption) (*idl.ReloadConfigReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadConfig", varargs...)
+	ret0, _ := ret[0].(*idl.ReloadConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadConfig indicates an expected call of ReloadConfig.
+func (mr *MockHubClientMockRecorder) ReloadConfig(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockHubClient)(nil).ReloadConfig), varargs...)
+}
+
 // StartAgents mocks base method.
 func (m *MockHubClient) StartAgents(arg0 context.Context, arg1 *idl.StartAgentsRequest, arg2 ...grpc.CallOption) (*idl.StartAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +197,26 @@ func (mr *MockHubClientMockRecorder) StartAgents(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAgents", reflect.TypeOf((*MockHubClient)(nil).StartAgents), varargs...)
 }
 
+// Status mocks base method.
+func (m *MockHubClient) Status(arg0 context.Context, arg1 *idl.StatusHubRequest, arg2 ...grpc.CallOption) (*idl.StatusHubReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Status", varargs...)
+	ret0, _ := ret[0].(*idl.StatusHubReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockHubClientMockRecorder) Status(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockHubClient)(nil).Status), varargs...)
+}
+
 // StatusAgents mocks base method.
 func (m *MockHubClient) StatusAgents(arg0 context.Context, arg1 *idl.StatusAgentsRequest, arg2 ...grpc.CallOption) (*idl.StatusAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +277,86 @@ func (mr *MockHubClientMockRecorder) StopAgents(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAgents", reflect.TypeOf((*MockHubClient)(nil).StopAgents), varargs...)
 }
 
+// SyncConfig mocks base method.
+func (m *MockHubClient) SyncConfig(arg0 context.Context, arg1 *idl.SyncConfigRequest, arg2 ...grpc.CallOption) (*idl.SyncConfigReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SyncConfig", varargs...)
+	ret0, _ := ret[0].(*idl.SyncConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncConfig indicates an expected call of SyncConfig.
+func (mr *MockHubClientMockRecorder) SyncConfig(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncConfig", reflect.TypeOf((*MockHubClient)(nil).SyncConfig), varargs...)
+}
+
+// TailLogs mocks base method.
+func (m *MockHubClient) TailLogs(arg0 context.Context, arg1 *idl.TailLogsRequest, arg2 ...grpc.CallOption) (idl.Hub_TailLogsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TailLogs", varargs...)
+	ret0, _ := ret[0].(idl.Hub_TailLogsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TailLogs indicates an expected call of TailLogs.
+func (mr *MockHubClientMockRecorder) TailLogs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailLogs", reflect.TypeOf((*MockHubClient)(nil).TailLogs), varargs...)
+}
+
+// UpgradeTools mocks base method.
+func (m *MockHubClient) UpgradeTools(arg0 context.Context, arg1 *idl.UpgradeToolsRequest, arg2 ...grpc.CallOption) (idl.Hub_UpgradeToolsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpgradeTools", varargs...)
+	ret0, _ := ret[0].(idl.Hub_UpgradeToolsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeTools indicates an expected call of UpgradeTools.
+func (mr *MockHubClientMockRecorder) UpgradeTools(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeTools", reflect.TypeOf((*MockHubClient)(nil).UpgradeTools), varargs...)
+}
+
+// Version mocks base method.
+func (m *MockHubClient) Version(arg0 context.Context, arg1 *idl.VersionRequest, arg2 ...grpc.CallOption) (*idl.VersionReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Version", varargs...)
+	ret0, _ := ret[0].(*idl.VersionReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockHubClientMockRecorder) Version(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockHubClient)(nil).Version), varargs...)
+}
+
 // MockHubServer is a mock of HubServer interface.
 type MockHubServer struct {
 	ctrl     *gomock.Controller
@@ -139,6 +380,110 @@ func (m *MockHubServer) EXPECT() *MockHubServerMockRecorder {
 	return m.recorder
 }
 
+// AgentCertificates mocks base method.
+func (m *MockHubServer) AgentCertificates(arg0 context.Context, arg1 *idl.AgentCertificatesRequest) (*idl.AgentCertificatesReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AgentCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*idl.AgentCertificatesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AgentCertificates indicates an expected call of AgentCertificates.
+func (mr *MockHubServerMockRecorder) AgentCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentCertificates", reflect.TypeOf((*MockHubServer)(nil).AgentCertificates), arg0, arg1)
+}
+
+// CheckConfig mocks base method.
+func (m *MockHubServer) CheckConfig(arg0 context.Context, arg1 *idl.CheckConfigRequest) (*idl.CheckConfigReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckConfig", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CheckConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckConfig indicates an expected call of CheckConfig.
+func (mr *MockHubServerMockRecorder) CheckConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckConfig", reflect.TypeOf((*MockHubServer)(nil).CheckConfig), arg0, arg1)
+}
+
+// CollectLogs mocks base method.
+func (m *MockHubServer) CollectLogs(arg0 context.Context, arg1 *idl.CollectLogsRequest) (*idl.CollectLogsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectLogs", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CollectLogsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLogs indicates an expected call of CollectLogs.
+func (mr *MockHubServerMockRecorder) CollectLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLogs", reflect.TypeOf((*MockHubServer)(nil).CollectLogs), arg0, arg1)
+}
+
+// CopyFile mocks base method.
+func (m *MockHubServer) CopyFile(arg0 context.Context, arg1 *idl.CopyFileRequest) (*idl.CopyFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFile", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CopyFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFile indicates an expected call of CopyFile.
+func (mr *MockHubServerMockRecorder) CopyFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFile", reflect.TypeOf((*MockHubServer)(nil).CopyFile), arg0, arg1)
+}
+
+// Exec mocks base method.
+func (m *MockHubServer) Exec(arg0 *idl.ExecRequest, arg1 idl.Hub_ExecServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockHubServerMockRecorder) Exec(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockHubServer)(nil).Exec), arg0, arg1)
+}
+
+// HostInfo mocks base method.
+func (m *MockHubServer) HostInfo(arg0 context.Context, arg1 *idl.HostInfoRequest) (*idl.HostInfoReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HostInfo", arg0, arg1)
+	ret0, _ := ret[0].(*idl.HostInfoReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostInfo indicates an expected call of HostInfo.
+func (mr *MockHubServerMockRecorder) HostInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostInfo", reflect.TypeOf((*MockHubServer)(nil).HostInfo), arg0, arg1)
+}
+
+// ReloadConfig mocks base method.
+func (m *MockHubServer) ReloadConfig(arg0 context.Context, arg1 *idl.ReloadConfigRequest) (*idl.ReloadConfigReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadConfig", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ReloadConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadConfig indicates an expected call of ReloadConfig.
+func (mr *MockHubServerMockRecorder) ReloadConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadConfig", reflect.TypeOf((*MockHubServer)(nil).ReloadConfig), arg0, arg1)
+}
+
 // StartAgents mocks base method.
 func (m *MockHubServer) StartAgents(arg0 context.Context, arg1 *idl.StartAgentsRequest) (*idl.StartAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +499,21 @@ func (mr *MockHubServerMockRecorder) StartAgents(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAgents", reflect.TypeOf((*MockHubServer)(nil).StartAgents), arg0, arg1)
 }
 
+// Status mocks base method.
+func (m *MockHubServer) Status(arg0 context.Context, arg1 *idl.StatusHubRequest) (*idl.StatusHubReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StatusHubReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockHubServerMockRecorder) Status(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockHubServer)(nil).Status), arg0, arg1)
+}
+
 // StatusAgents mocks base method.
 func (m *MockHubServer) StatusAgents(arg0 context.Context, arg1 *idl.StatusAgentsRequest) (*idl.StatusAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -198,3 +558,430 @@ func (mr *MockHubServerMockRecorder) StopAgents(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAgents", reflect.TypeOf((*MockHubServer)(nil).StopAgents), arg0, arg1)
 }
+
+// SyncConfig mocks base method.
+func (m *MockHubServer) SyncConfig(arg0 context.Context, arg1 *idl.SyncConfigRequest) (*idl.SyncConfigReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncConfig", arg0, arg1)
+	ret0, _ := ret[0].(*idl.SyncConfigReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncConfig indicates an expected call of SyncConfig.
+func (mr *MockHubServerMockRecorder) SyncConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncConfig", reflect.TypeOf((*MockHubServer)(nil).SyncConfig), arg0, arg1)
+}
+
+// TailLogs mocks base method.
+func (m *MockHubServer) TailLogs(arg0 *idl.TailLogsRequest, arg1 idl.Hub_TailLogsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TailLogs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TailLogs indicates an expected call of TailLogs.
+func (mr *MockHubServerMockRecorder) TailLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailLogs", reflect.TypeOf((*MockHubServer)(nil).TailLogs), arg0, arg1)
+}
+
+// UpgradeTools mocks base method.
+func (m *MockHubServer) UpgradeTools(arg0 *idl.UpgradeToolsRequest, arg1 idl.Hub_UpgradeToolsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeTools", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeTools indicates an expected call of UpgradeTools.
+func (mr *MockHubServerMockRecorder) UpgradeTools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeTools", reflect.TypeOf((*MockHubServer)(nil).UpgradeTools), arg0, arg1)
+}
+
+// Version mocks base method.
+func (m *MockHubServer) Version(arg0 context.Context, arg1 *idl.VersionRequest) (*idl.VersionReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", arg0, arg1)
+	ret0, _ := ret[0].(*idl.VersionReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockHubServerMockRecorder) Version(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockHubServer)(nil).Version), arg0, arg1)
+}
+
+// MockHub_ExecClient is a mock of Hub_ExecClient interface.
+type MockHub_ExecClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHub_ExecClientMockRecorder
+}
+
+// MockHub_ExecClientMockRecorder is the mock recorder for MockHub_ExecClient.
+type MockHub_ExecClientMockRecorder struct {
+	mock *MockHub_ExecClient
+}
+
+// NewMockHub_ExecClient creates a new mock instance.
+func NewMockHub_ExecClient(ctrl *gomock.Controller) *MockHub_ExecClient {
+	mock := &MockHub_ExecClient{ctrl: ctrl}
+	mock.recorder = &MockHub_ExecClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHub_ExecClient) EXPECT() *MockHub_ExecClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockHub_ExecClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockHub_ExecClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockHub_ExecClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockHub_ExecClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockHub_ExecClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockHub_ExecClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockHub_ExecClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockHub_ExecClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockHub_ExecClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockHub_ExecClient) Recv() (*idl.ExecReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.ExecReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockHub_ExecClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockHub_ExecClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockHub_ExecClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockHub_ExecClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockHub_ExecClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockHub_ExecClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockHub_ExecClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockHub_ExecClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockHub_ExecClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockHub_ExecClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockHub_ExecClient)(nil).Trailer))
+}
+
+// MockHub_TailLogsClient is a mock of Hub_TailLogsClient interface.
+type MockHub_TailLogsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHub_TailLogsClientMockRecorder
+}
+
+// MockHub_TailLogsClientMockRecorder is the mock recorder for MockHub_TailLogsClient.
+type MockHub_TailLogsClientMockRecorder struct {
+	mock *MockHub_TailLogsClient
+}
+
+// NewMockHub_TailLogsClient creates a new mock instance.
+func NewMockHub_TailLogsClient(ctrl *gomock.Controller) *MockHub_TailLogsClient {
+	mock := &MockHub_TailLogsClient{ctrl: ctrl}
+	mock.recorder = &MockHub_TailLogsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHub_TailLogsClient) EXPECT() *MockHub_TailLogsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockHub_TailLogsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockHub_TailLogsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockHub_TailLogsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockHub_TailLogsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockHub_TailLogsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockHub_TailLogsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockHub_TailLogsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockHub_TailLogsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockHub_TailLogsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockHub_TailLogsClient) Recv() (*idl.TailLogsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.TailLogsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockHub_TailLogsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockHub_TailLogsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockHub_TailLogsClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockHub_TailLogsClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockHub_TailLogsClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockHub_TailLogsClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockHub_TailLogsClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockHub_TailLogsClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockHub_TailLogsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockHub_TailLogsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockHub_TailLogsClient)(nil).Trailer))
+}
+
+// MockHub_UpgradeToolsClient is a mock of Hub_UpgradeToolsClient interface.
+type MockHub_UpgradeToolsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHub_UpgradeToolsClientMockRecorder
+}
+
+// MockHub_UpgradeToolsClientMockRecorder is the mock recorder for MockHub_UpgradeToolsClient.
+type MockHub_UpgradeToolsClientMockRecorder struct {
+	mock *MockHub_UpgradeToolsClient
+}
+
+// NewMockHub_UpgradeToolsClient creates a new mock instance.
+func NewMockHub_UpgradeToolsClient(ctrl *gomock.Controller) *MockHub_UpgradeToolsClient {
+	mock := &MockHub_UpgradeToolsClient{ctrl: ctrl}
+	mock.recorder = &MockHub_UpgradeToolsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHub_UpgradeToolsClient) EXPECT() *MockHub_UpgradeToolsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockHub_UpgradeToolsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockHub_UpgradeToolsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockHub_UpgradeToolsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockHub_UpgradeToolsClient) Recv() (*idl.UpgradeToolsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.UpgradeToolsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockHub_UpgradeToolsClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockHub_UpgradeToolsClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockHub_UpgradeToolsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockHub_UpgradeToolsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockHub_UpgradeToolsClient)(nil).Trailer))
+}
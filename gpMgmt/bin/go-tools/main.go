@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -15,6 +16,14 @@ func main() {
 
 	err := root.Execute()
 	if err != nil {
+		// Errors that know how badly the command failed, e.g. whether it failed on
+		// some or all of the hosts, report that through the exit status
+		exitCode := 1
+		var exitCoder interface{ ExitCode() int }
+		if errors.As(err, &exitCoder) {
+			exitCode = exitCoder.ExitCode()
+		}
+
 		// gplog is initialised in the PreRun function in cobra and sometimes when the
 		// error is due to the input flags, the cobra pkg would not run the PreRun function.
 		// In those cases directly print to the stdout instead of using gplog
@@ -26,6 +35,6 @@ func main() {
 				fmt.Println(err.Error())
 			}
 		}
-		os.Exit(1)
+		os.Exit(exitCode)
 	}
 }
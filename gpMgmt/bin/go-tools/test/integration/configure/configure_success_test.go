@@ -3,6 +3,7 @@ package configure
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -220,6 +221,37 @@ func TestConfigureSuccess(t *testing.T) {
 		testutils.CleanupFilesOnAgents(agentFile, hosts)
 	})
 
+	t.Run("configure service without certificates creates a certificate authority", func(t *testing.T) {
+		certDir := filepath.Join(testutils.GpHome, constants.CertificatesDir)
+		cliParams := []string{
+			"--hostfile", *hostfile,
+		}
+
+		result, err := testutils.RunConfigure(false, cliParams...)
+		if err != nil {
+			t.Errorf("\nUnexpected error: %#v", err)
+		}
+		if !strings.Contains(result.OutputMsg, "Created certificate authority") {
+			t.Errorf("\nExpected string: %#v \nNot found in: %#v", "Created certificate authority", result.OutputMsg)
+		}
+
+		// verify the certificates were issued from the created authority
+		config := testutils.ParseConfig(testutils.DefaultConfigurationFile)
+		cred := config.Credentials.(*utils.GpCredentials)
+		if cred.CACertPath != filepath.Join(certDir, utils.CACertFileName) {
+			t.Errorf("\nExpected: %v \nGot: %v", filepath.Join(certDir, utils.CACertFileName), cred.CACertPath)
+		}
+		testutils.FilesExistOnHub(t, cred.CAKeyPath, cred.HubCertPath, cred.ClientCertPath)
+		testutils.FilesExistsOnAgents(t, cred.AgentCertPath, hosts)
+		if config.Authorization != nil {
+			t.Errorf("\nExpected the default authorization policy, got: %v", testutils.StructToString(config.Authorization))
+		}
+
+		// clean up files after each test cases
+		testutils.CleanupFilesOnHub(testutils.DefaultConfigurationFile, defaultLogFile, hubFile, certDir)
+		testutils.CleanupFilesOnAgents(agentFile, hosts)
+	})
+
 	t.Run("configure service with verbose option", func(t *testing.T) {
 		cliParams := []string{
 			"--hostfile", *hostfile,
@@ -39,6 +39,7 @@ var (
 
 func init() {
 	certPath := "/tmp/certificates"
+	hostname, _ := os.Hostname()
 	p := utils.GetPlatform()
 	defaultServiceDir, serviceExt, _ = testutils.GetServiceDetails(p)
 	cred := &utils.GpCredentials{
@@ -48,13 +49,19 @@ func init() {
 		ServerKeyPath:  fmt.Sprintf("%s/%s", certPath, "server-key.pem"),
 	}
 	defaultGPConf = hub.Config{
-		Port:        constants.DefaultHubPort,
-		AgentPort:   constants.DefaultAgentPort,
-		Hostnames:   []string{},
-		LogDir:      constants.DefaultHubLogDir,
-		ServiceName: constants.DefaultServiceName,
-		GpHome:      testutils.GpHome,
-		Credentials: cred,
+		ConfigVersion: hub.ConfigVersion,
+		Port:          constants.DefaultHubPort,
+		AgentPort:     constants.DefaultAgentPort,
+		Hostnames:     []string{},
+		LogDir:        constants.DefaultHubLogDir,
+		ServiceName:   constants.DefaultServiceName,
+		GpHome:        testutils.GpHome,
+		LogMaxSizeMB:  constants.DefaultLogMaxSizeMB,
+		LogMaxAgeDays: constants.DefaultLogMaxAgeDays,
+		LogMaxBackups: constants.DefaultLogMaxBackups,
+		Credentials:   cred,
+		// The test certificates are issued for the name of the host
+		Authorization: utils.DefaultAuthorizationPolicy([]string{hostname}, []string{hostname}),
 	}
 }
 
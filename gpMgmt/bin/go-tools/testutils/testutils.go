@@ -1,15 +1,23 @@
 package testutils
 
 import (
+	"context"
+	"crypto/x509"
 	"errors"
 	"io"
 	"os"
 	"os/exec"
+	"sync"
+	"time"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/executor"
 	"github.com/greenplum-db/gpdb/gp/hub"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type MockPlatform struct {
@@ -19,7 +27,9 @@ type MockPlatform struct {
 	ServiceFileContent   string
 	DefServiceDir        string
 	StartCmd             *exec.Cmd
+	LogCmd               *exec.Cmd
 	ConfigFileData       []byte
+	DisplayedStatuses    []*idl.ServiceStatus
 }
 
 func InitializeTestEnv() *hub.Config {
@@ -37,12 +47,15 @@ func InitializeTestEnv() *hub.Config {
 	}
 	return conf
 }
-func (p *MockPlatform) CreateServiceDir(hostnames []string, serviceDir string, gphome string) error {
+func (p *MockPlatform) CreateServiceDir(hostnames []string, serviceDir string) error {
 	return nil
 }
 func (p *MockPlatform) GetServiceStatusMessage(serviceName string) (string, error) {
 	return p.ServiceStatusMessage, p.Err
 }
+func (p *MockPlatform) GetServiceLogCommand(serviceName string, since time.Time, until time.Time) *exec.Cmd {
+	return p.LogCmd
+}
 func (p *MockPlatform) GenerateServiceFileContents(process string, gphome string, serviceName string) string {
 	return p.ServiceFileContent
 }
@@ -52,13 +65,16 @@ func (p *MockPlatform) GetDefaultServiceDir() string {
 func (p *MockPlatform) ReloadHubService(servicePath string) error {
 	return p.Err
 }
-func (p *MockPlatform) ReloadAgentService(gphome string, hostList []string, servicePath string) error {
+func (p *MockPlatform) ReloadAgentService(hostnames []string, servicePath string) error {
 	return p.Err
 }
 func (p *MockPlatform) CreateAndInstallHubServiceFile(gphome string, serviceDir string, serviceName string) error {
 	return p.Err
 }
-func (p *MockPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string) error {
+func (p *MockPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string, writeFile utils.FileWriter) error {
+	return p.Err
+}
+func (p *MockPlatform) RemoveAgentServiceFile(hostnames []string, serviceDir string, serviceName string) error {
 	return p.Err
 }
 func (p *MockPlatform) GetStartHubCommand(serviceName string) *exec.Cmd {
@@ -71,8 +87,9 @@ func (p *MockPlatform) ParseServiceStatusMessage(message string) idl.ServiceStat
 	return idl.ServiceStatus{Status: p.RetStatus.Status, Pid: p.RetStatus.Pid, Uptime: p.RetStatus.Uptime}
 }
 func (p *MockPlatform) DisplayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool) {
+	p.DisplayedStatuses = append(p.DisplayedStatuses, statuses...)
 }
-func (p *MockPlatform) EnableUserLingering(hostnames []string, gphome string, serviceUser string) error {
+func (p *MockPlatform) EnableUserLingering(hostnames []string, serviceUser string) error {
 	return nil
 }
 func (p *MockPlatform) ReadFile(configFilePath string) (config *hub.Config, err error) {
@@ -84,20 +101,78 @@ func (p *MockPlatform) SetServiceFileContent(content string) {
 
 type MockCredentials struct {
 	TlsConnection credentials.TransportCredentials
+	Cert          *x509.Certificate
 	Err           error
 }
 
-func (s *MockCredentials) LoadServerCredentials() (credentials.TransportCredentials, error) {
+func (s *MockCredentials) LoadServerCredentials(role utils.Role) (credentials.TransportCredentials, error) {
 	return s.TlsConnection, s.Err
 }
 
-func (s *MockCredentials) LoadClientCredentials() (credentials.TransportCredentials, error) {
+func (s *MockCredentials) LoadClientCredentials(role utils.Role) (credentials.TransportCredentials, error) {
 	return s.TlsConnection, s.Err
 }
 
+func (s *MockCredentials) Certificate(role utils.Role) (*x509.Certificate, error) {
+	if s.Cert == nil && s.Err == nil {
+		return nil, errors.New("no certificate")
+	}
+
+	return s.Cert, s.Err
+}
+
 func (s *MockCredentials) SetCredsError(errMsg string) {
 	s.Err = errors.New(errMsg)
 }
 func (s *MockCredentials) ResetCredsError() {
 	s.Err = nil
 }
+
+type MockExecutor struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Err      error
+
+	mutex    sync.Mutex
+	Commands []string
+	Inputs   [][]byte
+	Hosts    [][]string
+}
+
+func (e *MockExecutor) Run(ctx context.Context, hosts []string, command string) []executor.Result {
+	return e.RunWithInput(ctx, hosts, command, nil)
+}
+
+func (e *MockExecutor) RunWithInput(ctx context.Context, hosts []string, command string, input []byte) []executor.Result {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.Commands = append(e.Commands, command)
+	e.Inputs = append(e.Inputs, input)
+	e.Hosts = append(e.Hosts, hosts)
+
+	results := make([]executor.Result, 0, len(hosts))
+	for _, host := range hosts {
+		results = append(results, executor.Result{Host: host, ExitCode: e.ExitCode, Stdout: e.Stdout, Stderr: e.Stderr, Err: e.Err})
+	}
+
+	return results
+}
+
+type MockHealthClient struct {
+	Status healthpb.HealthCheckResponse_ServingStatus
+	Err    error
+}
+
+func (c *MockHealthClient) Check(ctx context.Context, in *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (*healthpb.HealthCheckResponse, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	return &healthpb.HealthCheckResponse{Status: c.Status}, nil
+}
+
+func (c *MockHealthClient) Watch(ctx context.Context, in *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (healthpb.Health_WatchClient, error) {
+	return nil, errors.New("not implemented")
+}
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxRequestSummary bounds the size of the request recorded for an RPC, as
+// requests may carry file contents.
+const maxRequestSummary = 512
+
+const reflectionMethodPrefix = "/grpc.reflection."
+
+// AuditRecord describes a single RPC served by the hub or an agent.
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"requestId,omitempty"`
+	Identity  string    `json:"identity"`
+	Peer      string    `json:"peer"`
+	Method    string    `json:"method"`
+	Request   string    `json:"request,omitempty"`
+	Duration  string    `json:"duration"`
+	Code      string    `json:"code"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// AuditLog appends one JSON line per RPC to a file.
+type AuditLog struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func OpenAuditLog(path string) (*AuditLog, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open audit log: %w", err)
+	}
+
+	return &AuditLog{file: file}, nil
+}
+
+func (l *AuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.file.Close()
+}
+
+// Write appends record to the log. Records are written with a single write
+// call, so concurrent processes appending to the same file do not interleave.
+func (l *AuditLog) Write(record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("could not encode audit record: %w", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err = l.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("could not write audit record: %w", err)
+	}
+
+	return nil
+}
+
+func (l *AuditLog) record(ctx context.Context, method string, request string, start time.Time, err error) {
+	record := AuditRecord{
+		Time:      start.UTC(),
+		RequestID: RequestIDFromContext(ctx),
+		Identity:  strings.Join(PeerIdentities(ctx), ","),
+		Method:    method,
+		Request:   request,
+		Duration:  time.Since(start).String(),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		record.Peer = p.Addr.String()
+	}
+
+	status := grpcStatus.Convert(err)
+	record.Code = status.Code().String()
+	record.Message = status.Message()
+
+	// Failing to audit must not fail the RPC itself
+	_ = l.Write(record)
+}
+
+// audited reports whether calls to method are recorded. Health checks and
+// reflection are polled constantly and do not manage anything, so they are
+// left out. A nil AuditLog records nothing.
+func (l *AuditLog) audited(method string) bool {
+	return l != nil && !strings.HasPrefix(method, healthMethodPrefix) && !strings.HasPrefix(method, reflectionMethodPrefix)
+}
+
+// UnaryInterceptor records every unary RPC. It should be the outermost
+// interceptor, so that calls rejected by other interceptors are recorded too.
+func (l *AuditLog) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.audited(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		l.record(ctx, info.FullMethod, summarize(req), start, err)
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor records every streaming RPC, summarizing the first
+// message the client sent.
+func (l *AuditLog) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !l.audited(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		start := time.Now()
+		auditStream := &auditServerStream{ServerStream: stream}
+		err := handler(srv, auditStream)
+		l.record(stream.Context(), info.FullMethod, auditStream.request, start, err)
+
+		return err
+	}
+}
+
+type auditServerStream struct {
+	grpc.ServerStream
+	request  string
+	received bool
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && !s.received {
+		s.received = true
+		s.request = summarize(m)
+	}
+
+	return err
+}
+
+func summarize(req interface{}) string {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	summary, err := protojson.Marshal(message)
+	if err != nil {
+		return ""
+	}
+
+	// protojson does not guarantee stable whitespace, so normalize it
+	compact := strings.Join(strings.Fields(string(summary)), " ")
+	if len(compact) > maxRequestSummary {
+		compact = compact[:maxRequestSummary] + "..."
+	}
+
+	return compact
+}
+
+// ReadAuditLog returns the records in the audit log which were written at or
+// after since.
+func ReadAuditLog(r io.Reader, since time.Time) ([]AuditRecord, error) {
+	records := make([]AuditRecord, 0)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var record AuditRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse audit record on line %d: %w", line, err)
+		}
+
+		if !record.Time.Before(since) {
+			records = append(records, record)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("could not read audit log: %w", err)
+	}
+
+	return records, nil
+}
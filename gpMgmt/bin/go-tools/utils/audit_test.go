@@ -0,0 +1,170 @@
+package utils_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuditLog(t *testing.T) {
+	openLog := func(t *testing.T) (*utils.AuditLog, string) {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "logs", "audit.log")
+		auditLog, err := utils.OpenAuditLog(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		t.Cleanup(func() { auditLog.Close() })
+
+		return auditLog, path
+	}
+
+	readLog := func(t *testing.T, path string, since time.Time) []utils.AuditRecord {
+		t.Helper()
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		records, err := utils.ReadAuditLog(file, since)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return records
+	}
+
+	auditedContext := func() context.Context {
+		ctx := peerContext("gp-cli")
+		p, _ := peer.FromContext(ctx)
+		p.Addr = &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 45678}
+
+		return utils.ContextWithRequestID(ctx, "0123456789abcdef")
+	}
+
+	t.Run("records the caller, method, request and outcome of unary RPCs", func(t *testing.T) {
+		auditLog, path := openLog(t)
+		interceptor := auditLog.UnaryInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.FailedPrecondition, "agents are not running")
+		}
+
+		req := &idl.StopAgentsRequest{}
+		_, err := interceptor(auditedContext(), req, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StopAgents"}, handler)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want %v", status.Code(err), codes.FailedPrecondition)
+		}
+
+		records := readLog(t, path, time.Time{})
+		if len(records) != 1 {
+			t.Fatalf("got %d records, want 1", len(records))
+		}
+
+		record := records[0]
+		if record.Identity != "gp-cli" || record.Peer != "10.0.0.1:45678" || record.Method != "/idl.Hub/StopAgents" {
+			t.Fatalf("got %+v, want identity gp-cli, peer 10.0.0.1:45678 and method /idl.Hub/StopAgents", record)
+		}
+		if record.RequestID != "0123456789abcdef" {
+			t.Fatalf("got %q, want %q", record.RequestID, "0123456789abcdef")
+		}
+		if record.Code != "FailedPrecondition" || record.Message != "agents are not running" {
+			t.Fatalf("got code %q and message %q, want FailedPrecondition and the error message", record.Code, record.Message)
+		}
+		if record.Request != "{}" {
+			t.Fatalf("got %q, want %q", record.Request, "{}")
+		}
+		if _, err := time.ParseDuration(record.Duration); err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("truncates long requests", func(t *testing.T) {
+		auditLog, path := openLog(t)
+		interceptor := auditLog.UnaryInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+
+		req := &idl.CopyFileRequest{Source: strings.Repeat("a", 1000)}
+		_, err := interceptor(auditedContext(), req, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/CopyFile"}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		records := readLog(t, path, time.Time{})
+		if len(records[0].Request) != 515 || !strings.HasSuffix(records[0].Request, "...") {
+			t.Fatalf("got a request summary of %d characters, want it truncated to 512", len(records[0].Request))
+		}
+		if records[0].Code != "OK" {
+			t.Fatalf("got %q, want %q", records[0].Code, "OK")
+		}
+	})
+
+	t.Run("does not record health checks", func(t *testing.T) {
+		auditLog, path := openLog(t)
+		interceptor := auditLog.UnaryInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+
+		_, err := interceptor(auditedContext(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		records := readLog(t, path, time.Time{})
+		if len(records) != 0 {
+			t.Fatalf("got %+v, want no records", records)
+		}
+	})
+
+	t.Run("passes calls through without an audit log", func(t *testing.T) {
+		var auditLog *utils.AuditLog
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "called", nil
+		}
+
+		resp, err := auditLog.UnaryInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/Status"}, handler)
+		if err != nil || resp != "called" {
+			t.Fatalf("got %v, %v, want the handler to be called", resp, err)
+		}
+	})
+
+	t.Run("only reads records written since the given time", func(t *testing.T) {
+		auditLog, path := openLog(t)
+		now := time.Now().UTC()
+		for _, age := range []time.Duration{48 * time.Hour, time.Hour, 0} {
+			err := auditLog.Write(utils.AuditRecord{Time: now.Add(-age), Method: "/idl.Hub/Status", Code: "OK"})
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		records := readLog(t, path, now.Add(-2*time.Hour))
+		if len(records) != 2 {
+			t.Fatalf("got %d records, want 2", len(records))
+		}
+	})
+
+	t.Run("errors on malformed records", func(t *testing.T) {
+		_, err := utils.ReadAuditLog(strings.NewReader("{}\nnot json\n"), time.Time{})
+		expected := "could not parse audit record on line 2"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// healthMethodPrefix is the prefix of the methods of the standard health
+// service, which every authenticated client may call so that liveness checks
+// keep working regardless of the policy.
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// AuthorizationPolicy decides which RPCs a client may call, based on the
+// identities in its verified certificate. Roles maps the name of a role to the
+// full gRPC method names its members may call, such as
+// "/idl.Hub/StatusAgents". Method names may contain shell patterns, as in
+// "/idl.Agent/*", and "*" allows every method. Identities maps the common
+// name or a DNS name of a client certificate to the roles of that client, and
+// clients not listed there get DefaultRoles.
+type AuthorizationPolicy struct {
+	Roles        map[string][]string `json:"roles"`
+	Identities   map[string][]string `json:"identities"`
+	DefaultRoles []string            `json:"defaultRoles,omitempty"`
+}
+
+// DefaultAuthorizationPolicy only allows the hub to call the agents and the CLI
+// to call the hub, so that an agent host cannot use its certificate to call
+// the other agents. The hub and CLI are identified by the common names of the
+// certificates gp certs issues for them, as well as by the given identities.
+func DefaultAuthorizationPolicy(hubIdentities []string, cliIdentities []string) *AuthorizationPolicy {
+	policy := &AuthorizationPolicy{
+		Roles: map[string][]string{
+			"hub": {"/idl.Agent/*"},
+			"cli": {"/idl.Hub/*"},
+		},
+		Identities: map[string][]string{},
+	}
+
+	add := func(role string, identities []string) {
+		for _, identity := range identities {
+			roles := policy.Identities[identity]
+			if len(roles) == 0 || roles[len(roles)-1] != role {
+				policy.Identities[identity] = append(roles, role)
+			}
+		}
+	}
+	add("hub", append([]string{HubIdentity}, hubIdentities...))
+	add("cli", append([]string{CLIIdentity}, cliIdentities...))
+
+	return policy
+}
+
+// CertificateAuthorizationPolicy returns the default authorization policy for
+// a cluster using its own certificates, which identifies the hub and the CLI
+// by the common names of the certificates configured for them.
+func CertificateAuthorizationPolicy(creds GpCredentials) (*AuthorizationPolicy, error) {
+	identity := func(role Role) (string, error) {
+		certPath, _ := creds.CertificatePaths(role)
+		cert, err := readCertificate(certPath)
+		if err != nil {
+			return "", fmt.Errorf("could not load %s certificate: %w", role, err)
+		}
+		if cert.Subject.CommonName == "" {
+			return "", fmt.Errorf("%s certificate %s has no common name to identify it by", role, certPath)
+		}
+
+		return cert.Subject.CommonName, nil
+	}
+
+	hub, err := identity(RoleHub)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := identity(RoleCLI)
+	if err != nil {
+		return nil, err
+	}
+
+	return DefaultAuthorizationPolicy([]string{hub}, []string{cli}), nil
+}
+
+// defaultAuthorizationPolicy applies when no policy is configured.
+var defaultAuthorizationPolicy = DefaultAuthorizationPolicy(nil, nil)
+
+// Validate checks that every role referred to is defined, and that every
+// method pattern is well formed.
+func (p *AuthorizationPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	for role, methods := range p.Roles {
+		for _, method := range methods {
+			if _, err := path.Match(method, ""); err != nil {
+				return fmt.Errorf("invalid authorization policy: invalid method pattern %q for role %s: %w", method, role, err)
+			}
+		}
+	}
+
+	check := func(owner string, roles []string) error {
+		for _, role := range roles {
+			if _, ok := p.Roles[role]; !ok {
+				return fmt.Errorf("%s refers to undefined role %s", owner, role)
+			}
+		}
+		return nil
+	}
+
+	for identity, roles := range p.Identities {
+		if err := check(fmt.Sprintf("identity %s", identity), roles); err != nil {
+			return fmt.Errorf("invalid authorization policy: %w", err)
+		}
+	}
+	if err := check("defaultRoles", p.DefaultRoles); err != nil {
+		return fmt.Errorf("invalid authorization policy: %w", err)
+	}
+
+	return nil
+}
+
+// Allowed reports whether a client with the given identities may call method.
+// A nil policy is the default policy for certificates issued by gp certs.
+func (p *AuthorizationPolicy) Allowed(identities []string, method string) bool {
+	if p == nil {
+		p = defaultAuthorizationPolicy
+	}
+
+	var roles []string
+	for _, identity := range identities {
+		roles = append(roles, p.Identities[identity]...)
+	}
+	if len(roles) == 0 {
+		roles = p.DefaultRoles
+	}
+
+	for _, role := range roles {
+		for _, pattern := range p.Roles[role] {
+			if pattern == "*" || pattern == method {
+				return true
+			}
+			if matched, _ := path.Match(pattern, method); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PeerIdentities returns the common name and the DNS names of the verified
+// certificate the client of an RPC presented, or nil if it did not present one.
+func PeerIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	identities := make([]string, 0, 1+len(cert.DNSNames))
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	for _, name := range cert.DNSNames {
+		if name != cert.Subject.CommonName {
+			identities = append(identities, name)
+		}
+	}
+
+	return identities
+}
+
+func (p *AuthorizationPolicy) authorize(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthMethodPrefix) {
+		return nil
+	}
+
+	identities := PeerIdentities(ctx)
+	if len(identities) == 0 {
+		return grpcStatus.Errorf(codes.Unauthenticated, "client did not present a certificate")
+	}
+
+	if !p.Allowed(identities, method) {
+		sorted := append([]string{}, identities...)
+		sort.Strings(sorted)
+		gplog.Warn("Denied call to %s by %s", method, strings.Join(sorted, ", "))
+		return grpcStatus.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", identities[0], method)
+	}
+
+	return nil
+}
+
+// UnaryAuthorizationInterceptor rejects unary RPCs the client is not allowed
+// to call according to policy.
+func UnaryAuthorizationInterceptor(policy *AuthorizationPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := policy.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthorizationInterceptor rejects streaming RPCs the client is not
+// allowed to call according to policy.
+func StreamAuthorizationInterceptor(policy *AuthorizationPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := policy.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,222 @@
+package utils_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizationPolicy(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	policy := &utils.AuthorizationPolicy{
+		Roles: map[string][]string{
+			"admin":      {"*"},
+			"agent":      {"/idl.Agent/*"},
+			"monitoring": {"/idl.Hub/StatusAgents", "/idl.Hub/Status"},
+		},
+		Identities: map[string][]string{
+			"gp-cli":  {"admin"},
+			"gp-hub":  {"agent"},
+			"monitor": {"monitoring"},
+		},
+	}
+
+	t.Run("allows the methods of the roles of an identity", func(t *testing.T) {
+		cases := []struct {
+			identities []string
+			method     string
+			expected   bool
+		}{
+			{[]string{"gp-cli"}, "/idl.Hub/StopAgents", true},
+			{[]string{"gp-hub"}, "/idl.Agent/Stop", true},
+			{[]string{"gp-hub"}, "/idl.Hub/Stop", false},
+			{[]string{"monitor"}, "/idl.Hub/StatusAgents", true},
+			{[]string{"monitor"}, "/idl.Hub/StopAgents", false},
+			{[]string{"monitor"}, "/idl.Hub/Stop", false},
+			{[]string{"unknown", "monitor"}, "/idl.Hub/Status", true},
+			{[]string{"unknown"}, "/idl.Hub/Status", false},
+		}
+
+		for _, tc := range cases {
+			result := policy.Allowed(tc.identities, tc.method)
+			if result != tc.expected {
+				t.Fatalf("got %t for %v calling %s, want %t", result, tc.identities, tc.method, tc.expected)
+			}
+		}
+	})
+
+	t.Run("applies the default roles to unlisted identities", func(t *testing.T) {
+		withDefault := *policy
+		withDefault.DefaultRoles = []string{"monitoring"}
+
+		if !withDefault.Allowed([]string{"unknown"}, "/idl.Hub/StatusAgents") {
+			t.Fatalf("expected the default role to apply")
+		}
+		if withDefault.Allowed([]string{"gp-hub"}, "/idl.Hub/StatusAgents") {
+			t.Fatalf("expected the default role not to apply to listed identities")
+		}
+	})
+
+	t.Run("only allows the hub to call the agents and the CLI to call the hub without a policy", func(t *testing.T) {
+		var noPolicy *utils.AuthorizationPolicy
+		cases := []struct {
+			identities []string
+			method     string
+			expected   bool
+		}{
+			{[]string{"gp-hub", "cdw"}, "/idl.Agent/Exec", true},
+			{[]string{"gp-cli"}, "/idl.Hub/Exec", true},
+			{[]string{"sdw1"}, "/idl.Agent/Exec", false},
+			{[]string{"sdw1"}, "/idl.Agent/PutFile", false},
+			{[]string{"sdw1"}, "/idl.Hub/StopAgents", false},
+			{[]string{"gp-hub"}, "/idl.Hub/Stop", false},
+			{[]string{"gp-cli"}, "/idl.Agent/Exec", false},
+		}
+
+		for _, tc := range cases {
+			result := noPolicy.Allowed(tc.identities, tc.method)
+			if result != tc.expected {
+				t.Fatalf("got %t for %v calling %s, want %t", result, tc.identities, tc.method, tc.expected)
+			}
+		}
+	})
+
+	t.Run("rejects agents calling the other agents without a policy", func(t *testing.T) {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "called", nil
+		}
+
+		_, err := utils.UnaryAuthorizationInterceptor(nil)(peerContext("sdw1", "sdw1"), nil, &grpc.UnaryServerInfo{FullMethod: "/idl.Agent/Exec"}, handler)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("got %v, want %v", err, codes.PermissionDenied)
+		}
+
+		stream := func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		}
+		err = utils.StreamAuthorizationInterceptor(nil)(nil, &contextStream{ctx: peerContext("sdw1", "sdw1")}, &grpc.StreamServerInfo{FullMethod: "/idl.Agent/Exec"}, stream)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("got %v, want %v", err, codes.PermissionDenied)
+		}
+	})
+
+	t.Run("identifies the hub and CLI by the common names of given certificates", func(t *testing.T) {
+		dir := t.TempDir()
+		ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		creds := utils.GpCredentials{
+			ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+			ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+			ClientCertPath: filepath.Join(dir, "client-cert.pem"),
+			ClientKeyPath:  filepath.Join(dir, "client-key.pem"),
+		}
+		err = ca.IssueLocalCertificate(utils.RoleHub, "cdw", []string{"cdw"}, time.Hour, creds.ServerCertPath, creds.ServerKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = ca.IssueLocalCertificate(utils.RoleCLI, "admin", nil, time.Hour, creds.ClientCertPath, creds.ClientKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		policy, err := utils.CertificateAuthorizationPolicy(creds)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := utils.DefaultAuthorizationPolicy([]string{"cdw"}, []string{"admin"})
+		if !reflect.DeepEqual(policy, expected) {
+			t.Fatalf("got %+v, want %+v", policy, expected)
+		}
+		if !policy.Allowed([]string{"cdw"}, "/idl.Agent/Exec") || !policy.Allowed([]string{"admin"}, "/idl.Hub/Exec") {
+			t.Fatalf("expected the given certificates to be allowed")
+		}
+		if !policy.Allowed([]string{"gp-hub"}, "/idl.Agent/Exec") {
+			t.Fatalf("expected certificates issued by gp certs to still be allowed")
+		}
+	})
+
+	t.Run("rejects policies referring to undefined roles", func(t *testing.T) {
+		err := policy.Validate()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		invalid := &utils.AuthorizationPolicy{
+			Roles:      map[string][]string{"admin": {"*"}},
+			Identities: map[string][]string{"gp-cli": {"operator"}},
+		}
+		err = invalid.Validate()
+		expected := "invalid authorization policy: identity gp-cli refers to undefined role operator"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("enforces the policy on the peer certificate", func(t *testing.T) {
+		interceptor := utils.UnaryAuthorizationInterceptor(policy)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "called", nil
+		}
+
+		cases := []struct {
+			ctx      context.Context
+			method   string
+			expected codes.Code
+		}{
+			{peerContext("monitor"), "/idl.Hub/StatusAgents", codes.OK},
+			{peerContext("monitor"), "/idl.Hub/StopAgents", codes.PermissionDenied},
+			{peerContext("monitor"), "/grpc.health.v1.Health/Check", codes.OK},
+			{context.Background(), "/idl.Hub/StatusAgents", codes.Unauthenticated},
+		}
+
+		for _, tc := range cases {
+			_, err := interceptor(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if status.Code(err) != tc.expected {
+				t.Fatalf("got %v calling %s, want %v", status.Code(err), tc.method, tc.expected)
+			}
+		}
+	})
+
+	t.Run("identifies the peer by the common name and DNS names of its certificate", func(t *testing.T) {
+		identities := utils.PeerIdentities(peerContext("sdw1", "sdw1", "sdw1.example.com"))
+		if len(identities) != 2 || identities[0] != "sdw1" || identities[1] != "sdw1.example.com" {
+			t.Fatalf("got %v, want %v", identities, []string{"sdw1", "sdw1.example.com"})
+		}
+	})
+}
+
+// peerContext returns a context as seen by a server whose client presented a
+// certificate with the given common name and DNS names.
+// contextStream is a server stream which only carries a context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func peerContext(commonName string, dnsNames ...string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}, DNSNames: dnsNames}
+	authInfo := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}}
+
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: authInfo})
+}
@@ -0,0 +1,413 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/executor"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+// File names of the certificates created by gp certs
+const (
+	CACertFileName     = "ca-cert.pem"
+	CAKeyFileName      = "ca-key.pem"
+	HubCertFileName    = "hub-cert.pem"
+	HubKeyFileName     = "hub-key.pem"
+	AgentCertFileName  = "agent-cert.pem"
+	AgentKeyFileName   = "agent-key.pem"
+	ClientCertFileName = "client-cert.pem"
+	ClientKeyFileName  = "client-key.pem"
+)
+
+// Common names of the hub and CLI certificates issued by gp certs, by which the
+// default authorization policy tells them apart from the agents
+const (
+	HubIdentity = "gp-hub"
+	CLIIdentity = "gp-cli"
+)
+
+// CertificateAuthority signs the certificates used by the hub, agents and CLI
+// of a cluster.
+type CertificateAuthority struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+func NewCertificateAuthority(commonName string, validity time.Duration) (*CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate CA key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Greenplum"}},
+		NotBefore:             now.Add(-time.Hour), // allow for some clock skew between hosts
+		NotAfter:              now.Add(validity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		MaxPathLenZero:        true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA certificate: %w", err)
+	}
+
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+func LoadCertificateAuthority(certPath string, keyPath string) (*CertificateAuthority, error) {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("could not find a certificate in %s", certPath)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA certificate %s: %w", certPath, err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", certPath)
+	}
+
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA key: %w", err)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA key %s: %w", keyPath, err)
+	}
+
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+// CertificatePEM returns the PEM encoded certificate of the CA, as distributed
+// to every host to verify its peers.
+func (ca *CertificateAuthority) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Cert.Raw})
+}
+
+// Write saves the CA certificate and key. The key is only readable by the
+// current user.
+func (ca *CertificateAuthority) Write(certPath string, keyPath string) error {
+	keyPEM, err := encodePrivateKey(ca.Key)
+	if err != nil {
+		return err
+	}
+
+	err = WriteFileAtomically(certPath, ca.CertificatePEM(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return WriteFileAtomically(keyPath, keyPEM, 0600)
+}
+
+// Issue creates a new key and a certificate signed by the CA for it, which can
+// only be used the way the given role uses it: agents only serve, the CLI
+// only connects, and the hub does both. Each host is added to the certificate
+// as a DNS or IP subject alternative name.
+func (ca *CertificateAuthority) Issue(role Role, commonName string, hosts []string, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not generate key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Greenplum"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  extKeyUsages(role),
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, ca.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create certificate for %s: %w", commonName, err)
+	}
+
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+// extKeyUsages returns what the certificate of the given role may be used for,
+// so that a certificate taken from an agent host cannot be used to connect to
+// the other agents or to the hub.
+func extKeyUsages(role Role) []x509.ExtKeyUsage {
+	switch role {
+	case RoleAgent:
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	case RoleCLI:
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	default:
+		return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+}
+
+// IssueLocalCertificate issues a certificate and writes it and its key to the
+// given paths on this host.
+func (ca *CertificateAuthority) IssueLocalCertificate(role Role, commonName string, hosts []string, validity time.Duration, certPath string, keyPath string) error {
+	certPEM, keyPEM, err := ca.Issue(role, commonName, hosts, validity)
+	if err != nil {
+		return err
+	}
+
+	err = WriteFileAtomically(certPath, certPEM, 0644)
+	if err != nil {
+		return err
+	}
+
+	return WriteFileAtomically(keyPath, keyPEM, 0600)
+}
+
+// InstallAgentCertificates issues a certificate for the agent on each host,
+// valid only for that host's name, and installs it on the host together with
+// the CA certificate. All hosts are handled in parallel. The fingerprints of
+// the installed certificates are returned by host.
+func (ca *CertificateAuthority) InstallAgentCertificates(hostnames []string, validity time.Duration, caCertPath string, certPath string, keyPath string) (map[string]string, error) {
+	ctx := context.Background()
+	results := make([]executor.Result, len(hostnames))
+	fingerprints := make([]string, len(hostnames))
+
+	var wg sync.WaitGroup
+	for i, host := range hostnames {
+		i, host := i, host
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results[i] = executor.Result{Host: host}
+			certPEM, keyPEM, err := ca.Issue(RoleAgent, host, []string{host}, validity)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+
+			fingerprints[i], err = PEMFingerprint(certPEM)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+
+			files := []struct {
+				path     string
+				contents []byte
+				mode     os.FileMode
+			}{
+				{caCertPath, ca.CertificatePEM(), 0644},
+				{certPath, certPEM, 0644},
+				{keyPath, keyPEM, 0600},
+			}
+			for _, file := range files {
+				result := executor.WriteFile(ctx, remoteExecutor, []string{host}, file.contents, file.mode, file.path)[0]
+				if result.Failure() != nil {
+					results[i] = result
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	err := executor.Errors(results)
+	if err != nil {
+		return nil, fmt.Errorf("could not install agent certificates: %w", err)
+	}
+
+	installed := make(map[string]string, len(hostnames))
+	for i, host := range hostnames {
+		installed[host] = fingerprints[i]
+	}
+
+	return installed, nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of a certificate, as printed by
+// "openssl x509 -fingerprint -sha256" but without the colons.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// NewCertificateInfo describes a certificate presented by host.
+func NewCertificateInfo(host string, cert *x509.Certificate) *idl.CertificateInfo {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return &idl.CertificateInfo{
+		Host:        host,
+		Subject:     cert.Subject.CommonName,
+		Issuer:      cert.Issuer.CommonName,
+		Sans:        sans,
+		Fingerprint: Fingerprint(cert),
+		NotAfter:    cert.NotAfter.Unix(),
+	}
+}
+
+// CheckValidity returns an error describing why cert is not valid at the
+// given time, if it is not.
+func CheckValidity(cert *x509.Certificate, now time.Time) error {
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("certificate %q expired on %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC1123))
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("certificate %q is not valid before %s", cert.Subject.CommonName, cert.NotBefore.Format(time.RFC1123))
+	}
+
+	return nil
+}
+
+// PEMFingerprint returns the fingerprint of the first certificate in certPEM.
+func PEMFingerprint(certPEM []byte) (string, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("could not find a certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("could not parse certificate: %w", err)
+	}
+
+	return Fingerprint(cert), nil
+}
+
+// WriteFileAtomically writes contents to a temporary file next to path and
+// renames it into place, creating the parent directory if needed.
+func WriteFileAtomically(path string, contents []byte, mode os.FileMode) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, fmt.Sprintf(".%s.*", filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("could not create temporary file in %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once the file has been renamed
+	defer tmp.Close()
+
+	_, err = tmp.Write(contents)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("could not generate serial number: %w", err)
+	}
+
+	return serial, nil
+}
+
+func encodePrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("could not find a private key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
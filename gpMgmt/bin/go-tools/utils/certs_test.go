@@ -0,0 +1,241 @@
+package utils_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/testutils"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	dir := t.TempDir()
+	caCertPath := filepath.Join(dir, utils.CACertFileName)
+	caKeyPath := filepath.Join(dir, utils.CAKeyFileName)
+
+	ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("writes the CA and loads it back", func(t *testing.T) {
+		err := ca.Write(caCertPath, caKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		info, err := os.Stat(caKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("got %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+		}
+
+		loaded, err := utils.LoadCertificateAuthority(caCertPath, caKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !loaded.Cert.Equal(ca.Cert) {
+			t.Fatalf("got CA %s, want %s", loaded.Cert.Subject, ca.Cert.Subject)
+		}
+	})
+
+	t.Run("errors out when the certificate is not a CA", func(t *testing.T) {
+		certPath := filepath.Join(dir, "leaf-cert.pem")
+		keyPath := filepath.Join(dir, "leaf-key.pem")
+		err := ca.IssueLocalCertificate(utils.RoleAgent, "leaf", []string{"sdw1"}, time.Hour, certPath, keyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = utils.LoadCertificateAuthority(certPath, keyPath)
+		expected := certPath + " is not a CA certificate"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("issues certificates which can be used for mutual TLS", func(t *testing.T) {
+		creds := &utils.GpCredentials{
+			CACertPath:     caCertPath,
+			ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+			ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+		}
+		err := ca.IssueLocalCertificate(utils.RoleHub, "sdw1", []string{"sdw1", "10.0.0.1"}, time.Hour, creds.ServerCertPath, creds.ServerKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for _, host := range []string{"sdw1", "10.0.0.1"} {
+			err = handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, host)
+			if err != nil {
+				t.Fatalf("unexpected error for host %s: %#v", host, err)
+			}
+		}
+
+		err = handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, "sdw2")
+		if err == nil {
+			t.Fatalf("expected the handshake to fail")
+		}
+	})
+
+	t.Run("issues certificates which can only be used the way their role uses them", func(t *testing.T) {
+		cases := []struct {
+			role     utils.Role
+			expected []x509.ExtKeyUsage
+		}{
+			{utils.RoleHub, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}},
+			{utils.RoleAgent, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}},
+			{utils.RoleCLI, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}},
+		}
+		for _, tc := range cases {
+			certPEM, _, err := ca.Issue(tc.role, "sdw1", []string{"sdw1"}, time.Hour)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			block, _ := pem.Decode(certPEM)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if !reflect.DeepEqual(cert.ExtKeyUsage, tc.expected) {
+				t.Fatalf("got %v, want %v for role %s", cert.ExtKeyUsage, tc.expected, tc.role)
+			}
+		}
+	})
+
+	t.Run("rejects agent certificates presented by clients", func(t *testing.T) {
+		creds := &utils.GpCredentials{
+			CACertPath:    caCertPath,
+			HubCertPath:   filepath.Join(dir, "hub-cert.pem"),
+			HubKeyPath:    filepath.Join(dir, "hub-key.pem"),
+			AgentCertPath: filepath.Join(dir, "agent-cert.pem"),
+			AgentKeyPath:  filepath.Join(dir, "agent-key.pem"),
+		}
+		err := ca.IssueLocalCertificate(utils.RoleHub, "gp-hub", []string{"cdw"}, time.Hour, creds.HubCertPath, creds.HubKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = ca.IssueLocalCertificate(utils.RoleAgent, "sdw1", []string{"sdw1"}, time.Hour, creds.AgentCertPath, creds.AgentKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, "sdw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = handshake(t, creds, creds, utils.RoleAgent, utils.RoleAgent, "sdw1")
+		if err == nil {
+			t.Fatalf("expected the agent certificate to be rejected as a client certificate")
+		}
+	})
+
+	t.Run("describes an issued certificate", func(t *testing.T) {
+		certPEM, _, err := ca.Issue(utils.RoleAgent, "sdw1", []string{"sdw1", "10.0.0.1"}, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		info := utils.NewCertificateInfo("sdw1", cert)
+		if info.Subject != "sdw1" || info.Issuer != "test CA" || !reflect.DeepEqual(info.Sans, []string{"sdw1", "10.0.0.1"}) {
+			t.Fatalf("got %+v, want the subject, issuer and SANs of the certificate", info)
+		}
+		if info.NotAfter != cert.NotAfter.Unix() || info.Fingerprint != utils.Fingerprint(cert) {
+			t.Fatalf("got %+v, want the expiry and fingerprint of the certificate", info)
+		}
+
+		err = utils.CheckValidity(cert, time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = utils.CheckValidity(cert, cert.NotAfter.Add(time.Second))
+		expected := fmt.Sprintf("certificate \"sdw1\" expired on %s", cert.NotAfter.Format(time.RFC1123))
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("rejects servers presenting an expired certificate", func(t *testing.T) {
+		creds := &utils.GpCredentials{
+			CACertPath:     caCertPath,
+			ServerCertPath: filepath.Join(dir, "expired-cert.pem"),
+			ServerKeyPath:  filepath.Join(dir, "expired-key.pem"),
+		}
+		err := ca.IssueLocalCertificate(utils.RoleHub, "sdw1", []string{"sdw1"}, -time.Minute, creds.ServerCertPath, creds.ServerKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, "sdw1")
+		expected := "server sdw1 presents an invalid certificate: certificate \"sdw1\" expired on"
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("installs a certificate for each agent host", func(t *testing.T) {
+		defer utils.ResetExecutor()
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+
+		fingerprints, err := ca.InstallAgentCertificates([]string{"sdw1", "sdw2"}, time.Hour, "/gphome/ca-cert.pem", "/gphome/agent-cert.pem", "/gphome/agent-key.pem")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(mockExecutor.Commands) != 6 {
+			t.Fatalf("got %d commands, want %d", len(mockExecutor.Commands), 6)
+		}
+		for i, command := range mockExecutor.Commands {
+			hosts := mockExecutor.Hosts[i]
+			if len(hosts) != 1 {
+				t.Fatalf("got hosts %+v, want a single host", hosts)
+			}
+
+			if strings.Contains(command, "agent-cert.pem") {
+				block, _ := pem.Decode(mockExecutor.Inputs[i])
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+				if !reflect.DeepEqual(cert.DNSNames, hosts) {
+					t.Fatalf("got certificate for %+v, want one for %+v", cert.DNSNames, hosts)
+				}
+				if fingerprints[hosts[0]] != utils.Fingerprint(cert) {
+					t.Fatalf("got fingerprint %s, want %s", fingerprints[hosts[0]], utils.Fingerprint(cert))
+				}
+			}
+			if strings.Contains(command, "agent-key.pem") && !strings.Contains(command, "chmod 600") {
+				t.Fatalf("got %q, want the key to only be readable by its owner", command)
+			}
+		}
+	})
+
+	t.Run("errors out when the certificates could not be installed", func(t *testing.T) {
+		defer utils.ResetExecutor()
+		utils.SetExecutor(&testutils.MockExecutor{Err: errors.New("connection refused")})
+
+		_, err := ca.InstallAgentCertificates([]string{"sdw1"}, time.Hour, "/gphome/ca-cert.pem", "/gphome/agent-cert.pem", "/gphome/agent-key.pem")
+		expected := "could not install agent certificates: host sdw1: connection refused"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+// NewFileInfo describes the local file at path for transferring it to or from
+// an agent.
+func NewFileInfo(path string) (*idl.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+
+	owner, err := FileOwner(info)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := FileChecksum(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idl.FileInfo{
+		Path:   path,
+		Mode:   uint32(info.Mode().Perm()),
+		Owner:  owner,
+		Sha256: checksum,
+	}, nil
+}
+
+// ReadFileChunks calls send with the contents of the file at path, split into
+// chunks of at most constants.FileChunkSize bytes.
+func ReadFileChunks(path string, send func(chunk []byte) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buf := make([]byte, constants.FileChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			sendErr := send(buf[:n])
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+	}
+}
+
+// FileChecksum returns the hex encoded SHA-256 checksum of the file at path.
+func FileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of contents.
+func Checksum(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
+
+// FileOwner returns the name of the user owning the file described by info.
+func FileOwner(info os.FileInfo) (string, error) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", fmt.Errorf("could not determine owner of %s", info.Name())
+	}
+
+	owner, err := user.LookupId(strconv.Itoa(int(stat.Uid)))
+	if err != nil {
+		return "", fmt.Errorf("could not determine owner of %s: %w", info.Name(), err)
+	}
+
+	return owner.Username, nil
+}
+
+// ChownFile changes the owner of the file at path to the named user, keeping
+// the user's primary group.
+func ChownFile(path string, username string) error {
+	owner, err := user.Lookup(username)
+	if err != nil {
+		return fmt.Errorf("could not look up user %s: %w", username, err)
+	}
+
+	uid, err := strconv.Atoi(owner.Uid)
+	if err != nil {
+		return fmt.Errorf("invalid uid for user %s: %w", username, err)
+	}
+	gid, err := strconv.Atoi(owner.Gid)
+	if err != nil {
+		return fmt.Errorf("invalid gid for user %s: %w", username, err)
+	}
+
+	err = os.Chown(path, uid, gid)
+	if err != nil {
+		return fmt.Errorf("could not change owner of %s to %s: %w", path, username, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+// GetHostInfo describes the state of this host, including the file systems
+// holding the data directories matching dataDirs. Whatever cannot be
+// determined on this platform is left unset.
+func GetHostInfo(dataDirs []string) (*idl.HostInfo, error) {
+	info := &idl.HostInfo{
+		Cpus:      int32(runtime.NumCPU()),
+		GpVersion: Version,
+	}
+	info.Host, _ = os.Hostname()
+	info.KernelVersion = kernelVersion()
+	info.Load1, info.Load5, info.Load15 = loadAverages()
+
+	memory := memoryInfo()
+	info.MemoryTotal = memory["MemTotal"]
+	info.MemoryAvailable = memory["MemAvailable"]
+	info.SwapTotal = memory["SwapTotal"]
+	info.SwapFree = memory["SwapFree"]
+
+	for _, pattern := range dataDirs {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data directory pattern %s: %w", pattern, err)
+		}
+
+		for _, path := range paths {
+			var stat syscall.Statfs_t
+			err := syscall.Statfs(path, &stat)
+			if err != nil {
+				return nil, fmt.Errorf("could not get the free space of %s: %w", path, err)
+			}
+
+			info.Disks = append(info.Disks, &idl.DiskUsage{
+				Path:  path,
+				Total: uint64(stat.Blocks) * uint64(stat.Bsize),
+				Free:  uint64(stat.Bavail) * uint64(stat.Bsize),
+			})
+		}
+	}
+
+	return info, nil
+}
+
+func kernelVersion() string {
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		release, err = exec.Command("uname", "-r").Output()
+		if err != nil {
+			return ""
+		}
+	}
+
+	return strings.TrimSpace(string(release))
+}
+
+func loadAverages() (float64, float64, float64) {
+	contents, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, 0, 0
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) < 3 {
+		return 0, 0, 0
+	}
+
+	loads := make([]float64, 3)
+	for i := range loads {
+		loads[i], _ = strconv.ParseFloat(fields[i], 64)
+	}
+
+	return loads[0], loads[1], loads[2]
+}
+
+// memoryInfo returns the sizes listed in /proc/meminfo in bytes, keyed by
+// their names.
+func memoryInfo() map[string]uint64 {
+	sizes := make(map[string]uint64)
+
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return sizes
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Lines look like "MemTotal:       32781400 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		size, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 2 && fields[2] == "kB" {
+			size *= 1024
+		}
+		sizes[strings.TrimSuffix(fields[0], ":")] = size
+	}
+
+	return sizes
+}
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestGetHostInfo(t *testing.T) {
+	t.Run("describes this host and the file systems of the data directories", func(t *testing.T) {
+		dir := t.TempDir()
+
+		info, err := utils.GetHostInfo([]string{filepath.Join(dir, "*"), dir})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if info.Host == "" || info.KernelVersion == "" || info.GpVersion != utils.Version {
+			t.Fatalf("got %+v, want the host name, kernel and gp version", info)
+		}
+		if info.Cpus != int32(runtime.NumCPU()) {
+			t.Fatalf("got %d, want %d", info.Cpus, runtime.NumCPU())
+		}
+		if runtime.GOOS == "linux" && (info.MemoryTotal == 0 || info.MemoryAvailable > info.MemoryTotal) {
+			t.Fatalf("got %d of %d bytes available, want the memory of this host", info.MemoryAvailable, info.MemoryTotal)
+		}
+
+		if len(info.Disks) != 1 || info.Disks[0].Path != dir {
+			t.Fatalf("got %+v, want only %s", info.Disks, dir)
+		}
+		if info.Disks[0].Total == 0 || info.Disks[0].Free > info.Disks[0].Total {
+			t.Fatalf("got %+v, want the size of its file system", info.Disks[0])
+		}
+	})
+
+	t.Run("errors out when a data directory pattern is invalid", func(t *testing.T) {
+		_, err := utils.GetHostInfo([]string{"/data/["})
+		expected := "invalid data directory pattern /data/[: syntax error in pattern"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+)
+
+// levelMarker delimits the log level which gplog prepends to every message,
+// so that the formatters below can take the message apart again.
+const levelMarker = "\x00"
+
+// LogOptions describes how the logs of a gp process are written.
+type LogOptions struct {
+	Path      string
+	Format    string // constants.LogFormatText or constants.LogFormatJSON; applies to the log file only
+	Component string // cli, hub or agent
+	Command   string
+	RequestID string // stamped on every line of a CLI command; the hub and agents stamp it per line instead
+	Rotation  LogRotation
+	Verbose   bool
+}
+
+// LogRotation limits the size and age of the log file. The zero value never
+// rotates the log.
+type LogRotation struct {
+	MaxSize    int64         // bytes
+	MaxAge     time.Duration // since the file was opened
+	MaxBackups int           // rotated files to keep; zero keeps all of them
+}
+
+// LogRecord is a log line in the JSON log format.
+type LogRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Host      string    `json:"host"`
+	Component string    `json:"component"`
+	Command   string    `json:"command"`
+	RequestID string    `json:"requestId,omitempty"`
+	Message   string    `json:"message"`
+}
+
+func ValidateLogFormat(format string) error {
+	if format != constants.LogFormatText && format != constants.LogFormatJSON {
+		return fmt.Errorf("invalid log format %q: expected %s or %s", format, constants.LogFormatText, constants.LogFormatJSON)
+	}
+
+	return nil
+}
+
+// InitializeLogging sets up gplog to write to the log file described by
+// options, as well as to the terminal. Terminal output is always in the text
+// format.
+func InitializeLogging(options LogOptions) error {
+	err := ValidateLogFormat(options.Format)
+	if err != nil {
+		return err
+	}
+
+	file, err := OpenRotatingFile(options.Path, options.Rotation)
+	if err != nil {
+		return err
+	}
+
+	hostname, _ := os.Hostname()
+	formatter := func(out io.Writer, format string) io.Writer {
+		return &logFormatter{out: out, format: format, hostname: hostname, options: options}
+	}
+
+	logger := gplog.NewLogger(formatter(os.Stdout, constants.LogFormatText), formatter(os.Stderr, constants.LogFormatText),
+		formatter(file, options.Format), options.Path, gplog.LOGINFO, options.Command)
+	gplog.SetLogger(logger)
+	gplog.SetLogPrefixFunc(func(level string) string {
+		return levelMarker + level + levelMarker
+	})
+	gplog.SetExitFunc(func() { os.Exit(1) })
+	if options.Verbose {
+		gplog.SetVerbosity(gplog.LOGDEBUG)
+	}
+
+	return nil
+}
+
+// logFormatter turns the messages written by gplog into log lines of the
+// given format. gplog writes each message with a single call to Write.
+type logFormatter struct {
+	out      io.Writer
+	format   string
+	hostname string
+	options  LogOptions
+}
+
+func (f *logFormatter) Write(p []byte) (int, error) {
+	record := f.parse(strings.TrimSuffix(string(p), "\n"))
+
+	var line []byte
+	if f.format == constants.LogFormatJSON {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			return 0, err
+		}
+		line = append(encoded, '\n')
+	} else {
+		request := ""
+		if record.RequestID != "" {
+			request = fmt.Sprintf("[request %s] ", record.RequestID)
+		}
+		line = []byte(fmt.Sprintf("%s %s  [%s] %s%s\n", record.Timestamp.Format("2006-01-02 15:04:05.000000"),
+			record.Host, record.Level, request, record.Message))
+	}
+
+	_, err := f.out.Write(line)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (f *logFormatter) parse(message string) LogRecord {
+	record := LogRecord{
+		Timestamp: time.Now(),
+		Host:      f.hostname,
+		Component: f.options.Component,
+		Command:   f.options.Command,
+		RequestID: f.options.RequestID,
+		Message:   message,
+	}
+
+	if strings.HasPrefix(message, levelMarker) {
+		parts := strings.SplitN(message[len(levelMarker):], levelMarker, 2)
+		if len(parts) == 2 {
+			record.Level, record.Message = parts[0], parts[1]
+		}
+	}
+
+	// Messages logged by the hub and agents on behalf of a request carry its ID
+	if strings.HasPrefix(record.Message, "[request ") {
+		if end := strings.Index(record.Message, "] "); end > 0 {
+			record.RequestID = record.Message[len("[request "):end]
+			record.Message = record.Message[end+2:]
+		}
+	}
+
+	return record
+}
+
+// LogFileName returns the name of the log file of the gp command with the
+// given command path, e.g. gp_stop_hub.log for "gp stop hub".
+func LogFileName(commandPath string) string {
+	return strings.ReplaceAll(commandPath, " ", "_") + ".log"
+}
+
+// CurrentLogFile returns the log file in dir which the gp command with the
+// given command path is writing to. Right after the log file was rotated it
+// may not have been recreated yet, in which case the most recently rotated
+// file is returned.
+func CurrentLogFile(dir string, commandPath string) (string, error) {
+	path := filepath.Join(dir, LogFileName(commandPath))
+	_, err := os.Stat(path)
+	if err == nil {
+		return path, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("could not open log file: %w", err)
+	}
+
+	backups, err := rotatedLogFiles(path)
+	if err != nil {
+		return "", err
+	}
+	if len(backups) == 0 {
+		return "", fmt.Errorf("no log file %s found in %s", filepath.Base(path), dir)
+	}
+
+	return backups[len(backups)-1], nil
+}
+
+// rotatedLogFiles returns the files the log file at path was rotated to, from
+// the oldest to the newest. The timestamps in their names sort chronologically.
+func rotatedLogFiles(path string) ([]string, error) {
+	backups, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("could not list rotated log files: %w", err)
+	}
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+// RotatingFile is a log file which is moved aside once it grows too large or
+// too old, keeping a limited number of rotated files. Rotated files are named
+// after the log file with the time of rotation appended.
+type RotatingFile struct {
+	path     string
+	rotation LogRotation
+
+	mutex  sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+func OpenRotatingFile(path string, rotation LogRotation) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, rotation: rotation}
+
+	err := f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(f.path), 0755)
+	if err != nil {
+		return fmt.Errorf("could not create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+
+	return nil
+}
+
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.shouldRotate(int64(len(p))) {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+func (f *RotatingFile) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.file.Close()
+}
+
+func (f *RotatingFile) shouldRotate(length int64) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.rotation.MaxSize > 0 && f.size+length > f.rotation.MaxSize {
+		return true
+	}
+
+	return f.rotation.MaxAge > 0 && time.Since(f.opened) > f.rotation.MaxAge
+}
+
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	if err != nil {
+		return fmt.Errorf("could not close log file: %w", err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", f.path, time.Now().Format("20060102-150405.000000"))
+	err = os.Rename(f.path, rotated)
+	if err != nil {
+		return fmt.Errorf("could not rotate log file: %w", err)
+	}
+
+	err = f.removeOldBackups()
+	if err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// removeOldBackups deletes the oldest rotated files beyond MaxBackups.
+func (f *RotatingFile) removeOldBackups() error {
+	if f.rotation.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := rotatedLogFiles(f.path)
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > f.rotation.MaxBackups {
+		err = os.Remove(backups[0])
+		if err != nil {
+			return fmt.Errorf("could not remove rotated log file: %w", err)
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestInitializeLogging(t *testing.T) {
+	defer testhelper.SetupTestLogger()
+
+	readLines := func(t *testing.T, path string) []string {
+		t.Helper()
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return strings.Split(strings.TrimSpace(string(contents)), "\n")
+	}
+
+	t.Run("writes one JSON object per line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_hub.log")
+		err := utils.InitializeLogging(utils.LogOptions{
+			Path:      path,
+			Format:    constants.LogFormatJSON,
+			Component: "hub",
+			Command:   "gp hub",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		gplog.Debug("first message")
+		gplog.Debug("[request 0123456789abcdef] second message")
+
+		lines := readLines(t, path)
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2", len(lines))
+		}
+
+		var record utils.LogRecord
+		err = json.Unmarshal([]byte(lines[1]), &record)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		hostname, _ := os.Hostname()
+		if record.Level != "DEBUG" || record.Host != hostname || record.Component != "hub" || record.Command != "gp hub" {
+			t.Fatalf("got %+v, want level DEBUG, host %s, component hub and command gp hub", record, hostname)
+		}
+		if record.RequestID != "0123456789abcdef" || record.Message != "second message" {
+			t.Fatalf("got request ID %q and message %q, want %q and %q", record.RequestID, record.Message, "0123456789abcdef", "second message")
+		}
+		if record.Timestamp.IsZero() {
+			t.Fatalf("expected a timestamp")
+		}
+	})
+
+	t.Run("writes text lines stamped with the request ID of the command", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_stop_agents.log")
+		err := utils.InitializeLogging(utils.LogOptions{
+			Path:      path,
+			Format:    constants.LogFormatText,
+			Component: "cli",
+			Command:   "gp stop agents",
+			RequestID: "0123456789abcdef",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		gplog.Debug("stopping agents")
+
+		lines := readLines(t, path)
+		expected := regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{6} \S+  \[DEBUG\] \[request 0123456789abcdef\] stopping agents$`)
+		if len(lines) != 1 || !expected.MatchString(lines[0]) {
+			t.Fatalf("got %q, want it to match %s", lines, expected)
+		}
+	})
+
+	t.Run("errors out on an unknown format", func(t *testing.T) {
+		err := utils.InitializeLogging(utils.LogOptions{Path: filepath.Join(t.TempDir(), "gp.log"), Format: "xml"})
+		expected := `invalid log format "xml": expected text or json`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("rotates the file once it exceeds the maximum size and keeps the newest backups", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "gp_hub.log")
+		file, err := utils.OpenRotatingFile(path, utils.LogRotation{MaxSize: 10, MaxBackups: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+			_, err = file.Write([]byte(line))
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(contents) != "line 4\n" {
+			t.Fatalf("got %q, want %q", contents, "line 4\n")
+		}
+
+		backups, err := filepath.Glob(path + ".*")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if len(backups) != 2 {
+			t.Fatalf("got %v, want 2 rotated files", backups)
+		}
+
+		contents, err = os.ReadFile(backups[1])
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(contents) != "line 3\n" {
+			t.Fatalf("got %q, want %q", contents, "line 3\n")
+		}
+	})
+
+	t.Run("does not rotate without limits", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_hub.log")
+		file, err := utils.OpenRotatingFile(path, utils.LogRotation{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		for i := 0; i < 3; i++ {
+			_, err = file.Write([]byte("line\n"))
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		backups, _ := filepath.Glob(path + ".*")
+		if len(backups) != 0 {
+			t.Fatalf("got %v, want no rotated files", backups)
+		}
+	})
+}
+
+func TestCurrentLogFile(t *testing.T) {
+	t.Run("names the log file after the command path", func(t *testing.T) {
+		name := utils.LogFileName("gp stop hub")
+		if name != "gp_stop_hub.log" {
+			t.Fatalf("got %s, want %s", name, "gp_stop_hub.log")
+		}
+	})
+
+	t.Run("returns the log file the rotating file writes to", func(t *testing.T) {
+		dir := t.TempDir()
+		file, err := utils.OpenRotatingFile(filepath.Join(dir, utils.LogFileName("gp agent")), utils.LogRotation{MaxSize: 5})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		for _, line := range []string{"line 1\n", "line 2\n"} {
+			_, err = file.Write([]byte(line))
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		path, err := utils.CurrentLogFile(dir, "gp agent")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if string(contents) != "line 2\n" {
+			t.Fatalf("got %q, want %q", contents, "line 2\n")
+		}
+	})
+
+	t.Run("returns the most recently rotated file while the log file is not recreated yet", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"gp_agent.log.20240101-120000.000000", "gp_agent.log.20240102-120000.000000", "gp_hub.log"} {
+			err := os.WriteFile(filepath.Join(dir, name), nil, 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		path, err := utils.CurrentLogFile(dir, "gp agent")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		expected := filepath.Join(dir, "gp_agent.log.20240102-120000.000000")
+		if path != expected {
+			t.Fatalf("got %s, want %s", path, expected)
+		}
+	})
+
+	t.Run("errors out when there is no log file", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, err := utils.CurrentLogFile(dir, "gp agent")
+		expected := "no log file gp_agent.log found in " + dir
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogArchive writes log files into a tar archive.
+type LogArchive struct {
+	tw *tar.Writer
+}
+
+func NewLogArchive(w io.Writer) *LogArchive {
+	return &LogArchive{tw: tar.NewWriter(w)}
+}
+
+// Close writes the end of the archive, but does not close the underlying writer.
+func (a *LogArchive) Close() error {
+	return a.tw.Close()
+}
+
+// AddFile adds the file at path to the archive under name. Log files may
+// still be written to, so only their contents at the time they were added
+// are archived.
+func (a *LogArchive) AddFile(name string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("could not stat %s: %w", path, err)
+	}
+
+	err = a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not archive %s: %w", path, err)
+	}
+
+	_, err = io.CopyN(a.tw, file, info.Size())
+	if err != nil {
+		return fmt.Errorf("could not archive %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// AddBytes adds contents to the archive under name.
+func (a *LogArchive) AddBytes(name string, contents []byte) error {
+	err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(contents)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("could not archive %s: %w", name, err)
+	}
+
+	_, err = a.tw.Write(contents)
+	if err != nil {
+		return fmt.Errorf("could not archive %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// AddLogFiles adds the regular files in dir whose names match pattern and
+// which were modified since the given time, or all of them if it is zero. They
+// are added under prefix, keeping their names, which are returned.
+func (a *LogArchive) AddLogFiles(prefix string, dir string, pattern string, since time.Time) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid log file pattern %q: %w", pattern, err)
+	}
+
+	names := make([]string, 0)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not stat %s: %w", path, err)
+		}
+		if !info.Mode().IsRegular() || info.ModTime().Before(since) {
+			continue
+		}
+
+		name := filepath.Join(prefix, filepath.Base(path))
+		err = a.AddFile(name, path)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// AddArchive adds the files of the tar archive read from r under prefix, and
+// returns their names in this archive.
+func (a *LogArchive) AddArchive(prefix string, r io.Reader) ([]string, error) {
+	tr := tar.NewReader(r)
+
+	names := make([]string, 0)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read archive: %w", err)
+		}
+
+		header.Name = filepath.Join(prefix, header.Name)
+		err = a.tw.WriteHeader(header)
+		if err != nil {
+			return nil, fmt.Errorf("could not archive %s: %w", header.Name, err)
+		}
+
+		_, err = io.Copy(a.tw, tr)
+		if err != nil {
+			return nil, fmt.Errorf("could not archive %s: %w", header.Name, err)
+		}
+		names = append(names, header.Name)
+	}
+}
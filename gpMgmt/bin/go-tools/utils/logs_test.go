@@ -0,0 +1,148 @@
+package utils_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+// readArchive returns the contents of the files in a tar archive by their names.
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		files[header.Name] = string(contents)
+	}
+}
+
+func TestLogArchive(t *testing.T) {
+	t.Run("adds the matching log files modified since the given time", func(t *testing.T) {
+		dir := t.TempDir()
+		for name, contents := range map[string]string{
+			"gp_agent.log":     "current\n",
+			"gp_agent.log.old": "rotated\n",
+			"gp_hub.log":       "other command\n",
+		} {
+			err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+		modified := time.Now().Add(-2 * time.Hour)
+		err := os.Chtimes(filepath.Join(dir, "gp_agent.log.old"), modified, modified)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = os.Mkdir(filepath.Join(dir, "gp_agent.dir"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var buffer bytes.Buffer
+		archive := utils.NewLogArchive(&buffer)
+		names, err := archive.AddLogFiles("agent", dir, "gp_agent*", time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = archive.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectedNames := []string{"agent/gp_agent.log"}
+		if !reflect.DeepEqual(names, expectedNames) {
+			t.Fatalf("got %v, want %v", names, expectedNames)
+		}
+		expected := map[string]string{"agent/gp_agent.log": "current\n"}
+		if files := readArchive(t, &buffer); !reflect.DeepEqual(files, expected) {
+			t.Fatalf("got %v, want %v", files, expected)
+		}
+	})
+
+	t.Run("adds all the matching log files without a time", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "gp_agent.log")
+		err := os.WriteFile(path, []byte("old\n"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = os.Chtimes(path, time.Unix(0, 0), time.Unix(0, 0))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		archive := utils.NewLogArchive(io.Discard)
+		names, err := archive.AddLogFiles("agent", dir, "*", time.Time{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"agent/gp_agent.log"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("got %v, want %v", names, expected)
+		}
+	})
+
+	t.Run("rejects an invalid log file pattern", func(t *testing.T) {
+		archive := utils.NewLogArchive(io.Discard)
+		_, err := archive.AddLogFiles("agent", t.TempDir(), "gp_agent[", time.Time{})
+		if err == nil || !strings.HasPrefix(err.Error(), `invalid log file pattern "gp_agent[":`) {
+			t.Fatalf("got %v, want an invalid pattern error", err)
+		}
+	})
+
+	t.Run("adds the files of another archive under a prefix", func(t *testing.T) {
+		var inner bytes.Buffer
+		innerArchive := utils.NewLogArchive(&inner)
+		err := innerArchive.AddBytes("journal/gp_agent.log", []byte("journal entry\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = innerArchive.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var buffer bytes.Buffer
+		archive := utils.NewLogArchive(&buffer)
+		names, err := archive.AddArchive("sdw1", &inner)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		err = archive.Close()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectedNames := []string{"sdw1/journal/gp_agent.log"}
+		if !reflect.DeepEqual(names, expectedNames) {
+			t.Fatalf("got %v, want %v", names, expectedNames)
+		}
+		expected := map[string]string{"sdw1/journal/gp_agent.log": "journal entry\n"}
+		if files := readArchive(t, &buffer); !reflect.DeepEqual(files, expected) {
+			t.Fatalf("got %v, want %v", files, expected)
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"google.golang.org/grpc"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the RPC latency
+// histogram buckets.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics collects the RPC and process metrics of the hub or an agent, and
+// exposes them in the Prometheus text format. A nil Metrics collects nothing.
+type Metrics struct {
+	prefix string
+	start  time.Time
+
+	mutex     sync.Mutex
+	requests  map[rpcKey]uint64
+	durations map[string]*histogram
+	gauges    []gauge
+}
+
+type rpcKey struct {
+	method string
+	code   string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative; the last one counts the overflow
+	sum    float64
+	total  uint64
+}
+
+type gauge struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// NewMetrics returns metrics whose names are prefixed by gp_<component>_, for
+// example gp_hub_rpc_requests_total.
+func NewMetrics(component string) *Metrics {
+	return &Metrics{
+		prefix:    fmt.Sprintf("gp_%s_", component),
+		start:     time.Now(),
+		requests:  make(map[rpcKey]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// AddGauge registers a gauge whose value is computed on every scrape.
+func (m *Metrics) AddGauge(name string, help string, value func() float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.gauges = append(m.gauges, gauge{name: m.prefix + name, help: help, value: value})
+}
+
+func (m *Metrics) observe(method string, err error, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests[rpcKey{method: method, code: grpcStatus.Code(err).String()}]++
+
+	h, ok := m.durations[method]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets)+1)}
+		m.durations[method] = h
+	}
+
+	seconds := duration.Seconds()
+	bucket := sort.SearchFloat64s(durationBuckets, seconds)
+	h.counts[bucket]++
+	h.sum += seconds
+	h.total++
+}
+
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m == nil {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, err, time.Since(start))
+
+		return resp, err
+	}
+}
+
+func (m *Metrics) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if m == nil {
+			return handler(srv, stream)
+		}
+
+		start := time.Now()
+		err := handler(srv, stream)
+		m.observe(info.FullMethod, err, time.Since(start))
+
+		return err
+	}
+}
+
+// Write writes all metrics in the Prometheus text exposition format. The
+// gauges are computed without holding the lock, as they may wait on the state
+// of the hub or agent while RPCs keep being counted.
+func (m *Metrics) Write(w io.Writer) {
+	m.mutex.Lock()
+	m.writeRPCMetrics(w)
+	gauges := append([]gauge(nil), m.gauges...)
+	m.mutex.Unlock()
+
+	writeGauge(w, m.prefix+"uptime_seconds", "Time since the service started.", time.Since(m.start).Seconds())
+	for _, g := range gauges {
+		writeGauge(w, g.name, g.help, g.value())
+	}
+
+	writeProcessMetrics(w, m.start)
+}
+
+// writeRPCMetrics writes the RPC counters and latency histograms. m.mutex must
+// be held.
+func (m *Metrics) writeRPCMetrics(w io.Writer) {
+	requests := m.prefix + "rpc_requests_total"
+	fmt.Fprintf(w, "# HELP %s Number of RPCs served, by method and gRPC code.\n", requests)
+	fmt.Fprintf(w, "# TYPE %s counter\n", requests)
+	keys := make([]rpcKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s{method=%q,code=%q} %d\n", requests, key.method, key.code, m.requests[key])
+	}
+
+	durations := m.prefix + "rpc_duration_seconds"
+	fmt.Fprintf(w, "# HELP %s Time taken to serve RPCs, by method.\n", durations)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", durations)
+	methods := make([]string, 0, len(m.durations))
+	for method := range m.durations {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		h := m.durations[method]
+		var cumulative uint64
+		for i, bound := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(w, "%s_bucket{method=%q,le=%q} %d\n", durations, method, formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{method=%q,le=\"+Inf\"} %d\n", durations, method, h.total)
+		fmt.Fprintf(w, "%s_sum{method=%q} %s\n", durations, method, formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count{method=%q} %d\n", durations, method, h.total)
+	}
+}
+
+// ServeHTTP serves the metrics to Prometheus.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.Write(w)
+}
+
+// ServeMetrics serves the metrics over plain HTTP on /metrics of the given
+// port until the returned server is closed.
+func ServeMetrics(port int, m *Metrics) (*http.Server, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("could not listen for metrics on port %d: %w", port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			gplog.Error("Metrics server stopped: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+func writeGauge(w io.Writer, name string, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(w, "%s %s\n", name, formatFloat(value))
+}
+
+// writeProcessMetrics writes the standard Prometheus process metrics. Those
+// read from /proc are left out on platforms without it.
+func writeProcessMetrics(w io.Writer, start time.Time) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err == nil {
+		cpu := time.Duration(usage.Utime.Nano() + usage.Stime.Nano()).Seconds()
+		fmt.Fprintln(w, "# HELP process_cpu_seconds_total Total user and system CPU time spent in seconds.")
+		fmt.Fprintln(w, "# TYPE process_cpu_seconds_total counter")
+		fmt.Fprintf(w, "process_cpu_seconds_total %s\n", formatFloat(cpu))
+	}
+
+	if statm, err := os.ReadFile("/proc/self/statm"); err == nil {
+		fields := strings.Fields(string(statm))
+		if len(fields) > 1 {
+			if pages, err := strconv.ParseFloat(fields[1], 64); err == nil {
+				writeGauge(w, "process_resident_memory_bytes", "Resident memory size in bytes.", pages*float64(os.Getpagesize()))
+			}
+		}
+	}
+
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		writeGauge(w, "process_open_fds", "Number of open file descriptors.", float64(len(fds)))
+	}
+
+	writeGauge(w, "process_start_time_seconds", "Start time of the process since unix epoch in seconds.", float64(start.Unix()))
+	writeGauge(w, "go_goroutines", "Number of goroutines that currently exist.", float64(runtime.NumGoroutine()))
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,151 @@
+package utils_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics(t *testing.T) {
+	scrape := func(t *testing.T, metrics *utils.Metrics) string {
+		t.Helper()
+
+		server := httptest.NewServer(metrics)
+		defer server.Close()
+
+		resp, err := server.Client().Get(server.URL + "/metrics")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return string(body)
+	}
+
+	expectLines := func(t *testing.T, output string, expected ...string) {
+		t.Helper()
+
+		lines := strings.Split(output, "\n")
+		for _, line := range expected {
+			found := false
+			for _, actual := range lines {
+				if actual == line {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("got %s, want it to contain %q", output, line)
+			}
+		}
+	}
+
+	t.Run("counts RPCs by method and code", func(t *testing.T) {
+		metrics := utils.NewMetrics("hub")
+		interceptor := metrics.UnaryInterceptor()
+		succeed := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		fail := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unavailable, "agent is down")
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StatusAgents"}
+		for _, handler := range []grpc.UnaryHandler{succeed, succeed, fail} {
+			_, _ = interceptor(context.Background(), nil, info, handler)
+		}
+
+		expectLines(t, scrape(t, metrics),
+			"# TYPE gp_hub_rpc_requests_total counter",
+			`gp_hub_rpc_requests_total{method="/idl.Hub/StatusAgents",code="OK"} 2`,
+			`gp_hub_rpc_requests_total{method="/idl.Hub/StatusAgents",code="Unavailable"} 1`,
+			"# TYPE gp_hub_rpc_duration_seconds histogram",
+			`gp_hub_rpc_duration_seconds_bucket{method="/idl.Hub/StatusAgents",le="30"} 3`,
+			`gp_hub_rpc_duration_seconds_bucket{method="/idl.Hub/StatusAgents",le="+Inf"} 3`,
+			`gp_hub_rpc_duration_seconds_count{method="/idl.Hub/StatusAgents"} 3`,
+		)
+	})
+
+	t.Run("counts streaming RPCs", func(t *testing.T) {
+		metrics := utils.NewMetrics("agent")
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		}
+
+		err := metrics.StreamInterceptor()(nil, nil, &grpc.StreamServerInfo{FullMethod: "/idl.Agent/Exec"}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectLines(t, scrape(t, metrics), `gp_agent_rpc_requests_total{method="/idl.Agent/Exec",code="OK"} 1`)
+	})
+
+	t.Run("reports gauges, uptime and process metrics", func(t *testing.T) {
+		metrics := utils.NewMetrics("hub")
+		metrics.AddGauge("agents_connected", "Number of agents the hub is connected to.", func() float64 {
+			return 3
+		})
+
+		output := scrape(t, metrics)
+		expectLines(t, output,
+			"# HELP gp_hub_agents_connected Number of agents the hub is connected to.",
+			"# TYPE gp_hub_agents_connected gauge",
+			"gp_hub_agents_connected 3",
+			"# TYPE gp_hub_uptime_seconds gauge",
+			"# TYPE process_cpu_seconds_total counter",
+			"# TYPE go_goroutines gauge",
+		)
+	})
+
+	t.Run("counts RPCs while a gauge is being computed", func(t *testing.T) {
+		metrics := utils.NewMetrics("hub")
+		interceptor := metrics.UnaryInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		metrics.AddGauge("agents_connected", "Number of agents the hub is connected to.", func() float64 {
+			// An RPC finishing while the hub is busy working out the value
+			_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StatusAgents"}, handler)
+			return 1
+		})
+
+		done := make(chan string, 1)
+		go func() {
+			var output strings.Builder
+			metrics.Write(&output)
+			done <- output.String()
+		}()
+
+		select {
+		case output := <-done:
+			expectLines(t, output, "gp_hub_agents_connected 1")
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the RPC to be counted while the gauge is computed")
+		}
+	})
+
+	t.Run("passes calls through without metrics", func(t *testing.T) {
+		var metrics *utils.Metrics
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "called", nil
+		}
+
+		resp, err := metrics.UnaryInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/Status"}, handler)
+		if err != nil || resp != "called" {
+			t.Fatalf("got %v, %v, want the handler to be called", resp, err)
+		}
+	})
+}
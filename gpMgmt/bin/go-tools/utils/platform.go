@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,10 +12,12 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/executor"
 	"github.com/greenplum-db/gpdb/gp/idl"
 	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
 )
@@ -23,11 +26,15 @@ var (
 	platform             Platform
 	execCommand          = exec.Command
 	writeServiceFileFunc = WriteServiceFile
-	GpsyncCommand        = exec.Command
 	LoadServiceCommand   = exec.Command
 	UnloadServiceCommand = exec.Command
 )
 
+var remoteExecutor executor.Executor = executor.NewSSHExecutor()
+
+// FileWriter writes contents to dest on the given hosts.
+type FileWriter func(hosts []string, contents []byte, mode os.FileMode, dest string) error
+
 type GpPlatform struct {
 	OS         string
 	ServiceCmd string // Binary for managing services
@@ -62,19 +69,21 @@ func NewPlatform(os string) (Platform, error) {
 }
 
 type Platform interface {
-	CreateServiceDir(hostnames []string, serviceDir string, gphome string) error
+	CreateServiceDir(hostnames []string, serviceDir string) error
 	GenerateServiceFileContents(process string, gphome string, serviceName string) string
 	GetDefaultServiceDir() string
 	ReloadHubService(servicePath string) error
-	ReloadAgentService(gphome string, hostList []string, servicePath string) error
+	ReloadAgentService(hostnames []string, servicePath string) error
 	CreateAndInstallHubServiceFile(gphome string, serviceDir string, serviceName string) error
-	CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string) error
+	CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string, writeFile FileWriter) error
+	RemoveAgentServiceFile(hostnames []string, serviceDir string, serviceName string) error
 	GetStartHubCommand(serviceName string) *exec.Cmd
 	GetStartAgentCommandString(serviceName string) []string
 	GetServiceStatusMessage(serviceName string) (string, error)
+	GetServiceLogCommand(serviceName string, since time.Time, until time.Time) *exec.Cmd
 	ParseServiceStatusMessage(message string) idl.ServiceStatus
 	DisplayServiceStatus(outfile io.Writer, serviceName string, statuses []*idl.ServiceStatus, skipHeader bool)
-	EnableUserLingering(hostnames []string, gphome string, serviceUser string) error
+	EnableUserLingering(hostnames []string, serviceUser string) error
 }
 
 func GetPlatform() Platform {
@@ -91,16 +100,10 @@ func GetPlatform() Platform {
 	return platform
 }
 
-func (p GpPlatform) CreateServiceDir(hostnames []string, serviceDir string, gphome string) error {
-	hostList := make([]string, 0)
-	for _, host := range hostnames {
-		hostList = append(hostList, "-h", host)
-	}
-
+func (p GpPlatform) CreateServiceDir(hostnames []string, serviceDir string) error {
 	// Create service directory if it does not exist
-	args := append(hostList, "mkdir", "-p", serviceDir)
-	utility := filepath.Join(gphome, "bin", constants.GpSSH)
-	err := execCommand(utility, args...).Run()
+	results := remoteExecutor.Run(context.Background(), hostnames, fmt.Sprintf("mkdir -p %s", executor.Quote(serviceDir)))
+	err := executor.Errors(results)
 	if err != nil {
 		return fmt.Errorf("could not create service directory %s on hosts: %w", serviceDir, err)
 	}
@@ -227,17 +230,17 @@ func (p GpPlatform) ReloadHubService(servicePath string) error {
 	return nil
 }
 
-func (p GpPlatform) ReloadAgentService(gphome string, hostList []string, servicePath string) error {
-	args := append(hostList, p.ServiceCmd)
-
+func (p GpPlatform) ReloadAgentService(hostnames []string, servicePath string) error {
 	if p.OS == constants.PlatformDarwin { // launchctl reloads a specific service, not all of them
 		// launchctl does not have a single reload command. Hence unload and load the file to update the configuration.
-		err := UnloadServiceCommand(fmt.Sprintf("%s/bin/gpssh", gphome), append(args, "unload", servicePath)...).Run()
+		results := remoteExecutor.Run(context.Background(), hostnames, fmt.Sprintf("%s unload %s", p.ServiceCmd, executor.Quote(servicePath)))
+		err := executor.Errors(results)
 		if err != nil {
 			return fmt.Errorf("could not unload agent service file %s on segment hosts: %w", servicePath, err)
 		}
 
-		err = LoadServiceCommand(fmt.Sprintf("%s/bin/gpssh", gphome), append(args, "load", servicePath)...).Run()
+		results = remoteExecutor.Run(context.Background(), hostnames, fmt.Sprintf("%s load %s", p.ServiceCmd, executor.Quote(servicePath)))
+		err = executor.Errors(results)
 		if err != nil {
 			return fmt.Errorf("could not load agent service file %s on segment hosts: %w", servicePath, err)
 		}
@@ -245,7 +248,8 @@ func (p GpPlatform) ReloadAgentService(gphome string, hostList []string, service
 		return nil
 	}
 
-	err := execCommand(fmt.Sprintf("%s/bin/gpssh", gphome), append(args, p.UserArg, "daemon-reload")...).Run()
+	results := remoteExecutor.Run(context.Background(), hostnames, fmt.Sprintf("%s %s daemon-reload", p.ServiceCmd, p.UserArg))
+	err := executor.Errors(results)
 	if err != nil {
 		return fmt.Errorf("could not reload agent service file %s on segment hosts: %w", servicePath, err)
 	}
@@ -253,29 +257,19 @@ func (p GpPlatform) ReloadAgentService(gphome string, hostList []string, service
 	return nil
 }
 
-func (p GpPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string) error {
+// CreateAndInstallAgentServiceFile writes the agent service file to the given
+// hosts with writeFile, and has the service manager on them load it.
+func (p GpPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gphome string, serviceDir string, serviceName string, writeFile FileWriter) error {
 	agentServiceContents := p.GenerateServiceFileContents("agent", gphome, serviceName)
-	localAgentServiceFilePath := fmt.Sprintf("./%s_agent.%s", serviceName, p.ServiceExt)
-	err := writeServiceFileFunc(localAgentServiceFilePath, agentServiceContents)
-	if err != nil {
-		return err
-	}
-	defer os.Remove(localAgentServiceFilePath)
-
 	remoteAgentServiceFilePath := fmt.Sprintf("%s/%s_agent.%s", serviceDir, serviceName, p.ServiceExt)
-	hostList := make([]string, 0)
-	for _, host := range hostnames {
-		hostList = append(hostList, "-h", host)
-	}
 
 	// Copy the file to segment host service directories
-	args := append(hostList, localAgentServiceFilePath, fmt.Sprintf("=:%s", remoteAgentServiceFilePath))
-	err = GpsyncCommand(fmt.Sprintf("%s/bin/gpsync", gphome), args...).Run()
+	err := writeFile(hostnames, []byte(agentServiceContents), 0644, remoteAgentServiceFilePath)
 	if err != nil {
 		return fmt.Errorf("could not copy agent service files to segment hosts: %w", err)
 	}
 
-	err = p.ReloadAgentService(gphome, hostList, remoteAgentServiceFilePath)
+	err = p.ReloadAgentService(hostnames, remoteAgentServiceFilePath)
 	if err != nil {
 		return err
 	}
@@ -315,6 +309,25 @@ func (p GpPlatform) GetServiceStatusMessage(serviceName string) (string, error)
 	return string(output), nil
 }
 
+// GetServiceLogCommand returns the command printing the log of the given
+// service between since and until, either of which may be zero. launchd keeps
+// no per-service logs, so it returns nil on Darwin.
+func (p GpPlatform) GetServiceLogCommand(serviceName string, since time.Time, until time.Time) *exec.Cmd {
+	if p.OS == constants.PlatformDarwin {
+		return nil
+	}
+
+	args := []string{p.UserArg, "--unit", serviceName, "--no-pager", "--output", "short-iso"}
+	if !since.IsZero() {
+		args = append(args, "--since", since.Format("2006-01-02 15:04:05"))
+	}
+	if !until.IsZero() {
+		args = append(args, "--until", until.Format("2006-01-02 15:04:05"))
+	}
+
+	return execCommand("journalctl", args...)
+}
+
 /*
 Example service status output
 
@@ -325,14 +338,15 @@ ExecMainCode=0
 ExecMainStatus=0
 
 Darwin:
-{
-	"PID" = 19909;
-	"Program" = "/usr/local/gpdb/bin/gp";
-	"ProgramArguments" = (
-		"/usr/local/gpdb/bin/gp";
-		"hub";
-	);
-};
+
+	{
+		"PID" = 19909;
+		"Program" = "/usr/local/gpdb/bin/gp";
+		"ProgramArguments" = (
+			"/usr/local/gpdb/bin/gp";
+			"hub";
+		);
+	};
 */
 func (p GpPlatform) ParseServiceStatusMessage(message string) idl.ServiceStatus {
 	var status, uptime string
@@ -370,29 +384,55 @@ func (p GpPlatform) DisplayServiceStatus(outfile io.Writer, serviceName string,
 	w.Init(outfile, 0, 8, 2, '\t', 0)
 
 	if !skipHeader {
-		fmt.Fprintln(w, "ROLE\tHOST\tSTATUS\tPID\tUPTIME")
+		fmt.Fprintln(w, "ROLE\tHOST\tSTATUS\tPID\tUPTIME\tWARNING")
 	}
 
 	for _, s := range statuses {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", serviceName, s.Host, s.Status, s.Pid, s.Uptime)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", serviceName, s.Host, s.Status, s.Pid, s.Uptime, s.Warning)
 	}
 	w.Flush()
 }
 
+// RemoveAgentServiceFile stops the agents on the given hosts and removes their
+// service files, so that they are no longer started with the host.
+func (p GpPlatform) RemoveAgentServiceFile(hostnames []string, serviceDir string, serviceName string) error {
+	remoteAgentServiceFilePath := executor.Quote(fmt.Sprintf("%s/%s_agent.%s", serviceDir, serviceName, p.ServiceExt))
+
+	// Stopping fails if the agent was never started, which is fine here
+	command := fmt.Sprintf("%s unload %s; rm -f %s", p.ServiceCmd, remoteAgentServiceFilePath, remoteAgentServiceFilePath)
+	if p.OS != constants.PlatformDarwin {
+		command = fmt.Sprintf("%s %s stop %s_agent; rm -f %s && %s %s daemon-reload",
+			p.ServiceCmd, p.UserArg, serviceName, remoteAgentServiceFilePath, p.ServiceCmd, p.UserArg)
+	}
+
+	results := remoteExecutor.Run(context.Background(), hostnames, command)
+	err := executor.Errors(results)
+	if err != nil {
+		return fmt.Errorf("could not remove agent service files from hosts: %w", err)
+	}
+
+	gplog.Info("Removed agent service file %s/%s_agent.%s from hosts %s", serviceDir, serviceName, p.ServiceExt, strings.Join(hostnames, ", "))
+	return nil
+}
+
+// WriteFileOverSSH writes contents to dest on the given hosts over SSH. It is
+// meant for hosts which do not run an agent yet; files for running agents are
+// copied through them instead.
+func WriteFileOverSSH(hosts []string, contents []byte, mode os.FileMode, dest string) error {
+	results := executor.WriteFile(context.Background(), remoteExecutor, hosts, contents, mode, dest)
+
+	return executor.Errors(results)
+}
+
 // Allow systemd services to run on startup and be started/stopped without root access
 // This is a no-op on Mac, as launchctl lacks the concept of user lingering
-func (p GpPlatform) EnableUserLingering(hostnames []string, gphome string, serviceUser string) error {
+func (p GpPlatform) EnableUserLingering(hostnames []string, serviceUser string) error {
 	if p.OS != "linux" {
 		return nil
 	}
 
-	hostList := make([]string, 0)
-	for _, host := range hostnames {
-		hostList = append(hostList, "-h", host)
-	}
-
-	remoteCmd := append(hostList, "loginctl enable-linger ", serviceUser)
-	err := execCommand(fmt.Sprintf("%s/bin/gpssh", gphome), remoteCmd...).Run()
+	results := remoteExecutor.Run(context.Background(), hostnames, fmt.Sprintf("loginctl enable-linger %s", executor.Quote(serviceUser)))
+	err := executor.Errors(results)
 	if err != nil {
 		return fmt.Errorf("could not enable user lingering: %w", err)
 	}
@@ -408,6 +448,14 @@ func ResetExecCommand() {
 	execCommand = exec.Command
 }
 
+func SetExecutor(e executor.Executor) {
+	remoteExecutor = e
+}
+
+func ResetExecutor() {
+	remoteExecutor = executor.NewSSHExecutor()
+}
+
 func SetWriteServiceFileFunc(writeFunc func(filename string, contents string) error) {
 	writeServiceFileFunc = writeFunc
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/testutils"
 	"github.com/greenplum-db/gpdb/gp/testutils/exectest"
 	"github.com/greenplum-db/gpdb/gp/utils"
 )
@@ -31,13 +32,11 @@ func TestMain(m *testing.M) {
 }
 
 func setMocks(t *testing.T) {
-	utils.GpsyncCommand = nil
 	utils.LoadServiceCommand = nil
 	utils.UnloadServiceCommand = nil
 }
 
 func resetMocks(t *testing.T) {
-	utils.GpsyncCommand = exec.Command
 	utils.LoadServiceCommand = exec.Command
 	utils.UnloadServiceCommand = exec.Command
 }
@@ -48,11 +47,11 @@ func TestCreateServiceDir(t *testing.T) {
 	t.Run("CreateServiceDir returns error", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Failure))
-		defer utils.ResetExecCommand()
+		utils.SetExecutor(&testutils.MockExecutor{ExitCode: 1})
+		defer utils.ResetExecutor()
 
-		err := platform.CreateServiceDir([]string{"host1"}, "path/to/serviceDir", "gphome")
-		if err.Error() != "could not create service directory path/to/serviceDir on hosts: exit status 1" {
+		err := platform.CreateServiceDir([]string{"host1"}, "path/to/serviceDir")
+		if err.Error() != "could not create service directory path/to/serviceDir on hosts: host host1: exit status 1" {
 			t.Fatalf("unexpected error: %#v", err)
 		}
 	})
@@ -60,13 +59,19 @@ func TestCreateServiceDir(t *testing.T) {
 	t.Run("CreateServiceDir runs successfully", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer utils.ResetExecCommand()
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+		defer utils.ResetExecutor()
 
-		err := platform.CreateServiceDir([]string{"host1"}, "path/to/serviceDir", "gphome")
+		err := platform.CreateServiceDir([]string{"host1"}, "path/to/serviceDir")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		expectedCommands := []string{"mkdir -p 'path/to/serviceDir'"}
+		if !reflect.DeepEqual(mockExecutor.Commands, expectedCommands) {
+			t.Fatalf("got %+v, want %+v", mockExecutor.Commands, expectedCommands)
+		}
 	})
 }
 
@@ -225,11 +230,13 @@ func TestReloadServices(t *testing.T) {
 
 			utils.SetExecCommand(exectest.NewCommand(exectest.Success))
 			defer utils.ResetExecCommand()
+			utils.SetExecutor(&testutils.MockExecutor{})
+			defer utils.ResetExecutor()
 
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
-				err = platform.ReloadAgentService("gphome", []string{"host1"}, "/path/to/service/file")
+				err = platform.ReloadAgentService([]string{"host1"}, "/path/to/service/file")
 			}
 
 			if err != nil {
@@ -238,79 +245,92 @@ func TestReloadServices(t *testing.T) {
 		})
 	}
 
-	failure_tests_darwin := []test{
-		{os: "darwin", service: "hub"},
-		{os: "darwin", service: "agent", errSuffix: " on segment hosts"},
-	}
-	for _, tc := range failure_tests_darwin {
-		t.Run(fmt.Sprintf("reloading of %s service returns error when not able to unload the file on darwin", tc.service), func(t *testing.T) {
-			var err error
-			platform := GetPlatform(tc.os, t)
+	t.Run("reloading of hub service returns error when not able to unload the file on darwin", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformDarwin, t)
 
-			setMocks(t)
-			defer resetMocks(t)
-			utils.UnloadServiceCommand = exectest.NewCommand(exectest.Failure)
-			utils.LoadServiceCommand = exectest.NewCommand(exectest.Success)
+		setMocks(t)
+		defer resetMocks(t)
+		utils.UnloadServiceCommand = exectest.NewCommand(exectest.Failure)
+		utils.LoadServiceCommand = exectest.NewCommand(exectest.Success)
 
-			if tc.service == "hub" {
-				err = platform.ReloadHubService("/path/to/service/file")
-			} else {
-				err = platform.ReloadAgentService("gphome", []string{"host1"}, "/path/to/service/file")
-			}
+		err := platform.ReloadHubService("/path/to/service/file")
+		expectedErr := "could not unload hub service file /path/to/service/file: exit status 1"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
 
-			expectedErr := fmt.Sprintf("could not unload %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
-			if err.Error() != expectedErr {
-				t.Fatalf("got %q, want %q", err, expectedErr)
-			}
-		})
+	t.Run("reloading of hub service returns error when not able to load the file on darwin", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformDarwin, t)
 
-		t.Run(fmt.Sprintf("reloading of %s service returns error when not able to load the file on darwin", tc.service), func(t *testing.T) {
-			var err error
-			platform := GetPlatform(constants.PlatformDarwin, t)
+		setMocks(t)
+		defer resetMocks(t)
+		utils.UnloadServiceCommand = exectest.NewCommand(exectest.Success)
+		utils.LoadServiceCommand = exectest.NewCommand(exectest.Failure)
 
-			setMocks(t)
-			defer resetMocks(t)
-			utils.UnloadServiceCommand = exectest.NewCommand(exectest.Success)
-			utils.LoadServiceCommand = exectest.NewCommand(exectest.Failure)
+		err := platform.ReloadHubService("/path/to/service/file")
+		expectedErr := "could not load hub service file /path/to/service/file: exit status 1"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
 
-			if tc.service == "hub" {
-				err = platform.ReloadHubService("/path/to/service/file")
-			} else {
-				err = platform.ReloadAgentService("gphome", []string{"host1"}, "/path/to/service/file")
-			}
+	t.Run("reloading of agent service unloads and loads the file on darwin", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformDarwin, t)
 
-			expectedErr := fmt.Sprintf("could not load %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
-			if err.Error() != expectedErr {
-				t.Fatalf("got %q, want %q", err, expectedErr)
-			}
-		})
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+		defer utils.ResetExecutor()
 
-	}
+		err := platform.ReloadAgentService([]string{"host1"}, "/path/to/service/file")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
 
-	failure_tests_linux := []test{
-		{os: constants.PlatformLinux, service: "hub"},
-		{os: constants.PlatformLinux, service: "agent", errSuffix: " on segment hosts"},
-	}
-	for _, tc := range failure_tests_linux {
-		t.Run(fmt.Sprintf("reloading of %s service returns error when not able to reload the file on linux", tc.service), func(t *testing.T) {
-			var err error
-			platform := GetPlatform(constants.PlatformLinux, t)
+		expectedCommands := []string{"launchctl unload '/path/to/service/file'", "launchctl load '/path/to/service/file'"}
+		if !reflect.DeepEqual(mockExecutor.Commands, expectedCommands) {
+			t.Fatalf("got %+v, want %+v", mockExecutor.Commands, expectedCommands)
+		}
+	})
 
-			utils.SetExecCommand(exectest.NewCommand(exectest.Failure))
-			defer utils.ResetExecCommand()
+	t.Run("reloading of agent service returns error when not able to unload the file on darwin", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformDarwin, t)
 
-			if tc.service == "hub" {
-				err = platform.ReloadHubService("/path/to/service/file")
-			} else {
-				err = platform.ReloadAgentService("gphome", []string{"host1"}, "/path/to/service/file")
-			}
+		utils.SetExecutor(&testutils.MockExecutor{ExitCode: 1})
+		defer utils.ResetExecutor()
 
-			expectedErr := fmt.Sprintf("could not reload %s service file /path/to/service/file%s: exit status 1", tc.service, tc.errSuffix)
-			if err.Error() != expectedErr {
-				t.Fatalf("got %q, want %q", err, expectedErr)
-			}
-		})
-	}
+		err := platform.ReloadAgentService([]string{"host1"}, "/path/to/service/file")
+		expectedErr := "could not unload agent service file /path/to/service/file on segment hosts: host host1: exit status 1"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
+
+	t.Run("reloading of hub service returns error when not able to reload the file on linux", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformLinux, t)
+
+		utils.SetExecCommand(exectest.NewCommand(exectest.Failure))
+		defer utils.ResetExecCommand()
+
+		err := platform.ReloadHubService("/path/to/service/file")
+		expectedErr := "could not reload hub service file /path/to/service/file: exit status 1"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
+
+	t.Run("reloading of agent service returns error when not able to reload the file on linux", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformLinux, t)
+
+		utils.SetExecutor(&testutils.MockExecutor{ExitCode: 1, Stderr: "Failed to connect to bus"})
+		defer utils.ResetExecutor()
+
+		err := platform.ReloadAgentService([]string{"host1"}, "/path/to/service/file")
+		expectedErr := "could not reload agent service file /path/to/service/file on segment hosts: host host1: exit status 1: Failed to connect to bus"
+		if err.Error() != expectedErr {
+			t.Fatalf("got %q, want %q", err, expectedErr)
+		}
+	})
 }
 
 func TestCreateAndInstallHubServiceFile(t *testing.T) {
@@ -376,105 +396,83 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 	t.Run("CreateAndInstallAgentServiceFile runs successfully", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
-		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
-			return nil
-		})
-		defer utils.ResetWriteServiceFileFunc()
-
-		setMocks(t)
-		defer resetMocks(t)
-		utils.GpsyncCommand = exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
-			if !strings.HasSuffix(utility, "gpsync") {
-				t.Fatalf("got %q, want gpsync", utility)
-			}
-
-			expectedArgs := []string{"-h", "host1", "-h", "host2", "./gptest_agent.service", "=:testdir/gptest_agent.service"}
-			if !reflect.DeepEqual(args, expectedArgs) {
-				t.Fatalf("got %+v, want %+v", args, expectedArgs)
-			}
-		})
-
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer utils.ResetExecCommand()
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+		defer utils.ResetExecutor()
 
-		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest")
+		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest", utils.WriteFileOverSSH)
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
-	})
 
-	t.Run("CreateAndInstallAgentServiceFile errors when gpsync fails", func(t *testing.T) {
-		platform := GetPlatform(constants.PlatformLinux, t)
+		expectedCommands := []string{
+			"mkdir -p 'testdir' && cat > 'testdir/gptest_agent.service.tmp' && chmod 644 'testdir/gptest_agent.service.tmp' && mv -f 'testdir/gptest_agent.service.tmp' 'testdir/gptest_agent.service'",
+			"systemctl --user daemon-reload",
+		}
+		if !reflect.DeepEqual(mockExecutor.Commands, expectedCommands) {
+			t.Fatalf("got %+v, want %+v", mockExecutor.Commands, expectedCommands)
+		}
 
-		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
-			return nil
-		})
-		defer utils.ResetWriteServiceFileFunc()
+		expectedContents := platform.GenerateServiceFileContents("agent", "gphome", "gptest")
+		if string(mockExecutor.Inputs[0]) != expectedContents {
+			t.Fatalf("got %q, want %q", mockExecutor.Inputs[0], expectedContents)
+		}
+	})
 
-		setMocks(t)
-		defer resetMocks(t)
-		utils.GpsyncCommand = exectest.NewCommandWithVerifier(exectest.Failure, func(utility string, args ...string) {
-			if !strings.HasSuffix(utility, "gpsync") {
-				t.Fatalf("got %q, want gpsync", utility)
-			}
+	t.Run("CreateAndInstallAgentServiceFile errors when copying the file fails", func(t *testing.T) {
+		platform := GetPlatform(constants.PlatformLinux, t)
 
-			expectedArgs := []string{"-h", "host1", "-h", "host2", "./gptest_agent.service", "=:testdir/gptest_agent.service"}
-			if !reflect.DeepEqual(args, expectedArgs) {
-				t.Fatalf("got %+v, want %+v", args, expectedArgs)
-			}
-		})
+		utils.SetExecutor(&testutils.MockExecutor{Err: errors.New("connection refused")})
+		defer utils.ResetExecutor()
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer utils.ResetExecCommand()
-
-		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest")
-		expectedErr := "could not copy agent service files to segment hosts: exit status 1"
+		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest", utils.WriteFileOverSSH)
+		expectedErr := "could not copy agent service files to segment hosts: host host1: connection refused; host host2: connection refused"
 		if err.Error() != expectedErr {
 			t.Fatalf("got %q, want %q", err, expectedErr)
 		}
 	})
+}
 
-	t.Run("CreateAndInstallAgentServiceFile errors when not able to write to a file", func(t *testing.T) {
-		platform := GetPlatform(constants.PlatformLinux, t)
+func TestRemoveAgentServiceFile(t *testing.T) {
+	testhelper.SetupTestLogger()
 
-		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
-			return os.ErrPermission
-		})
-		defer utils.ResetWriteServiceFileFunc()
+	t.Run("stops the agents and removes their service files", func(t *testing.T) {
+		cases := []struct {
+			os       string
+			expected string
+		}{
+			{constants.PlatformLinux, "systemctl --user stop gptest_agent; rm -f 'testdir/gptest_agent.service' && systemctl --user daemon-reload"},
+			{constants.PlatformDarwin, "launchctl unload 'testdir/gptest_agent.plist'; rm -f 'testdir/gptest_agent.plist'"},
+		}
+		for _, c := range cases {
+			platform := GetPlatform(c.os, t)
 
-		setMocks(t)
-		defer resetMocks(t)
-		utils.GpsyncCommand = exectest.NewCommand(exectest.Success)
+			mockExecutor := &testutils.MockExecutor{}
+			utils.SetExecutor(mockExecutor)
+			defer utils.ResetExecutor()
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer utils.ResetExecCommand()
+			err := platform.RemoveAgentServiceFile([]string{"host1", "host2"}, "testdir", "gptest")
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
 
-		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest")
-		expectedErr := os.ErrPermission
-		if !errors.Is(err, expectedErr) {
-			t.Fatalf("got %q, want %q", err, expectedErr)
+			expectedHosts := [][]string{{"host1", "host2"}}
+			if !reflect.DeepEqual(mockExecutor.Commands, []string{c.expected}) || !reflect.DeepEqual(mockExecutor.Hosts, expectedHosts) {
+				t.Fatalf("got %+v on %v, want %q on %v", mockExecutor.Commands, mockExecutor.Hosts, c.expected, expectedHosts)
+			}
 		}
 	})
 
-	t.Run("CreateAndInstallAgentServiceFile errors when not able to reload the service", func(t *testing.T) {
+	t.Run("errors when the service files could not be removed", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
-		utils.SetWriteServiceFileFunc(func(filename, contents string) error {
-			return nil
-		})
-		defer utils.ResetWriteServiceFileFunc()
-
-		setMocks(t)
-		defer resetMocks(t)
-		utils.GpsyncCommand = exectest.NewCommand(exectest.Success)
+		utils.SetExecutor(&testutils.MockExecutor{Err: errors.New("connection refused")})
+		defer utils.ResetExecutor()
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Failure))
-		defer utils.ResetExecCommand()
-
-		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gphome", "testdir", "gptest")
-		expectedErr := "could not reload agent service file testdir/gptest_agent.service on segment hosts: exit status 1"
-		if err.Error() != expectedErr {
-			t.Fatalf("got %q, want %q", err, expectedErr)
+		err := platform.RemoveAgentServiceFile([]string{"host1"}, "testdir", "gptest")
+		expectedErr := "could not remove agent service files from hosts: host host1: connection refused"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %v", err, expectedErr)
 		}
 	})
 }
@@ -710,7 +708,7 @@ func TestDisplayServiceStatus(t *testing.T) {
 
 		platform.DisplayServiceStatus(&output, "hub", statuses, true)
 
-		expected := "hub\tsdw1\trunning\t\t1234\t5H\n"
+		expected := "hub\tsdw1\trunning\t\t1234\t5H\t\n"
 		if output.String() != expected {
 			t.Fatalf("got %q, want %q", output.String(), expected)
 		}
@@ -730,7 +728,7 @@ func TestDisplayServiceStatus(t *testing.T) {
 
 		platform.DisplayServiceStatus(&output, "hub", statuses, false)
 
-		expected := "ROLE\tHOST\tSTATUS\t\tPID\tUPTIME\nhub\tsdw1\trunning\t\t1234\t5H\n"
+		expected := "ROLE\tHOST\tSTATUS\t\tPID\tUPTIME\tWARNING\nhub\tsdw1\trunning\t\t1234\t5H\t\n"
 		if output.String() != expected {
 			t.Fatalf("got %q, want %q", output.String(), expected)
 		}
@@ -743,19 +741,25 @@ func TestEnableUserLingering(t *testing.T) {
 	t.Run("EnableUserLingering run successfully for linux", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
 
-		utils.SetExecCommand(exectest.NewCommand(exectest.Success))
-		defer utils.ResetExecCommand()
+		mockExecutor := &testutils.MockExecutor{}
+		utils.SetExecutor(mockExecutor)
+		defer utils.ResetExecutor()
 
-		err := platform.EnableUserLingering([]string{"host1", "host2"}, "/path/to/gphome", "serviceUser")
+		err := platform.EnableUserLingering([]string{"host1", "host2"}, "serviceUser")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		expectedCommands := []string{"loginctl enable-linger 'serviceUser'"}
+		if !reflect.DeepEqual(mockExecutor.Commands, expectedCommands) {
+			t.Fatalf("got %+v, want %+v", mockExecutor.Commands, expectedCommands)
+		}
 	})
 
 	t.Run("EnableUserLingering runs successfully for other platforms", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformDarwin, t)
 
-		err := platform.EnableUserLingering([]string{"host1", "host2"}, "path/to/gphome", "serviceUser")
+		err := platform.EnableUserLingering([]string{"host1", "host2"}, "serviceUser")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
@@ -763,11 +767,11 @@ func TestEnableUserLingering(t *testing.T) {
 
 	t.Run("EnableUserLingering returns error on failure", func(t *testing.T) {
 		platform := GetPlatform(constants.PlatformLinux, t)
-		utils.SetExecCommand(exectest.NewCommand(exectest.Failure))
-		defer utils.ResetExecCommand()
+		utils.SetExecutor(&testutils.MockExecutor{ExitCode: 1})
+		defer utils.ResetExecutor()
 
-		err := platform.EnableUserLingering([]string{"host1", "host2"}, "path/to/gphome", "serviceUser")
-		expected := "could not enable user lingering: exit status 1"
+		err := platform.EnableUserLingering([]string{"host1", "host2"}, "serviceUser")
+		expected := "could not enable user lingering: host host1: exit status 1; host host2: exit status 1"
 		if err.Error() != expected {
 			t.Fatalf("got %q, want %q", err, expected)
 		}
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+)
+
+var (
+	reloadersMutex sync.Mutex
+	reloaders      = map[reloaderKey]*credentialsReloader{}
+)
+
+type reloaderKey struct {
+	certPath string
+	keyPath  string
+	caPath   string
+}
+
+// credentialsReloader holds the certificate, key and CA certificate presented
+// and trusted by a TLS endpoint, and reloads them from disk whenever one of
+// the files changes, so that certificates can be rotated without restarting
+// the hub or the agents. If reloading fails, for example because only some of
+// the files have been replaced so far, the previous material stays in use and
+// reloading is retried on the next handshake.
+type credentialsReloader struct {
+	reloaderKey
+
+	mutex    sync.Mutex
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// reloaderFor returns the reloader for the given files. Reloaders are shared,
+// so that the same files are not reloaded once for every connection.
+func reloaderFor(certPath string, keyPath string, caPath string) *credentialsReloader {
+	reloadersMutex.Lock()
+	defer reloadersMutex.Unlock()
+
+	key := reloaderKey{certPath: certPath, keyPath: keyPath, caPath: caPath}
+	r, ok := reloaders[key]
+	if !ok {
+		r = &credentialsReloader{reloaderKey: key, modTimes: map[string]time.Time{}}
+		reloaders[key] = r
+	}
+
+	return r
+}
+
+// ReloadCredentials reloads all credentials which have been loaded so far,
+// regardless of whether their files appear to have changed.
+func ReloadCredentials() error {
+	reloadersMutex.Lock()
+	defer reloadersMutex.Unlock()
+
+	var failures []string
+	for _, r := range reloaders {
+		err := r.reloadCertificate()
+		if err == nil {
+			err = r.reloadCAPool()
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.certPath, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("could not reload credentials: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// ReloadCredentialsOnSignal reloads all credentials whenever the process
+// receives SIGHUP, until the returned function is called.
+func ReloadCredentialsOnSignal() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				err := ReloadCredentials()
+				if err != nil {
+					gplog.Warn("%v", err)
+					continue
+				}
+				gplog.Info("Reloaded credentials")
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+func (r *credentialsReloader) reloadCertificate() error {
+	modTimes := r.stat(r.certPath, r.keyPath)
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cert = &cert
+	for path, modTime := range modTimes {
+		r.modTimes[path] = modTime
+	}
+
+	return nil
+}
+
+func (r *credentialsReloader) reloadCAPool() error {
+	modTimes := r.stat(r.caPath)
+	pool, err := loadCACertPool(r.caPath)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pool = pool
+	for path, modTime := range modTimes {
+		r.modTimes[path] = modTime
+	}
+
+	return nil
+}
+
+// reloadIfChanged reloads any of the files which were modified since they were
+// last loaded successfully.
+func (r *credentialsReloader) reloadIfChanged() {
+	changed := func(paths ...string) bool {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		for path, modTime := range r.stat(paths...) {
+			if !modTime.Equal(r.modTimes[path]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if changed(r.certPath, r.keyPath) {
+		err := r.reloadCertificate()
+		if err != nil {
+			gplog.Warn("Could not reload certificate %s, continuing to use the previous one: %v", r.certPath, err)
+		} else {
+			gplog.Info("Reloaded certificate %s", r.certPath)
+		}
+	}
+
+	if changed(r.caPath) {
+		err := r.reloadCAPool()
+		if err != nil {
+			gplog.Warn("Could not reload CA certificate %s, continuing to use the previous one: %v", r.caPath, err)
+		} else {
+			gplog.Info("Reloaded CA certificate %s", r.caPath)
+		}
+	}
+}
+
+func (r *credentialsReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.cert, r.pool
+}
+
+// stat returns the modification times of the given files. Files which cannot
+// be read are left out, so that they count as changed once they reappear.
+func (r *credentialsReloader) stat(paths ...string) map[string]time.Time {
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+
+	return modTimes
+}
+
+// serverConfig returns a TLS configuration which presents the current
+// certificate to each new client, and only accepts clients with a certificate
+// signed by the current CA.
+func (r *credentialsReloader) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.reloadIfChanged()
+			cert, pool := r.current()
+
+			return &tls.Config{
+				Certificates: []tls.Certificate{*cert},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    pool,
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// clientConfig returns a TLS configuration which presents the current
+// certificate, and verifies servers against the current CA. The standard
+// verification is replaced by an equivalent one in VerifyConnection, as the
+// trusted CAs of a tls.Config cannot be changed once it is in use.
+func (r *credentialsReloader) clientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, // nolint: the server is verified in VerifyConnection
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		VerifyConnection: func(state tls.ConnectionState) error {
+			r.reloadIfChanged()
+			_, pool := r.current()
+
+			if len(state.PeerCertificates) == 0 {
+				return fmt.Errorf("server did not present a certificate")
+			}
+
+			// Name the most common reason for a failed verification explicitly,
+			// rather than leaving it to a generic verification error
+			err := CheckValidity(state.PeerCertificates[0], time.Now())
+			if err != nil {
+				return fmt.Errorf("server %s presents an invalid certificate: %w", state.ServerName, err)
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				DNSName:       state.ServerName,
+				Roots:         pool,
+				Intermediates: intermediates,
+			})
+			return err
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestCredentialsReload(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	ca, err := utils.NewCertificateAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	creds := &utils.GpCredentials{
+		CACertPath:     filepath.Join(dir, utils.CACertFileName),
+		CAKeyPath:      filepath.Join(dir, utils.CAKeyFileName),
+		ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+		ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+		ClientCertPath: filepath.Join(dir, "client-cert.pem"),
+		ClientKeyPath:  filepath.Join(dir, "client-key.pem"),
+	}
+	err = ca.Write(creds.CACertPath, creds.CAKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	err = ca.IssueLocalCertificate(utils.RoleCLI, "cdw", nil, time.Hour, creds.ClientCertPath, creds.ClientKeyPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	// issue replaces the server certificate. If keepModTime is set, the new
+	// files get the modification times of the old ones, so that the change
+	// goes unnoticed.
+	issue := func(host string, keepModTime bool) {
+		paths := []string{creds.ServerCertPath, creds.ServerKeyPath}
+		modTimes := make([]time.Time, len(paths))
+		for i, path := range paths {
+			if info, err := os.Stat(path); err == nil {
+				modTimes[i] = info.ModTime()
+			}
+		}
+
+		// Keep the modification times of successive certificates apart
+		time.Sleep(10 * time.Millisecond)
+		err := ca.IssueLocalCertificate(utils.RoleAgent, host, []string{host}, time.Hour, creds.ServerCertPath, creds.ServerKeyPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if keepModTime {
+			for i, path := range paths {
+				err = os.Chtimes(path, modTimes[i], modTimes[i])
+				if err != nil {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+			}
+		}
+	}
+	issue("sdw1", false)
+
+	serverCreds, err := creds.LoadServerCredentials(utils.RoleAgent)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	clientCreds, err := creds.LoadClientCredentials(utils.RoleCLI)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	t.Run("presents a replaced certificate on the next handshake", func(t *testing.T) {
+		err := handshakeWith(serverCreds, clientCreds, "sdw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		issue("sdw2", false)
+
+		err = handshakeWith(serverCreds, clientCreds, "sdw2")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("reloads files which appear unchanged on demand", func(t *testing.T) {
+		issue("sdw3", true)
+
+		err := handshakeWith(serverCreds, clientCreds, "sdw3")
+		if err == nil {
+			t.Fatalf("expected the previous certificate to still be presented")
+		}
+
+		// Other tests leave credentials behind whose files have since been
+		// removed, so only the outcome for these credentials is checked
+		_ = utils.ReloadCredentials()
+
+		err = handshakeWith(serverCreds, clientCreds, "sdw3")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("keeps presenting the previous certificate if the new one is incomplete", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		err := os.WriteFile(creds.ServerKeyPath, []byte("not a key"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = handshakeWith(serverCreds, clientCreds, "sdw3")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+}
@@ -3,57 +3,236 @@ package utils
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
+	"time"
 
 	"google.golang.org/grpc/credentials"
 )
 
+// Role identifies which component a set of credentials is loaded for. Each
+// role can be given its own certificate, so that the hub, the agents and the
+// CLI can be told apart by the certificate they present.
+type Role string
+
+const (
+	RoleHub   Role = "hub"
+	RoleAgent Role = "agent"
+	RoleCLI   Role = "cli"
+)
+
 type Credentials interface {
-	LoadServerCredentials() (credentials.TransportCredentials, error)
-	LoadClientCredentials() (credentials.TransportCredentials, error)
+	LoadServerCredentials(role Role) (credentials.TransportCredentials, error)
+	LoadClientCredentials(role Role) (credentials.TransportCredentials, error)
+	Certificate(role Role) (*x509.Certificate, error)
 }
 
+// GpCredentials holds the paths to the CA and the certificates of each role.
+// The role specific certificates are optional, and the server certificate is
+// used in place of any that are not configured.
 type GpCredentials struct {
 	CACertPath     string `json:"caCert"`
 	CAKeyPath      string `json:"caKey"`
 	ServerCertPath string `json:"serverCert"`
 	ServerKeyPath  string `json:"serverKey"`
+	HubCertPath    string `json:"hubCert,omitempty"`
+	HubKeyPath     string `json:"hubKey,omitempty"`
+	AgentCertPath  string `json:"agentCert,omitempty"`
+	AgentKeyPath   string `json:"agentKey,omitempty"`
+	ClientCertPath string `json:"clientCert,omitempty"`
+	ClientKeyPath  string `json:"clientKey,omitempty"`
 }
 
-func (c GpCredentials) LoadServerCredentials() (credentials.TransportCredentials, error) {
-	serverCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+// CertificatePaths returns the paths to the certificate and key presented by
+// the given role.
+func (c GpCredentials) CertificatePaths(role Role) (string, string) {
+	certPath, keyPath := c.ServerCertPath, c.ServerKeyPath
+
+	switch role {
+	case RoleHub:
+		if c.HubCertPath != "" {
+			certPath, keyPath = c.HubCertPath, c.HubKeyPath
+		}
+	case RoleAgent:
+		if c.AgentCertPath != "" {
+			certPath, keyPath = c.AgentCertPath, c.AgentKeyPath
+		}
+	case RoleCLI:
+		if c.ClientCertPath != "" {
+			certPath, keyPath = c.ClientCertPath, c.ClientKeyPath
+		}
+	}
+
+	return certPath, keyPath
+}
+
+// LoadServerCredentials only accepts clients presenting a certificate signed
+// by the configured CA. The certificate and CA are reloaded whenever their
+// files change.
+func (c GpCredentials) LoadServerCredentials(role Role) (credentials.TransportCredentials, error) {
+	certPath, keyPath := c.CertificatePaths(role)
+	reloader := reloaderFor(certPath, keyPath, c.CACertPath)
+
+	err := reloader.reloadCertificate()
 	if err != nil {
 		return nil, fmt.Errorf("could not load server credentials: %w", err)
 	}
 
-	config := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAnyClientCert,
+	err = reloader.reloadCAPool()
+	if err != nil {
+		return nil, err
 	}
-	return credentials.NewTLS(config), nil
 
+	return credentials.NewTLS(reloader.serverConfig()), nil
 }
 
-func (c GpCredentials) LoadClientCredentials() (credentials.TransportCredentials, error) {
-	caCert, err := os.ReadFile(c.CACertPath)
+// LoadClientCredentials verifies servers against the configured CA. The server
+// certificate also has to be valid for the hostname being connected to. The
+// certificate and CA are reloaded whenever their files change.
+func (c GpCredentials) LoadClientCredentials(role Role) (credentials.TransportCredentials, error) {
+	certPath, keyPath := c.CertificatePaths(role)
+	reloader := reloaderFor(certPath, keyPath, c.CACertPath)
+
+	err := reloader.reloadCAPool()
 	if err != nil {
-		return nil, fmt.Errorf("error while loading server certificate: %v", err)
+		return nil, err
 	}
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(caCert) {
-		return nil, fmt.Errorf("failed to add server CA's certificate")
+
+	err = reloader.reloadCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("error while loading %s client certificate: %v", role, err)
+	}
+
+	return credentials.NewTLS(reloader.clientConfig()), nil
+}
+
+// Certificate returns the certificate currently presented by the given role.
+func (c GpCredentials) Certificate(role Role) (*x509.Certificate, error) {
+	certPath, keyPath := c.CertificatePaths(role)
+	reloader := reloaderFor(certPath, keyPath, c.CACertPath)
+
+	reloader.reloadIfChanged()
+	cert, _ := reloader.current()
+	if cert == nil {
+		err := reloader.reloadCertificate()
+		if err != nil {
+			return nil, fmt.Errorf("could not load %s certificate: %w", role, err)
+		}
+		cert, _ = reloader.current()
 	}
 
-	clientCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s certificate %s: %w", role, certPath, err)
+	}
+
+	return leaf, nil
+}
+
+// VerifyHostnames checks that the agent certificate is valid for each of the
+// given hosts, so that a misissued certificate is caught when configuring the
+// cluster rather than on the first connection to an agent.
+func (c GpCredentials) VerifyHostnames(hostnames []string) error {
+	certPath, keyPath := c.CertificatePaths(RoleAgent)
+	agentCert, err := tls.LoadX509KeyPair(certPath, keyPath)
 	if err != nil {
-		return nil, fmt.Errorf("error while loading server certificate: %v", err)
+		return fmt.Errorf("could not load agent certificate: %w", err)
 	}
 
-	config := &tls.Config{
-		Certificates: []tls.Certificate{clientCert},
-		RootCAs:      certPool,
+	leaf, err := x509.ParseCertificate(agentCert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("could not parse agent certificate %s: %w", certPath, err)
+	}
+
+	for _, host := range hostnames {
+		err = leaf.VerifyHostname(host)
+		if err != nil {
+			return fmt.Errorf("agent certificate %s is not valid for host %s: %w", certPath, host, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyCA checks that the CA certificate can be read, is a certificate
+// authority and is currently valid.
+func (c GpCredentials) VerifyCA() error {
+	caCert, err := readCertificate(c.CACertPath)
+	if err != nil {
+		return fmt.Errorf("could not load CA certificate: %w", err)
+	}
+
+	if !caCert.IsCA {
+		return fmt.Errorf("CA certificate %s is not a certificate authority", c.CACertPath)
+	}
+
+	return CheckValidity(caCert, time.Now())
+}
+
+// VerifyCertificate checks that the certificate and key of the given role can
+// be read and belong together, and that the certificate chains up to the
+// configured CA.
+func (c GpCredentials) VerifyCertificate(role Role) error {
+	certPath, keyPath := c.CertificatePaths(role)
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return fmt.Errorf("could not load %s certificate: %w", role, err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("could not parse %s certificate %s: %w", role, certPath, err)
+	}
+
+	roots, err := loadCACertPool(c.CACertPath)
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range cert.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("could not parse %s certificate chain %s: %w", role, certPath, err)
+		}
+		intermediates.AddCert(intermediate)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("%s certificate %s is not valid for CA %s: %w", role, certPath, c.CACertPath, err)
+	}
+
+	return nil
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded certificate", path)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func loadCACertPool(caCertPath string) (*x509.CertPool, error) {
+	caCert, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading CA certificate: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
 	}
 
-	return credentials.NewTLS(config), nil
+	return certPool, nil
 }
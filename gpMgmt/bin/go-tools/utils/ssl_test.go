@@ -1,13 +1,27 @@
 package utils_test
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/greenplum-db/gpdb/gp/constants"
 
 	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc/credentials"
 )
 
 func TestLoadServerCredentials(t *testing.T) {
@@ -23,7 +37,7 @@ func TestLoadServerCredentials(t *testing.T) {
 			ServerCertPath: "./certificates/server-cert.pem",
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
-		_, err := creds.LoadServerCredentials()
+		_, err := creds.LoadServerCredentials(utils.RoleHub)
 		if err != nil {
 			t.Errorf("unexpected error %v", err)
 		}
@@ -37,7 +51,7 @@ func TestLoadServerCredentials(t *testing.T) {
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
 		creds.ServerCertPath = "/dev/null"
-		_, err := creds.LoadServerCredentials()
+		_, err := creds.LoadServerCredentials(utils.RoleHub)
 		if err == nil {
 			t.Fatalf("expected TLS error, did not receive one")
 		}
@@ -65,7 +79,7 @@ func TestLoadClientCredentials(t *testing.T) {
 			ServerCertPath: "./certificates/server-cert.pem",
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
-		_, err := creds.LoadClientCredentials()
+		_, err := creds.LoadClientCredentials(utils.RoleCLI)
 		if err != nil {
 			t.Errorf("unexpected error %v", err)
 		}
@@ -79,7 +93,7 @@ func TestLoadClientCredentials(t *testing.T) {
 			ServerKeyPath:  "./certificates/server-key.pem",
 		}
 		creds.CACertPath = "/dev/null"
-		_, err := creds.LoadClientCredentials()
+		_, err := creds.LoadClientCredentials(utils.RoleCLI)
 		if err == nil {
 			t.Fatalf("expected TLS error, did not receive one")
 		}
@@ -93,3 +107,191 @@ func TestLoadClientCredentials(t *testing.T) {
 		t.Fatalf("Cannot remove test certificates: %v", err)
 	}
 }
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeTestCA(t, dir, "ca")
+	writeTestCertificate(t, dir, "hub", ca, caKey, "cdw", "localhost")
+	writeTestCertificate(t, dir, "agent", ca, caKey, "sdw1", "sdw2")
+	writeTestCertificate(t, dir, "client", ca, caKey, "cdw")
+
+	otherCA, otherCAKey := writeTestCA(t, dir, "other-ca")
+	writeTestCertificate(t, dir, "untrusted", otherCA, otherCAKey, "cdw")
+
+	creds := &utils.GpCredentials{
+		CACertPath:     filepath.Join(dir, "ca-cert.pem"),
+		ServerCertPath: filepath.Join(dir, "hub-cert.pem"),
+		ServerKeyPath:  filepath.Join(dir, "hub-key.pem"),
+		AgentCertPath:  filepath.Join(dir, "agent-cert.pem"),
+		AgentKeyPath:   filepath.Join(dir, "agent-key.pem"),
+		ClientCertPath: filepath.Join(dir, "client-cert.pem"),
+		ClientKeyPath:  filepath.Join(dir, "client-key.pem"),
+	}
+
+	t.Run("uses the certificate of each role, falling back to the server certificate", func(t *testing.T) {
+		cases := map[utils.Role]string{
+			utils.RoleHub:   "hub",
+			utils.RoleAgent: "agent",
+			utils.RoleCLI:   "client",
+		}
+
+		for role, name := range cases {
+			certPath, keyPath := creds.CertificatePaths(role)
+			if certPath != filepath.Join(dir, name+"-cert.pem") || keyPath != filepath.Join(dir, name+"-key.pem") {
+				t.Fatalf("got %s and %s for role %s, want the %s certificate", certPath, keyPath, role, name)
+			}
+		}
+	})
+
+	t.Run("accepts clients with a certificate signed by the CA", func(t *testing.T) {
+		err := handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, "sdw1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
+	t.Run("rejects clients with a certificate not signed by the CA", func(t *testing.T) {
+		untrusted := *creds
+		untrusted.ClientCertPath = filepath.Join(dir, "untrusted-cert.pem")
+		untrusted.ClientKeyPath = filepath.Join(dir, "untrusted-key.pem")
+
+		err := handshake(t, creds, &untrusted, utils.RoleHub, utils.RoleCLI, "localhost")
+		if err == nil {
+			t.Fatalf("expected the handshake to fail")
+		}
+	})
+
+	t.Run("rejects servers whose certificate is not valid for the host", func(t *testing.T) {
+		err := handshake(t, creds, creds, utils.RoleAgent, utils.RoleHub, "sdw3")
+		if err == nil || !strings.Contains(err.Error(), "certificate is valid for sdw1, sdw2, not sdw3") {
+			t.Fatalf("got %v, want a hostname verification error", err)
+		}
+	})
+
+	t.Run("verifies the agent certificate against the hostnames", func(t *testing.T) {
+		err := creds.VerifyHostnames([]string{"sdw1", "sdw2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = creds.VerifyHostnames([]string{"sdw1", "sdw3"})
+		expected := fmt.Sprintf("agent certificate %s is not valid for host sdw3", creds.AgentCertPath)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}
+
+// handshake performs a TLS handshake between the given server and client
+// credentials, with the client connecting to host.
+func handshake(t *testing.T, server, client utils.Credentials, serverRole, clientRole utils.Role, host string) error {
+	t.Helper()
+
+	serverCreds, err := server.LoadServerCredentials(serverRole)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	clientCreds, err := client.LoadClientCredentials(clientRole)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return handshakeWith(serverCreds, clientCreds, host)
+}
+
+// handshakeWith performs a TLS handshake between already loaded credentials.
+func handshakeWith(serverCreds, clientCreds credentials.TransportCredentials, host string) error {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, _, err := serverCreds.ServerHandshake(serverConn)
+		serverConn.Close() // unblock the client if the server rejected it
+		errChan <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, clientErr := clientCreds.ClientHandshake(ctx, host, clientConn)
+	clientConn.Close()
+	serverErr := <-errChan
+
+	if clientErr != nil {
+		return clientErr
+	}
+	return serverErr
+}
+
+func writeTestCA(t *testing.T, dir string, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	writeTestPEM(t, filepath.Join(dir, name+"-cert.pem"), "CERTIFICATE", der)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	return cert, key
+}
+
+func writeTestCertificate(t *testing.T, dir string, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey, hosts ...string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	writeTestPEM(t, filepath.Join(dir, name+"-cert.pem"), "CERTIFICATE", der)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	writeTestPEM(t, filepath.Join(dir, name+"-key.pem"), "EC PRIVATE KEY", keyDER)
+}
+
+func writeTestPEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
@@ -0,0 +1,397 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TailPollInterval is how often a followed log is checked for new entries.
+var TailPollInterval = 250 * time.Millisecond
+
+// tailWindowSize bounds how far back from the end of a log its last entries
+// are looked for.
+const tailWindowSize = 1024 * 1024
+
+// csvSeverityField is the index of the event_severity field of the segment
+// CSV logs.
+const csvSeverityField = 16
+
+// logSeverities ranks the severities of the gp logs and of the segment logs,
+// so that one minimum severity can be applied to both.
+var logSeverities = map[string]int{
+	"DEBUG":    0,
+	"DEBUG1":   0,
+	"DEBUG2":   0,
+	"DEBUG3":   0,
+	"DEBUG4":   0,
+	"DEBUG5":   0,
+	"INFO":     1,
+	"NOTICE":   1,
+	"LOG":      1,
+	"WARNING":  2,
+	"ERROR":    3,
+	"FATAL":    4,
+	"CRITICAL": 4,
+	"PANIC":    5,
+}
+
+var (
+	gpLogLevel   = regexp.MustCompile(`\[(DEBUG|INFO|WARNING|ERROR|CRITICAL)\]`)
+	csvLogRecord = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`)
+)
+
+// LogEntry is a single entry of a log. Entries of the segment CSV logs may
+// span several lines.
+type LogEntry struct {
+	Severity string
+	Text     string
+}
+
+// LogFilter selects log entries of at least a minimum severity whose text
+// matches a regular expression.
+type LogFilter struct {
+	severity int
+	pattern  *regexp.Regexp
+}
+
+// NewLogFilter returns a filter for the given minimum severity and pattern,
+// either of which may be empty.
+func NewLogFilter(severity string, pattern string) (*LogFilter, error) {
+	filter := &LogFilter{severity: -1}
+
+	if severity != "" {
+		rank, ok := logSeverities[strings.ToUpper(severity)]
+		if !ok {
+			return nil, fmt.Errorf("invalid severity %q: expected one of DEBUG, INFO, LOG, WARNING, ERROR, FATAL or PANIC", severity)
+		}
+		filter.severity = rank
+	}
+
+	if pattern != "" {
+		var err error
+		filter.pattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// Match reports whether entry is selected by the filter. Entries whose
+// severity is unknown are only selected if no minimum severity was given.
+func (f *LogFilter) Match(entry LogEntry) bool {
+	if f.severity >= 0 {
+		rank, ok := logSeverities[entry.Severity]
+		if !ok || rank < f.severity {
+			return false
+		}
+	}
+
+	return f.pattern == nil || f.pattern.MatchString(entry.Text)
+}
+
+// LatestSegmentLog returns the path of the CSV log that the segment with the
+// given data directory is currently writing to.
+func LatestSegmentLog(dataDir string) (string, error) {
+	var logs []string
+	for _, dir := range []string{"log", "pg_log"} {
+		matches, err := filepath.Glob(filepath.Join(dataDir, dir, "*.csv"))
+		if err != nil {
+			return "", err
+		}
+		logs = append(logs, matches...)
+	}
+
+	if len(logs) == 0 {
+		return "", fmt.Errorf("no CSV logs found in data directory %s", dataDir)
+	}
+
+	// Log file names start with the time they were created
+	sort.Slice(logs, func(i, j int) bool {
+		return filepath.Base(logs[i]) < filepath.Base(logs[j])
+	})
+
+	return logs[len(logs)-1], nil
+}
+
+// TailOptions selects what TailLog passes on. The last Lines entries of the
+// log selected by Filter are passed first and, if Follow is set, every
+// selected entry written to the log afterwards. CSV logs are parsed as such.
+type TailOptions struct {
+	CSV    bool
+	Filter *LogFilter
+	Lines  int
+	Follow bool
+}
+
+// TailLog passes the entries of a log selected by options to send, following
+// it until ctx is done if requested. latest returns the path of the file the
+// log is currently written to, which changes when the log is rotated.
+func TailLog(ctx context.Context, latest func() (string, error), options TailOptions, send func(LogEntry) error) error {
+	filter := options.Filter
+	if filter == nil {
+		filter = &LogFilter{severity: -1}
+	}
+	selected := func(entry LogEntry) error {
+		if !filter.Match(entry) {
+			return nil
+		}
+		return send(entry)
+	}
+	lines, isCSV := options.Lines, options.CSV
+
+	path, err := latest()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", path, err)
+	}
+	defer func() {
+		file.Close()
+	}()
+
+	offset, err := tailOffset(file, lines, isCSV)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", path, err)
+	}
+	_, err = file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	if lines > 0 {
+		var last []LogEntry
+		err = readLogEntries(file, isCSV, func(entry LogEntry) error {
+			if !filter.Match(entry) {
+				return nil
+			}
+			last = append(last, entry)
+			if len(last) > lines {
+				last = last[1:]
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+
+		for _, entry := range last {
+			err = send(entry)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !options.Follow {
+		return nil
+	}
+
+	if lines <= 0 {
+		_, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+	}
+
+	for {
+		err = readLogEntries(&followReader{ctx: ctx, file: file, path: path, latest: latest}, isCSV, selected)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not read %s: %w", path, err)
+		}
+
+		// The log was rotated, so it continues from the start of a new file
+		file.Close()
+		path, err = latest()
+		if err != nil {
+			return err
+		}
+		file, err = os.Open(path)
+		if err != nil {
+			return fmt.Errorf("could not open %s: %w", path, err)
+		}
+	}
+}
+
+// tailOffset returns the offset of the first entry within the last
+// tailWindowSize bytes of file, or 0 if lines is 0.
+func tailOffset(file *os.File, lines int, isCSV bool) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if lines <= 0 || info.Size() <= tailWindowSize {
+		return 0, nil
+	}
+
+	start := info.Size() - tailWindowSize
+	reader := bufio.NewReader(io.NewSectionReader(file, start, tailWindowSize))
+
+	// Skip the partial line the window starts in, and for CSV logs any lines
+	// continuing a record which started before the window.
+	skipped, err := reader.ReadBytes('\n')
+	offset := start + int64(len(skipped))
+	for err == nil && isCSV {
+		prefix, _ := reader.Peek(len("2006-01-02 15:04:05"))
+		if csvLogRecord.Match(prefix) {
+			break
+		}
+		skipped, err = reader.ReadBytes('\n')
+		offset += int64(len(skipped))
+	}
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	return offset, nil
+}
+
+// readLogEntries passes every entry read from r to send until r is exhausted.
+func readLogEntries(r io.Reader, isCSV bool, send func(LogEntry) error) error {
+	if isCSV {
+		return readCSVLogEntries(r, send)
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			sendErr := send(LogEntry{Severity: gpLogSeverity(line), Text: line})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readCSVLogEntries(r io.Reader, send func(LogEntry) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var text bytes.Buffer
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		text.Reset()
+		writer := csv.NewWriter(&text)
+		writer.Write(record)
+		writer.Flush()
+
+		entry := LogEntry{Text: strings.TrimRight(text.String(), "\n")}
+		if len(record) > csvSeverityField {
+			entry.Severity = record[csvSeverityField]
+		}
+
+		err = send(entry)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// gpLogSeverity returns the level of a line of the gp logs, which are written
+// either as text or as JSON.
+func gpLogSeverity(line string) string {
+	if strings.HasPrefix(line, "{") {
+		var record LogRecord
+		if json.Unmarshal([]byte(line), &record) == nil {
+			return record.Level
+		}
+	}
+
+	if match := gpLogLevel.FindStringSubmatch(line); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// followReader reads a log file which is still being written to. At the end
+// of the file it waits for more to be written, until ctx is done or the log is
+// rotated to another file.
+type followReader struct {
+	ctx    context.Context
+	file   *os.File
+	path   string
+	latest func() (string, error)
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.file.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+
+		if f.rotated() {
+			// Anything written before the file was rotated is still read
+			n, err = f.file.Read(p)
+			if n > 0 || err != io.EOF {
+				return n, err
+			}
+			return 0, io.EOF
+		}
+
+		select {
+		case <-f.ctx.Done():
+			return 0, f.ctx.Err()
+		case <-time.After(TailPollInterval):
+		}
+	}
+}
+
+// rotated reports whether the log is now written to a different file than the
+// one being read.
+func (f *followReader) rotated() bool {
+	path, err := f.latest()
+	if err != nil {
+		return false
+	}
+	if path != f.path {
+		return true
+	}
+
+	// The log may have been moved aside and recreated under the same name
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	open, err := f.file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return !os.SameFile(current, open)
+}
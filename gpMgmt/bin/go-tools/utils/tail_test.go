@@ -0,0 +1,210 @@
+package utils_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestLogFilter(t *testing.T) {
+	t.Run("selects entries of at least the given severity matching the pattern", func(t *testing.T) {
+		filter, err := utils.NewLogFilter("warning", "disk")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		cases := []struct {
+			entry    utils.LogEntry
+			expected bool
+		}{
+			{utils.LogEntry{Severity: "ERROR", Text: "disk full"}, true},
+			{utils.LogEntry{Severity: "WARNING", Text: "disk almost full"}, true},
+			{utils.LogEntry{Severity: "PANIC", Text: "out of disk"}, true},
+			{utils.LogEntry{Severity: "LOG", Text: "disk ok"}, false},
+			{utils.LogEntry{Severity: "ERROR", Text: "network down"}, false},
+			{utils.LogEntry{Text: "disk of unknown severity"}, false},
+		}
+		for _, c := range cases {
+			if filter.Match(c.entry) != c.expected {
+				t.Fatalf("got %t for %+v, want %t", !c.expected, c.entry, c.expected)
+			}
+		}
+	})
+
+	t.Run("rejects an unknown severity or an invalid pattern", func(t *testing.T) {
+		_, err := utils.NewLogFilter("LOUD", "")
+		expected := `invalid severity "LOUD": expected one of DEBUG, INFO, LOG, WARNING, ERROR, FATAL or PANIC`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+
+		_, err = utils.NewLogFilter("", "(")
+		if err == nil || !strings.HasPrefix(err.Error(), `invalid pattern "(":`) {
+			t.Fatalf("got %v, want an invalid pattern error", err)
+		}
+	})
+}
+
+func TestTailLog(t *testing.T) {
+	utils.TailPollInterval = 10 * time.Millisecond
+	defer func() { utils.TailPollInterval = 250 * time.Millisecond }()
+
+	appendLog := func(t *testing.T, path string, contents string) {
+		t.Helper()
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		defer file.Close()
+
+		_, err = file.WriteString(contents)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	}
+
+	t.Run("sends the last selected entries of a gp log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_agent.log")
+		appendLog(t, path, "2023-01-01 00:00:00.000000 sdw1  [INFO] one\n"+
+			`{"timestamp":"2023-01-01T00:00:01Z","level":"WARNING","message":"two"}`+"\n"+
+			"2023-01-01 00:00:02.000000 sdw1  [ERROR] three\n"+
+			"2023-01-01 00:00:03.000000 sdw1  [INFO] four\n")
+
+		filter, err := utils.NewLogFilter("WARNING", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		var entries []utils.LogEntry
+		latest := func() (string, error) { return path, nil }
+		err = utils.TailLog(context.Background(), latest, utils.TailOptions{Filter: filter, Lines: 5}, func(entry utils.LogEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []utils.LogEntry{
+			{Severity: "WARNING", Text: `{"timestamp":"2023-01-01T00:00:01Z","level":"WARNING","message":"two"}`},
+			{Severity: "ERROR", Text: "2023-01-01 00:00:02.000000 sdw1  [ERROR] three"},
+		}
+		if !reflect.DeepEqual(entries, expected) {
+			t.Fatalf("got %+v, want %+v", entries, expected)
+		}
+	})
+
+	t.Run("parses the records of the latest segment CSV log", func(t *testing.T) {
+		dataDir := t.TempDir()
+		err := os.Mkdir(filepath.Join(dataDir, "log"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		record := func(severity string, message string) string {
+			return `2023-01-01 00:00:00.000000 UTC,"gpadmin","postgres",p1,th1,,,,,,,seg0,,,,,"` + severity + `","00000","` + message + `",,,,,,,0,,"postgres.c",1,` + "\n"
+		}
+		appendLog(t, filepath.Join(dataDir, "log", "gpdb-2023-01-01_000000.csv"), record("ERROR", "old"))
+		path := filepath.Join(dataDir, "log", "gpdb-2023-01-02_000000.csv")
+		appendLog(t, path, record("LOG", "first")+record("ERROR", "second\nline"))
+
+		latest, err := utils.LatestSegmentLog(dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if latest != path {
+			t.Fatalf("got %s, want %s", latest, path)
+		}
+
+		var entries []utils.LogEntry
+		err = utils.TailLog(context.Background(), func() (string, error) { return utils.LatestSegmentLog(dataDir) }, utils.TailOptions{CSV: true, Lines: 1}, func(entry utils.LogEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(entries) != 1 || entries[0].Severity != "ERROR" || !strings.Contains(entries[0].Text, "\"second\nline\"") {
+			t.Fatalf("got %+v, want the last record", entries)
+		}
+	})
+
+	t.Run("returns an error if a segment has no CSV logs", func(t *testing.T) {
+		dataDir := t.TempDir()
+
+		_, err := utils.LatestSegmentLog(dataDir)
+		expected := "no CSV logs found in data directory " + dataDir
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("follows a log across rotations until cancelled", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gp_agent.log")
+		appendLog(t, path, "2023-01-01 00:00:00.000000 sdw1  [INFO] old\n")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mutex sync.Mutex
+		var texts []string
+		received := func() []string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([]string(nil), texts...)
+		}
+
+		done := make(chan error)
+		go func() {
+			latest := func() (string, error) { return path, nil }
+			done <- utils.TailLog(ctx, latest, utils.TailOptions{Follow: true}, func(entry utils.LogEntry) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				texts = append(texts, entry.Text)
+				return nil
+			})
+		}()
+
+		waitFor := func(count int) {
+			t.Helper()
+			for start := time.Now(); len(received()) < count; time.Sleep(10 * time.Millisecond) {
+				if time.Since(start) > 5*time.Second {
+					t.Fatalf("got %q, want %d entries", received(), count)
+				}
+			}
+		}
+
+		// Give the tail time to reach the end of the log before writing to it
+		time.Sleep(50 * time.Millisecond)
+		appendLog(t, path, "new\n")
+		waitFor(1)
+
+		err := os.Rename(path, path+".1")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		appendLog(t, path+".1", "before rotation\n")
+		appendLog(t, path, "after rotation\n")
+		waitFor(3)
+
+		cancel()
+		err = <-done
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := []string{"new", "before rotation", "after rotation"}
+		if !reflect.DeepEqual(received(), expected) {
+			t.Fatalf("got %q, want %q", received(), expected)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+type requestIDKey struct{}
+
+// NewRequestID returns a random ID identifying one operation across the CLI,
+// the hub and the agents.
+func NewRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or the empty
+// string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// DetachedContext returns a context which carries the request ID of ctx, but
+// is not cancelled along with it. It is used for calls to the agents which
+// must complete even if the client which triggered them goes away.
+func DetachedContext(ctx context.Context) context.Context {
+	return ContextWithRequestID(context.Background(), RequestIDFromContext(ctx))
+}
+
+// requestContext adopts the request ID sent by the client, or assigns a new
+// one to requests which do not carry any.
+func requestContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(constants.RequestIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+			return ContextWithRequestID(ctx, ids[0])
+		}
+	}
+
+	return ContextWithRequestID(ctx, NewRequestID())
+}
+
+// UnaryRequestIDInterceptor makes the request ID available to the handler
+// through RequestIDFromContext. It must come before any interceptor which logs
+// or records the request.
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = requestContext(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		LogDebug(ctx, "%s returned %s after %s", info.FullMethod, grpcStatus.Code(err), time.Since(start))
+
+		return resp, err
+	}
+}
+
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := requestContext(stream.Context())
+		start := time.Now()
+		err := handler(srv, &requestIDServerStream{ServerStream: stream, ctx: ctx})
+		LogDebug(ctx, "%s returned %s after %s", info.FullMethod, grpcStatus.Code(err), time.Since(start))
+
+		return err
+	}
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientRequestIDInterceptor sends the request ID of the call context to
+// the server, or defaultID if the context does not carry one.
+func UnaryClientRequestIDInterceptor(defaultID string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx, defaultID), method, req, reply, cc, opts...)
+	}
+}
+
+func StreamClientRequestIDInterceptor(defaultID string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx, defaultID), desc, cc, method, opts...)
+	}
+}
+
+func outgoingContext(ctx context.Context, defaultID string) context.Context {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		id = defaultID
+	}
+	if id == "" {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, constants.RequestIDMetadataKey, id)
+}
+
+// The hub and agents serve many requests at once, so their log lines are
+// stamped with the request ID explicitly rather than through the log prefix.
+
+func LogInfo(ctx context.Context, format string, args ...interface{}) {
+	gplog.Info(requestPrefix(ctx)+format, args...)
+}
+
+func LogWarn(ctx context.Context, format string, args ...interface{}) {
+	gplog.Warn(requestPrefix(ctx)+format, args...)
+}
+
+func LogDebug(ctx context.Context, format string, args ...interface{}) {
+	gplog.Debug(requestPrefix(ctx)+format, args...)
+}
+
+func LogError(ctx context.Context, format string, args ...interface{}) {
+	gplog.Error(requestPrefix(ctx)+format, args...)
+}
+
+func requestPrefix(ctx context.Context) string {
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return ""
+	}
+
+	return "[request " + id + "] "
+}
@@ -0,0 +1,93 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gp/constants"
+	"github.com/greenplum-db/gpdb/gp/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	serve := func(ctx context.Context) string {
+		var id string
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = utils.RequestIDFromContext(ctx)
+			return nil, nil
+		}
+
+		_, _ = utils.UnaryRequestIDInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StopAgents"}, handler)
+
+		return id
+	}
+
+	send := func(ctx context.Context, defaultID string) []string {
+		var sent []string
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			sent = md.Get(constants.RequestIDMetadataKey)
+			return nil
+		}
+
+		_ = utils.UnaryClientRequestIDInterceptor(defaultID)(ctx, "/idl.Agent/Stop", nil, nil, nil, invoker)
+
+		return sent
+	}
+
+	t.Run("adopts the request ID sent by the client", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.RequestIDMetadataKey, "0123456789abcdef"))
+
+		id := serve(ctx)
+		if id != "0123456789abcdef" {
+			t.Fatalf("got %q, want %q", id, "0123456789abcdef")
+		}
+	})
+
+	t.Run("assigns a request ID to requests without one", func(t *testing.T) {
+		id := serve(context.Background())
+		if len(id) != 16 {
+			t.Fatalf("got %q, want a new request ID", id)
+		}
+	})
+
+	t.Run("sends the request ID of the context to the server", func(t *testing.T) {
+		ctx := utils.ContextWithRequestID(context.Background(), "0123456789abcdef")
+
+		sent := send(ctx, "fedcba9876543210")
+		if len(sent) != 1 || sent[0] != "0123456789abcdef" {
+			t.Fatalf("got %v, want %v", sent, []string{"0123456789abcdef"})
+		}
+	})
+
+	t.Run("sends the default request ID if the context has none", func(t *testing.T) {
+		sent := send(context.Background(), "fedcba9876543210")
+		if len(sent) != 1 || sent[0] != "fedcba9876543210" {
+			t.Fatalf("got %v, want %v", sent, []string{"fedcba9876543210"})
+		}
+
+		sent = send(context.Background(), "")
+		if len(sent) != 0 {
+			t.Fatalf("got %v, want no request ID", sent)
+		}
+	})
+
+	t.Run("keeps the request ID but not the cancellation of a detached context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(utils.ContextWithRequestID(context.Background(), "0123456789abcdef"), time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		detached := utils.DetachedContext(ctx)
+		if detached.Err() != nil {
+			t.Fatalf("unexpected error: %#v", detached.Err())
+		}
+		if utils.RequestIDFromContext(detached) != "0123456789abcdef" {
+			t.Fatalf("got %q, want %q", utils.RequestIDFromContext(detached), "0123456789abcdef")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+)
+
+// Version and Commit describe the build of gp. Release builds set them with
+// -ldflags "-X github.com/greenplum-db/gpdb/gp/utils.Version=<version>".
+var (
+	Version = "dev"
+	Commit  = "unknown"
+)
+
+// ProtocolVersion is the version of the protocol spoken between the CLI, the
+// hub and the agents. It must be incremented whenever the RPCs change in a way
+// that earlier builds cannot work with.
+const ProtocolVersion = 1
+
+// GetVersionInfo describes the build of gp running on this host.
+func GetVersionInfo() *idl.VersionInfo {
+	host, _ := os.Hostname()
+
+	return &idl.VersionInfo{
+		Host:            host,
+		Version:         Version,
+		Commit:          Commit,
+		ProtocolVersion: ProtocolVersion,
+	}
+}
+
+var versionLine = regexp.MustCompile(`^gp version (\S+) \(commit (\S+), protocol version (\d+)\)$`)
+
+// VersionString formats info the way gp version prints it.
+func VersionString(info *idl.VersionInfo) string {
+	return fmt.Sprintf("gp version %s (commit %s, protocol version %d)", info.Version, info.Commit, info.ProtocolVersion)
+}
+
+// BinaryVersion runs the gp binary at path to find out which version of gp it
+// is, without installing it.
+func BinaryVersion(path string) (*idl.VersionInfo, error) {
+	output, err := exec.Command(path, "version").Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not run %s: %w", path, err)
+	}
+
+	match := versionLine.FindStringSubmatch(strings.TrimSpace(string(output)))
+	if match == nil {
+		return nil, fmt.Errorf("%s is not a gp binary: unexpected output of version command %q", path, strings.TrimSpace(string(output)))
+	}
+
+	protocolVersion, err := strconv.ParseInt(match[3], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%s reported an invalid protocol version %s", path, match[3])
+	}
+
+	return &idl.VersionInfo{Version: match[1], Commit: match[2], ProtocolVersion: int32(protocolVersion)}, nil
+}
+
+// SameBuild reports whether a and b describe the same build of gp.
+func SameBuild(a *idl.VersionInfo, b *idl.VersionInfo) bool {
+	return a.Version == b.Version && a.Commit == b.Commit && a.ProtocolVersion == b.ProtocolVersion
+}
+
+// CheckVersionCompatibility returns an error if peer speaks a different
+// protocol version than this build.
+func CheckVersionCompatibility(peer *idl.VersionInfo) error {
+	if peer.ProtocolVersion != ProtocolVersion {
+		return fmt.Errorf("host %s runs gp %s with protocol version %d, which is incompatible with gp %s with protocol version %d",
+			peer.Host, peer.Version, peer.ProtocolVersion, Version, ProtocolVersion)
+	}
+
+	return nil
+}
+
+// VersionDiffers reports whether peer runs a different build of gp than this
+// one, even if it is compatible.
+func VersionDiffers(peer *idl.VersionInfo) bool {
+	return peer.Version != Version || peer.Commit != Commit
+}
@@ -0,0 +1,81 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gp/idl"
+	"github.com/greenplum-db/gpdb/gp/utils"
+)
+
+func TestCheckVersionCompatibility(t *testing.T) {
+	t.Run("accepts another build of the same protocol version", func(t *testing.T) {
+		peer := &idl.VersionInfo{Host: "sdw1", Version: "0.1.0", Commit: "abc", ProtocolVersion: utils.ProtocolVersion}
+
+		err := utils.CheckVersionCompatibility(peer)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !utils.VersionDiffers(peer) {
+			t.Fatalf("expected version %s to differ from %s", peer.Version, utils.Version)
+		}
+	})
+
+	t.Run("rejects another protocol version", func(t *testing.T) {
+		peer := &idl.VersionInfo{Host: "sdw1", Version: "9.0.0", ProtocolVersion: utils.ProtocolVersion + 1}
+
+		err := utils.CheckVersionCompatibility(peer)
+		expected := "host sdw1 runs gp 9.0.0 with protocol version 2, which is incompatible with gp dev with protocol version 1"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("does not report this build as differing", func(t *testing.T) {
+		if utils.VersionDiffers(utils.GetVersionInfo()) {
+			t.Fatalf("expected this build not to differ from itself")
+		}
+	})
+}
+
+func TestBinaryVersion(t *testing.T) {
+	writeBinary := func(t *testing.T, output string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "gp")
+		err := os.WriteFile(path, []byte("#!/bin/sh\necho '"+output+"'\n"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		return path
+	}
+
+	t.Run("reads the version of a gp binary", func(t *testing.T) {
+		expected := &idl.VersionInfo{Version: "2.0.0", Commit: "abc123", ProtocolVersion: 3}
+		path := writeBinary(t, utils.VersionString(expected))
+
+		info, err := utils.BinaryVersion(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		if !reflect.DeepEqual(info, expected) {
+			t.Fatalf("got %+v, want %+v", info, expected)
+		}
+		if !utils.SameBuild(info, expected) {
+			t.Fatalf("expected %+v to be the same build as %+v", info, expected)
+		}
+	})
+
+	t.Run("rejects a binary which is not gp", func(t *testing.T) {
+		path := writeBinary(t, "hello")
+
+		_, err := utils.BinaryVersion(path)
+		expected := path + ` is not a gp binary: unexpected output of version command "hello"`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+}